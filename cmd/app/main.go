@@ -2,21 +2,62 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"gogo-exercise/pkg/dao"
 	"gogo-exercise/pkg/server"
+	"io"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/jessevdk/go-flags"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 type Args struct {
-	HTTPAddr  string `long:"http.addr"  env:"HTTP_ADDR"  default:":8080"`
-	StorePath string `long:"store.path" env:"STORE_PATH" default:"./storage.gocache"`
+	HTTPAddr            string         `long:"http.addr"                  env:"HTTP_ADDR"                  default:":8080"`
+	TrustedProxies      []string       `long:"http.trusted-proxy"         env:"HTTP_TRUSTED_PROXIES"       env-delim:","`
+	AdminAddr           string         `long:"admin.addr"                 env:"ADMIN_ADDR"`
+	StorePath           string         `long:"store.path"                 env:"STORE_PATH"                 default:"./storage.gocache"`
+	StoreSaveInterval   time.Duration  `long:"store.save-interval"        env:"STORE_SAVE_INTERVAL"        default:"0"`
+	CommentStore        string         `long:"comment.store"              env:"COMMENT_STORE"              default:"gocache" choice:"gocache" choice:"sqlite"`
+	SQLitePath          string         `long:"sqlite.path"                env:"SQLITE_PATH"                default:"./storage.sqlite"`
+	ShutdownDelay       time.Duration  `long:"shutdown.delay"             env:"SHUTDOWN_DELAY"             default:"5s"`
+	TrashRetention      time.Duration  `long:"trash.retention"            env:"TRASH_RETENTION"            default:"720h"`
+	TrashPurgeInterval  time.Duration  `long:"trash.purge-interval"       env:"TRASH_PURGE_INTERVAL"       default:"1h"`
+	UndoLimit           int            `long:"undo.limit"                 env:"UNDO_LIMIT"                 default:"50"`
+	DescriptionMaxBytes int            `long:"task.description-max-bytes" env:"TASK_DESCRIPTION_MAX_BYTES" default:"65536"`
+	AttachmentDir       string         `long:"attachment.dir"             env:"ATTACHMENT_DIR"             default:"./attachments"`
+	AttachmentMaxBytes  int64          `long:"attachment.max-bytes"       env:"ATTACHMENT_MAX_BYTES"       default:"10485760"`
+	JWTSecret           string         `long:"jwt.secret"                 env:"JWT_SECRET"`
+	JWTTTL              time.Duration  `long:"jwt.ttl"                    env:"JWT_TTL"                    default:"24h"`
+	TaskQuota           int            `long:"task.quota"                 env:"TASK_QUOTA"                 default:"0"`
+	TenantTaskQuotas    map[string]int `long:"tenant.task-quota"          env:"TENANT_TASK_QUOTAS"         env-delim:","`
+	ReadRate            float64        `long:"ratelimit.read-rate"        env:"RATELIMIT_READ_RATE"        default:"20"`
+	ReadBurst           int            `long:"ratelimit.read-burst"       env:"RATELIMIT_READ_BURST"       default:"40"`
+	WriteRate           float64        `long:"ratelimit.write-rate"       env:"RATELIMIT_WRITE_RATE"       default:"5"`
+	WriteBurst          int            `long:"ratelimit.write-burst"      env:"RATELIMIT_WRITE_BURST"      default:"10"`
+	LogLevel            string         `long:"log.level"                  env:"LOG_LEVEL"                  default:"info"`
+	LogSampleInitial    int            `long:"log.sample-initial"         env:"LOG_SAMPLE_INITIAL"         default:"100"`
+	LogSampleThereafter int            `long:"log.sample-thereafter"      env:"LOG_SAMPLE_THEREAFTER"      default:"100"`
+	Webhooks            []string       `long:"webhook.url"                env:"WEBHOOK_URLS"               env-delim:","`
+	WebhookTimeout      time.Duration  `long:"webhook.timeout"            env:"WEBHOOK_TIMEOUT"            default:"5s"`
+	TraceExporter       string         `long:"trace.exporter"             env:"TRACE_EXPORTER"             default:"none" choice:"none" choice:"stdout" choice:"otlp"`
+	TraceFile           string         `long:"trace.file"                 env:"TRACE_FILE"`
+	TraceOTLPEndpoint   string         `long:"trace.otlp-endpoint"        env:"TRACE_OTLP_ENDPOINT"        default:"localhost:4318"`
+	TraceOTLPInsecure   bool           `long:"trace.otlp-insecure"        env:"TRACE_OTLP_INSECURE"`
+	TraceSampleRatio    float64        `long:"trace.sample-ratio"         env:"TRACE_SAMPLE_RATIO"         default:"1"`
 }
 
 func main() {
@@ -25,27 +66,145 @@ func main() {
 		panic(err)
 	}
 
-	zapLogger, err := zap.NewProduction()
+	zapLogger, err := newLogger(args)
 	if err != nil {
 		panic(err)
 	}
 	defer zapLogger.Sync()
 	logger := zapLogger.Sugar()
 
+	tracerProvider, shutdownTracing, err := newTracerProvider(args)
+	if err != nil {
+		logger.Infof("newTracerProvider failed, err=%v, exporter=%v", err, args.TraceExporter)
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
 	// setup http server
-	taskDAO := dao.NewGoCacheTaskDAO(logger)
+	cache := dao.NewGoCache()
+	taskDAO := dao.NewGoCacheTaskDAO(logger, cache)
 	if err := taskDAO.Load(args.StorePath); err != nil {
 		logger.Infof("taskDAO.Load failed, err=%v, path=%v", err, args.StorePath)
 		return
 	}
+	snapshotter := dao.NewSnapshotter(logger, taskDAO, args.StorePath, registry)
 	defer func() {
-		if err := taskDAO.Save(args.StorePath); err != nil {
-			logger.Infof("taskDAO.Save failed, err=%v, path=%v", err, args.StorePath)
+		if err := snapshotter.Save(); err != nil {
+			logger.Infof("snapshotter.Save failed, err=%v, path=%v", err, args.StorePath)
 		}
 	}()
-	httpServer := server.NewHttpServer(logger, args.HTTPAddr, taskDAO)
+	metricsTaskDAO := dao.NewMetricsTaskDAO(taskDAO, registry)
+	tracingTaskDAO := dao.NewTracingTaskDAO(metricsTaskDAO, tracerProvider)
+	revisionDAO := dao.NewGoCacheRevisionDAO(logger, cache)
+	pingers := map[string]dao.Pinger{
+		"tasks": taskDAO,
+		"store": snapshotter,
+	}
+	var commentDAO dao.CommentDAO = dao.NewGoCacheCommentDAO(logger, cache)
+	if args.CommentStore == "sqlite" {
+		db, err := dao.OpenSQLite(args.SQLitePath)
+		if err != nil {
+			logger.Infof("dao.OpenSQLite failed, err=%v, path=%v", err, args.SQLitePath)
+			return
+		}
+		defer db.Close()
+		sqliteCommentDAO, err := dao.NewSQLiteCommentDAO(logger, db)
+		if err != nil {
+			logger.Infof("dao.NewSQLiteCommentDAO failed, err=%v, path=%v", err, args.SQLitePath)
+			return
+		}
+		commentDAO = sqliteCommentDAO
+		pingers["comments"] = sqliteCommentDAO
+	}
+	blobStore, err := dao.NewLocalBlobStore(logger, args.AttachmentDir)
+	if err != nil {
+		logger.Infof("dao.NewLocalBlobStore failed, err=%v, dir=%v", err, args.AttachmentDir)
+		return
+	}
+	attachmentDAO := dao.NewBlobAttachmentDAO(logger, dao.NewGoCacheAttachmentDAO(logger, cache), blobStore)
+	timeEntryDAO := dao.NewGoCacheTimeEntryDAO(logger, cache)
+	taskShareDAO := dao.NewGoCacheTaskShareDAO(logger, cache)
+	cascadeTaskDAO := dao.NewCascadeTaskDAO(logger, tracingTaskDAO,
+		dao.Dependent[dao.RevisionDAO](revisionDAO),
+		dao.Dependent(commentDAO),
+		dao.Dependent[dao.AttachmentDAO](attachmentDAO),
+		dao.Dependent[dao.TimeEntryDAO](timeEntryDAO),
+		dao.Dependent[dao.TaskShareDAO](taskShareDAO),
+	)
+	revisionTaskDAO := dao.NewRevisionTaskDAO(logger, cascadeTaskDAO, revisionDAO)
+	quotaTaskDAO := dao.NewQuotaTaskDAO(logger, revisionTaskDAO, args.TaskQuota, args.TenantTaskQuotas)
+	boardDAO := dao.NewGoCacheBoardDAO(logger, cache)
+	wipLimitTaskDAO := dao.NewWIPLimitTaskDAO(logger, quotaTaskDAO, boardDAO)
+	ownerTaskDAO := dao.NewOwnerTaskDAO(logger, wipLimitTaskDAO, taskShareDAO)
+	registry.MustRegister(dao.NewTaskCollector(quotaTaskDAO))
+	undoLogDAO := dao.NewGoCacheUndoLogDAO(logger, cache, args.UndoLimit)
+	projectDAO := dao.NewGoCacheProjectDAO(logger, cache)
+	templateDAO := dao.NewGoCacheTemplateDAO(logger, cache)
+	userDAO := dao.NewGoCacheUserDAO(logger, cache)
+	if err := taskDAO.MigrateUsers(userDAO); err != nil {
+		logger.Infof("taskDAO.MigrateUsers failed, err=%v", err)
+		return
+	}
+	apiKeyDAO := dao.NewGoCacheAPIKeyDAO(logger, cache)
+	projectMemberDAO := dao.NewGoCacheProjectMemberDAO(logger, cache)
+	jwtSecret := []byte(args.JWTSecret)
+	if len(jwtSecret) == 0 {
+		// the tokens are signed by a random secret, so they are invalid once
+		// the server restarts
+		logger.Warnf("jwt.secret is not given, a random one is used")
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			logger.Infof("rand.Read failed, err=%v", err)
+			return
+		}
+	}
+	health := server.NewHealth(pingers)
+	serverConfig := server.Config{
+		DescriptionMaxBytes: args.DescriptionMaxBytes,
+		AttachmentMaxBytes:  args.AttachmentMaxBytes,
+		JWTSecret:           jwtSecret,
+		JWTTTL:              args.JWTTTL,
+		ReadRateLimit:       server.RateLimit{Rate: args.ReadRate, Burst: args.ReadBurst},
+		WriteRateLimit:      server.RateLimit{Rate: args.WriteRate, Burst: args.WriteBurst},
+		TrustedProxies:      args.TrustedProxies,
+		Registry:            registry,
+		ServeMetrics:        args.AdminAddr == "",
+		Health:              health,
+		TracerProvider:      tracerProvider,
+		Webhooks:            args.Webhooks,
+		WebhookTimeout:      args.WebhookTimeout,
+	}
+	httpServer := server.NewHttpServer(logger, args.HTTPAddr, serverConfig, server.Deps{
+		TaskDAO:          ownerTaskDAO,
+		RevisionDAO:      revisionDAO,
+		UndoLogDAO:       undoLogDAO,
+		CommentDAO:       commentDAO,
+		AttachmentDAO:    attachmentDAO,
+		BlobStore:        blobStore,
+		TimeEntryDAO:     timeEntryDAO,
+		ProjectDAO:       projectDAO,
+		BoardDAO:         boardDAO,
+		TemplateDAO:      templateDAO,
+		UserDAO:          userDAO,
+		APIKeyDAO:        apiKeyDAO,
+		ProjectMemberDAO: projectMemberDAO,
+		TaskShareDAO:     taskShareDAO,
+	})
+
+	// purge expired tasks in the trash
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	defer stopPurge()
+	go dao.PurgeTrashPeriodically(purgeCtx, logger, quotaTaskDAO, args.TrashRetention, args.TrashPurgeInterval)
 
-	// start to serve
+	// save snapshots, besides the one on shutdown
+	if args.StoreSaveInterval > 0 {
+		go snapshotter.SavePeriodically(purgeCtx, args.StoreSaveInterval)
+	}
+
+	// start to serve, the tasks are loaded by now
+	health.SetReady(true)
 	go func() {
 		logger.Infof("http server start listening on addr: %v", args.HTTPAddr)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -53,13 +212,28 @@ func main() {
 		}
 	}()
 
+	// serve the metrics apart from the API
+	var adminServer *http.Server
+	if args.AdminAddr != "" {
+		adminServer = server.NewAdminServer(args.AdminAddr, registry)
+		go func() {
+			logger.Infof("admin server start listening on addr: %v", args.AdminAddr)
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Errorf("adminServer.ListenAndServe failed, err=%v", err)
+			}
+		}()
+	}
+
 	// capture stop signals
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 	<-stop
 
-	// graceful shutdown
+	// graceful shutdown, the orchestrator stops routing the requests to the
+	// server once it is not ready
 	logger.Infof("start to shutdown")
+	health.SetReady(false)
+	time.Sleep(args.ShutdownDelay)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -69,4 +243,93 @@ func main() {
 	}
 
 	logger.Infof("http server closed")
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Errorf("adminServer.Shutdown failed, err=%v", err)
+		}
+	}
+
+	// flush the spans left in the batch
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Errorf("shutdownTracing failed, err=%v", err)
+	}
+}
+
+// newLogger builds the production logger at the level of args. Each second,
+// the first LogSampleInitial entries of the same message are logged and then
+// every LogSampleThereafter-th one, LogSampleInitial 0 logs all of them.
+func newLogger(args Args) (*zap.Logger, error) {
+	config := zap.NewProductionConfig()
+	if err := config.Level.UnmarshalText([]byte(args.LogLevel)); err != nil {
+		return nil, err
+	}
+	config.Sampling = nil
+	if args.LogSampleInitial > 0 {
+		config.Sampling = &zap.SamplingConfig{
+			Initial:    args.LogSampleInitial,
+			Thereafter: args.LogSampleThereafter,
+		}
+	}
+
+	// gin only prints the routes in the debug mode
+	if config.Level.Level() != zap.DebugLevel {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	return config.Build()
+}
+
+// newTracerProvider builds the provider exporting the spans by
+// TraceExporter, "none" records no spans. The stdout exporter writes the spans
+// as JSON to TraceFile, or to the stdout if it is empty, so the tracing can be
+// checked without a collector. The returned func flushes the spans and closes
+// the exporter.
+func newTracerProvider(args Args) (trace.TracerProvider, func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	closeFile := func() error { return nil }
+	switch args.TraceExporter {
+	case "stdout":
+		out := io.Writer(os.Stdout)
+		if args.TraceFile != "" {
+			file, err := os.OpenFile(args.TraceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, nil, err
+			}
+			out, closeFile = file, file.Close
+		}
+		stdoutExporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+		if err != nil {
+			closeFile()
+			return nil, nil, err
+		}
+		exporter = stdoutExporter
+	case "otlp":
+		options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(args.TraceOTLPEndpoint)}
+		if args.TraceOTLPInsecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		otlpExporter, err := otlptracehttp.New(context.Background(), options...)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter = otlpExporter
+	default:
+		return trace.NewNoopTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(args.TraceSampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("gogo"))),
+	)
+	shutdown := func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeErr := closeFile(); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+
+	return provider, shutdown, nil
 }
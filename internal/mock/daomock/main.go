@@ -1,3 +1,3 @@
 package daomock
 
-//go:generate mockgen -destination=mock.go -package=$GOPACKAGE gogo-exercise/pkg/dao TaskDAO
+//go:generate mockgen -destination=mock.go -package=$GOPACKAGE gogo-exercise/pkg/dao TaskDAO,RevisionDAO,UndoLogDAO,CommentDAO,AttachmentDAO,BlobStore,TimeEntryDAO,ProjectDAO,BoardDAO,TemplateDAO,UserDAO,APIKeyDAO,ProjectMemberDAO,TaskShareDAO
@@ -1,107 +1,1788 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: gogo-exercise/pkg/dao (interfaces: TaskDAO)
+// Source: gogo-exercise/pkg/dao (interfaces: TaskDAO,RevisionDAO,UndoLogDAO,CommentDAO,AttachmentDAO,BlobStore,TimeEntryDAO,ProjectDAO,BoardDAO,TemplateDAO,UserDAO,APIKeyDAO,ProjectMemberDAO,TaskShareDAO)
 
 // Package daomock is a generated GoMock package.
 package daomock
 
 import (
-	gomock "github.com/golang/mock/gomock"
+	context "context"
 	dao "gogo-exercise/pkg/dao"
+	io "io"
 	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	zap "go.uber.org/zap"
 )
 
-// MockTaskDAO is a mock of TaskDAO interface
+// MockTaskDAO is a mock of TaskDAO interface.
 type MockTaskDAO struct {
 	ctrl     *gomock.Controller
 	recorder *MockTaskDAOMockRecorder
 }
 
-// MockTaskDAOMockRecorder is the mock recorder for MockTaskDAO
+// MockTaskDAOMockRecorder is the mock recorder for MockTaskDAO.
 type MockTaskDAOMockRecorder struct {
 	mock *MockTaskDAO
 }
 
-// NewMockTaskDAO creates a new mock instance
+// NewMockTaskDAO creates a new mock instance.
 func NewMockTaskDAO(ctrl *gomock.Controller) *MockTaskDAO {
 	mock := &MockTaskDAO{ctrl: ctrl}
 	mock.recorder = &MockTaskDAOMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockTaskDAO) EXPECT() *MockTaskDAOMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method
-func (m *MockTaskDAO) Create(arg0 string) (dao.Task, error) {
+// Create mocks base method.
+func (m *MockTaskDAO) Create(arg0 context.Context, arg1 dao.Task) (dao.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(dao.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create
-func (mr *MockTaskDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockTaskDAOMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskDAO)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskDAO)(nil).Create), arg0, arg1)
 }
 
-// Delete mocks base method
-func (m *MockTaskDAO) Delete(arg0 int) error {
+// CreateTree mocks base method.
+func (m *MockTaskDAO) CreateTree(arg0 context.Context, arg1 dao.TaskTree) ([]dao.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "CreateTree", arg0, arg1)
+	ret0, _ := ret[0].([]dao.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTree indicates an expected call of CreateTree.
+func (mr *MockTaskDAOMockRecorder) CreateTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTree", reflect.TypeOf((*MockTaskDAO)(nil).CreateTree), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockTaskDAO) Delete(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete
-func (mr *MockTaskDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskDAOMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskDAO)(nil).Delete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskDAO)(nil).Delete), arg0, arg1)
 }
 
-// GetByID mocks base method
-func (m *MockTaskDAO) GetByID(arg0 int) (dao.Task, error) {
+// GetByID mocks base method.
+func (m *MockTaskDAO) GetByID(arg0 context.Context, arg1 int) (dao.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
 	ret0, _ := ret[0].(dao.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByID indicates an expected call of GetByID
-func (mr *MockTaskDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTaskDAOMockRecorder) GetByID(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTaskDAO)(nil).GetByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTaskDAO)(nil).GetByID), arg0, arg1)
 }
 
-// List mocks base method
-func (m *MockTaskDAO) List() ([]dao.Task, error) {
+// List mocks base method.
+func (m *MockTaskDAO) List(arg0 context.Context) ([]dao.Task, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List")
+	ret := m.ctrl.Call(m, "List", arg0)
 	ret0, _ := ret[0].([]dao.Task)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List
-func (mr *MockTaskDAOMockRecorder) List() *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockTaskDAOMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTaskDAO)(nil).List), arg0)
+}
+
+// ListTrashed mocks base method.
+func (m *MockTaskDAO) ListTrashed(arg0 context.Context) ([]dao.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrashed", arg0)
+	ret0, _ := ret[0].([]dao.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrashed indicates an expected call of ListTrashed.
+func (mr *MockTaskDAOMockRecorder) ListTrashed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrashed", reflect.TypeOf((*MockTaskDAO)(nil).ListTrashed), arg0)
+}
+
+// Purge mocks base method.
+func (m *MockTaskDAO) Purge(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockTaskDAOMockRecorder) Purge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockTaskDAO)(nil).Purge), arg0, arg1)
+}
+
+// PurgeTrashedBefore mocks base method.
+func (m *MockTaskDAO) PurgeTrashedBefore(arg0 context.Context, arg1 time.Time) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrashedBefore", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrashedBefore indicates an expected call of PurgeTrashedBefore.
+func (mr *MockTaskDAOMockRecorder) PurgeTrashedBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrashedBefore", reflect.TypeOf((*MockTaskDAO)(nil).PurgeTrashedBefore), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockTaskDAO) Restore(arg0 context.Context, arg1 int) (dao.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(dao.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTaskDAOMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTaskDAO)(nil).Restore), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockTaskDAO) Update(arg0 context.Context, arg1 *dao.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaskDAOMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskDAO)(nil).Update), arg0, arg1)
+}
+
+// WithLogger mocks base method.
+func (m *MockTaskDAO) WithLogger(arg0 *zap.SugaredLogger) dao.TaskDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.TaskDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockTaskDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockTaskDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockTaskDAO) WithTenant(arg0 string) dao.TaskDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.TaskDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockTaskDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockTaskDAO)(nil).WithTenant), arg0)
+}
+
+// MockRevisionDAO is a mock of RevisionDAO interface.
+type MockRevisionDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionDAOMockRecorder
+}
+
+// MockRevisionDAOMockRecorder is the mock recorder for MockRevisionDAO.
+type MockRevisionDAOMockRecorder struct {
+	mock *MockRevisionDAO
+}
+
+// NewMockRevisionDAO creates a new mock instance.
+func NewMockRevisionDAO(ctrl *gomock.Controller) *MockRevisionDAO {
+	mock := &MockRevisionDAO{ctrl: ctrl}
+	mock.recorder = &MockRevisionDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionDAO) EXPECT() *MockRevisionDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevisionDAO) Create(arg0 *dao.Revision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevisionDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevisionDAO)(nil).Create), arg0)
+}
+
+// DeleteByTaskID mocks base method.
+func (m *MockRevisionDAO) DeleteByTaskID(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTaskID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTaskID indicates an expected call of DeleteByTaskID.
+func (mr *MockRevisionDAOMockRecorder) DeleteByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTaskID", reflect.TypeOf((*MockRevisionDAO)(nil).DeleteByTaskID), arg0)
+}
+
+// GetByTaskIDAndNumber mocks base method.
+func (m *MockRevisionDAO) GetByTaskIDAndNumber(arg0, arg1 int) (dao.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTaskIDAndNumber", arg0, arg1)
+	ret0, _ := ret[0].(dao.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTaskIDAndNumber indicates an expected call of GetByTaskIDAndNumber.
+func (mr *MockRevisionDAOMockRecorder) GetByTaskIDAndNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTaskIDAndNumber", reflect.TypeOf((*MockRevisionDAO)(nil).GetByTaskIDAndNumber), arg0, arg1)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockRevisionDAO) ListByTaskID(arg0 int) ([]dao.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0)
+	ret0, _ := ret[0].([]dao.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockRevisionDAOMockRecorder) ListByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockRevisionDAO)(nil).ListByTaskID), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockRevisionDAO) WithLogger(arg0 *zap.SugaredLogger) dao.RevisionDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.RevisionDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockRevisionDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockRevisionDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockRevisionDAO) WithTenant(arg0 string) dao.RevisionDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.RevisionDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockRevisionDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockRevisionDAO)(nil).WithTenant), arg0)
+}
+
+// MockUndoLogDAO is a mock of UndoLogDAO interface.
+type MockUndoLogDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockUndoLogDAOMockRecorder
+}
+
+// MockUndoLogDAOMockRecorder is the mock recorder for MockUndoLogDAO.
+type MockUndoLogDAOMockRecorder struct {
+	mock *MockUndoLogDAO
+}
+
+// NewMockUndoLogDAO creates a new mock instance.
+func NewMockUndoLogDAO(ctrl *gomock.Controller) *MockUndoLogDAO {
+	mock := &MockUndoLogDAO{ctrl: ctrl}
+	mock.recorder = &MockUndoLogDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUndoLogDAO) EXPECT() *MockUndoLogDAOMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUndoLogDAO) Get(arg0 string) (dao.UndoLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(dao.UndoLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUndoLogDAOMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUndoLogDAO)(nil).Get), arg0)
+}
+
+// Save mocks base method.
+func (m *MockUndoLogDAO) Save(arg0 string, arg1 dao.UndoLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUndoLogDAOMockRecorder) Save(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUndoLogDAO)(nil).Save), arg0, arg1)
+}
+
+// WithLogger mocks base method.
+func (m *MockUndoLogDAO) WithLogger(arg0 *zap.SugaredLogger) dao.UndoLogDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.UndoLogDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockUndoLogDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockUndoLogDAO)(nil).WithLogger), arg0)
+}
+
+// MockCommentDAO is a mock of CommentDAO interface.
+type MockCommentDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentDAOMockRecorder
+}
+
+// MockCommentDAOMockRecorder is the mock recorder for MockCommentDAO.
+type MockCommentDAOMockRecorder struct {
+	mock *MockCommentDAO
+}
+
+// NewMockCommentDAO creates a new mock instance.
+func NewMockCommentDAO(ctrl *gomock.Controller) *MockCommentDAO {
+	mock := &MockCommentDAO{ctrl: ctrl}
+	mock.recorder = &MockCommentDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentDAO) EXPECT() *MockCommentDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommentDAO) Create(arg0 dao.Comment) (dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentDAO)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockCommentDAO) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentDAO)(nil).Delete), arg0)
+}
+
+// DeleteByTaskID mocks base method.
+func (m *MockCommentDAO) DeleteByTaskID(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTaskID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTaskID indicates an expected call of DeleteByTaskID.
+func (mr *MockCommentDAOMockRecorder) DeleteByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTaskID", reflect.TypeOf((*MockCommentDAO)(nil).DeleteByTaskID), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockCommentDAO) GetByID(arg0 int) (dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCommentDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCommentDAO)(nil).GetByID), arg0)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockCommentDAO) ListByTaskID(arg0 int) ([]dao.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0)
+	ret0, _ := ret[0].([]dao.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockCommentDAOMockRecorder) ListByTaskID(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTaskDAO)(nil).List))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockCommentDAO)(nil).ListByTaskID), arg0)
 }
 
-// Update mocks base method
-func (m *MockTaskDAO) Update(arg0 *dao.Task) error {
+// Update mocks base method.
+func (m *MockCommentDAO) Update(arg0 *dao.Comment) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update
-func (mr *MockTaskDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockCommentDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentDAO)(nil).Update), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockCommentDAO) WithLogger(arg0 *zap.SugaredLogger) dao.CommentDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.CommentDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockCommentDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockCommentDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockCommentDAO) WithTenant(arg0 string) dao.CommentDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.CommentDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockCommentDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockCommentDAO)(nil).WithTenant), arg0)
+}
+
+// MockAttachmentDAO is a mock of AttachmentDAO interface.
+type MockAttachmentDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentDAOMockRecorder
+}
+
+// MockAttachmentDAOMockRecorder is the mock recorder for MockAttachmentDAO.
+type MockAttachmentDAOMockRecorder struct {
+	mock *MockAttachmentDAO
+}
+
+// NewMockAttachmentDAO creates a new mock instance.
+func NewMockAttachmentDAO(ctrl *gomock.Controller) *MockAttachmentDAO {
+	mock := &MockAttachmentDAO{ctrl: ctrl}
+	mock.recorder = &MockAttachmentDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentDAO) EXPECT() *MockAttachmentDAOMockRecorder {
+	return m.recorder
+}
+
+// CountBySHA256 mocks base method.
+func (m *MockAttachmentDAO) CountBySHA256(arg0 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBySHA256", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBySHA256 indicates an expected call of CountBySHA256.
+func (mr *MockAttachmentDAOMockRecorder) CountBySHA256(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBySHA256", reflect.TypeOf((*MockAttachmentDAO)(nil).CountBySHA256), arg0)
+}
+
+// Create mocks base method.
+func (m *MockAttachmentDAO) Create(arg0 dao.Attachment) (dao.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentDAO)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentDAO) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentDAO)(nil).Delete), arg0)
+}
+
+// DeleteByTaskID mocks base method.
+func (m *MockAttachmentDAO) DeleteByTaskID(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTaskID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTaskID indicates an expected call of DeleteByTaskID.
+func (mr *MockAttachmentDAOMockRecorder) DeleteByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTaskID", reflect.TypeOf((*MockAttachmentDAO)(nil).DeleteByTaskID), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockAttachmentDAO) GetByID(arg0 int) (dao.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAttachmentDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAttachmentDAO)(nil).GetByID), arg0)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockAttachmentDAO) ListByTaskID(arg0 int) ([]dao.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0)
+	ret0, _ := ret[0].([]dao.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockAttachmentDAOMockRecorder) ListByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockAttachmentDAO)(nil).ListByTaskID), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockAttachmentDAO) WithLogger(arg0 *zap.SugaredLogger) dao.AttachmentDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.AttachmentDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockAttachmentDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockAttachmentDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockAttachmentDAO) WithTenant(arg0 string) dao.AttachmentDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.AttachmentDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockAttachmentDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockAttachmentDAO)(nil).WithTenant), arg0)
+}
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), arg0)
+}
+
+// Open mocks base method.
+func (m *MockBlobStore) Open(arg0 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockBlobStoreMockRecorder) Open(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockBlobStore)(nil).Open), arg0)
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(arg0 io.Reader) (string, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockBlobStore) WithLogger(arg0 *zap.SugaredLogger) dao.BlobStore {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.BlobStore)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockBlobStoreMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockBlobStore)(nil).WithLogger), arg0)
+}
+
+// MockTimeEntryDAO is a mock of TimeEntryDAO interface.
+type MockTimeEntryDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimeEntryDAOMockRecorder
+}
+
+// MockTimeEntryDAOMockRecorder is the mock recorder for MockTimeEntryDAO.
+type MockTimeEntryDAOMockRecorder struct {
+	mock *MockTimeEntryDAO
+}
+
+// NewMockTimeEntryDAO creates a new mock instance.
+func NewMockTimeEntryDAO(ctrl *gomock.Controller) *MockTimeEntryDAO {
+	mock := &MockTimeEntryDAO{ctrl: ctrl}
+	mock.recorder = &MockTimeEntryDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTimeEntryDAO) EXPECT() *MockTimeEntryDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTimeEntryDAO) Create(arg0 dao.TimeEntry) (dao.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTimeEntryDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTimeEntryDAO)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockTimeEntryDAO) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTimeEntryDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTimeEntryDAO)(nil).Delete), arg0)
+}
+
+// DeleteByTaskID mocks base method.
+func (m *MockTimeEntryDAO) DeleteByTaskID(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTaskID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTaskID indicates an expected call of DeleteByTaskID.
+func (mr *MockTimeEntryDAOMockRecorder) DeleteByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTaskID", reflect.TypeOf((*MockTimeEntryDAO)(nil).DeleteByTaskID), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockTimeEntryDAO) GetByID(arg0 int) (dao.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTimeEntryDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTimeEntryDAO)(nil).GetByID), arg0)
+}
+
+// List mocks base method.
+func (m *MockTimeEntryDAO) List(arg0, arg1 time.Time) ([]dao.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]dao.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTimeEntryDAOMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTimeEntryDAO)(nil).List), arg0, arg1)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockTimeEntryDAO) ListByTaskID(arg0 int) ([]dao.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0)
+	ret0, _ := ret[0].([]dao.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockTimeEntryDAOMockRecorder) ListByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockTimeEntryDAO)(nil).ListByTaskID), arg0)
+}
+
+// StartTimer mocks base method.
+func (m *MockTimeEntryDAO) StartTimer(arg0 int, arg1 string, arg2 []string) (dao.TimeEntry, *dao.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dao.TimeEntry)
+	ret1, _ := ret[1].(*dao.TimeEntry)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTimeEntryDAOMockRecorder) StartTimer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTimeEntryDAO)(nil).StartTimer), arg0, arg1, arg2)
+}
+
+// StopTimer mocks base method.
+func (m *MockTimeEntryDAO) StopTimer(arg0 int, arg1 string) (dao.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", arg0, arg1)
+	ret0, _ := ret[0].(dao.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTimeEntryDAOMockRecorder) StopTimer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTimeEntryDAO)(nil).StopTimer), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockTimeEntryDAO) Update(arg0 *dao.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTimeEntryDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTimeEntryDAO)(nil).Update), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockTimeEntryDAO) WithLogger(arg0 *zap.SugaredLogger) dao.TimeEntryDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.TimeEntryDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockTimeEntryDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockTimeEntryDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockTimeEntryDAO) WithTenant(arg0 string) dao.TimeEntryDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.TimeEntryDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockTimeEntryDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockTimeEntryDAO)(nil).WithTenant), arg0)
+}
+
+// MockProjectDAO is a mock of ProjectDAO interface.
+type MockProjectDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectDAOMockRecorder
+}
+
+// MockProjectDAOMockRecorder is the mock recorder for MockProjectDAO.
+type MockProjectDAOMockRecorder struct {
+	mock *MockProjectDAO
+}
+
+// NewMockProjectDAO creates a new mock instance.
+func NewMockProjectDAO(ctrl *gomock.Controller) *MockProjectDAO {
+	mock := &MockProjectDAO{ctrl: ctrl}
+	mock.recorder = &MockProjectDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectDAO) EXPECT() *MockProjectDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProjectDAO) Create(arg0 dao.Project) (dao.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProjectDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProjectDAO)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockProjectDAO) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProjectDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProjectDAO)(nil).Delete), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockProjectDAO) GetByID(arg0 int) (dao.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockProjectDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProjectDAO)(nil).GetByID), arg0)
+}
+
+// List mocks base method.
+func (m *MockProjectDAO) List() ([]dao.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]dao.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProjectDAOMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProjectDAO)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockProjectDAO) Update(arg0 *dao.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProjectDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProjectDAO)(nil).Update), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockProjectDAO) WithLogger(arg0 *zap.SugaredLogger) dao.ProjectDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.ProjectDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockProjectDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockProjectDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockProjectDAO) WithTenant(arg0 string) dao.ProjectDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.ProjectDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockProjectDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockProjectDAO)(nil).WithTenant), arg0)
+}
+
+// MockBoardDAO is a mock of BoardDAO interface.
+type MockBoardDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardDAOMockRecorder
+}
+
+// MockBoardDAOMockRecorder is the mock recorder for MockBoardDAO.
+type MockBoardDAOMockRecorder struct {
+	mock *MockBoardDAO
+}
+
+// NewMockBoardDAO creates a new mock instance.
+func NewMockBoardDAO(ctrl *gomock.Controller) *MockBoardDAO {
+	mock := &MockBoardDAO{ctrl: ctrl}
+	mock.recorder = &MockBoardDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardDAO) EXPECT() *MockBoardDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBoardDAO) Create(arg0 dao.Board) (dao.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardDAO)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockBoardDAO) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardDAO)(nil).Delete), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockBoardDAO) GetByID(arg0 int) (dao.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBoardDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBoardDAO)(nil).GetByID), arg0)
+}
+
+// List mocks base method.
+func (m *MockBoardDAO) List() ([]dao.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]dao.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockBoardDAOMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBoardDAO)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockBoardDAO) Update(arg0 *dao.Board) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBoardDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBoardDAO)(nil).Update), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockBoardDAO) WithLogger(arg0 *zap.SugaredLogger) dao.BoardDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.BoardDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockBoardDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockBoardDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockBoardDAO) WithTenant(arg0 string) dao.BoardDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.BoardDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockBoardDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockBoardDAO)(nil).WithTenant), arg0)
+}
+
+// MockTemplateDAO is a mock of TemplateDAO interface.
+type MockTemplateDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplateDAOMockRecorder
+}
+
+// MockTemplateDAOMockRecorder is the mock recorder for MockTemplateDAO.
+type MockTemplateDAOMockRecorder struct {
+	mock *MockTemplateDAO
+}
+
+// NewMockTemplateDAO creates a new mock instance.
+func NewMockTemplateDAO(ctrl *gomock.Controller) *MockTemplateDAO {
+	mock := &MockTemplateDAO{ctrl: ctrl}
+	mock.recorder = &MockTemplateDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplateDAO) EXPECT() *MockTemplateDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTemplateDAO) Create(arg0 dao.Template) (dao.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTemplateDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTemplateDAO)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockTemplateDAO) Delete(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTemplateDAOMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTemplateDAO)(nil).Delete), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockTemplateDAO) GetByID(arg0 int) (dao.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTemplateDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTemplateDAO)(nil).GetByID), arg0)
+}
+
+// List mocks base method.
+func (m *MockTemplateDAO) List() ([]dao.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]dao.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTemplateDAOMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTemplateDAO)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockTemplateDAO) Update(arg0 *dao.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTemplateDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTemplateDAO)(nil).Update), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockTemplateDAO) WithLogger(arg0 *zap.SugaredLogger) dao.TemplateDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.TemplateDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockTemplateDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockTemplateDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockTemplateDAO) WithTenant(arg0 string) dao.TemplateDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.TemplateDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockTemplateDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockTemplateDAO)(nil).WithTenant), arg0)
+}
+
+// MockUserDAO is a mock of UserDAO interface.
+type MockUserDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDAOMockRecorder
+}
+
+// MockUserDAOMockRecorder is the mock recorder for MockUserDAO.
+type MockUserDAOMockRecorder struct {
+	mock *MockUserDAO
+}
+
+// NewMockUserDAO creates a new mock instance.
+func NewMockUserDAO(ctrl *gomock.Controller) *MockUserDAO {
+	mock := &MockUserDAO{ctrl: ctrl}
+	mock.recorder = &MockUserDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDAO) EXPECT() *MockUserDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserDAO) Create(arg0 dao.User) (dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserDAO)(nil).Create), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockUserDAO) GetByID(arg0 int) (dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserDAO)(nil).GetByID), arg0)
+}
+
+// GetByUsername mocks base method.
+func (m *MockUserDAO) GetByUsername(arg0 string) (dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", arg0)
+	ret0, _ := ret[0].(dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockUserDAOMockRecorder) GetByUsername(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserDAO)(nil).GetByUsername), arg0)
+}
+
+// List mocks base method.
+func (m *MockUserDAO) List() ([]dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserDAOMockRecorder) List() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserDAO)(nil).List))
+}
+
+// Update mocks base method.
+func (m *MockUserDAO) Update(arg0 *dao.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserDAOMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserDAO)(nil).Update), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockUserDAO) WithLogger(arg0 *zap.SugaredLogger) dao.UserDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.UserDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockUserDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockUserDAO)(nil).WithLogger), arg0)
+}
+
+// MockAPIKeyDAO is a mock of APIKeyDAO interface.
+type MockAPIKeyDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyDAOMockRecorder
+}
+
+// MockAPIKeyDAOMockRecorder is the mock recorder for MockAPIKeyDAO.
+type MockAPIKeyDAOMockRecorder struct {
+	mock *MockAPIKeyDAO
+}
+
+// NewMockAPIKeyDAO creates a new mock instance.
+func NewMockAPIKeyDAO(ctrl *gomock.Controller) *MockAPIKeyDAO {
+	mock := &MockAPIKeyDAO{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyDAO) EXPECT() *MockAPIKeyDAOMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeyDAO) Create(arg0 dao.APIKey) (dao.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(dao.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyDAOMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeyDAO)(nil).Create), arg0)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeyDAO) GetByHash(arg0 string) (dao.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", arg0)
+	ret0, _ := ret[0].(dao.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeyDAOMockRecorder) GetByHash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeyDAO)(nil).GetByHash), arg0)
+}
+
+// GetByID mocks base method.
+func (m *MockAPIKeyDAO) GetByID(arg0 int) (dao.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0)
+	ret0, _ := ret[0].(dao.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAPIKeyDAOMockRecorder) GetByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAPIKeyDAO)(nil).GetByID), arg0)
+}
+
+// ListByUserID mocks base method.
+func (m *MockAPIKeyDAO) ListByUserID(arg0 int) ([]dao.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", arg0)
+	ret0, _ := ret[0].([]dao.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockAPIKeyDAOMockRecorder) ListByUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockAPIKeyDAO)(nil).ListByUserID), arg0)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeyDAO) Revoke(arg0 int) (dao.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", arg0)
+	ret0, _ := ret[0].(dao.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyDAOMockRecorder) Revoke(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeyDAO)(nil).Revoke), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockAPIKeyDAO) WithLogger(arg0 *zap.SugaredLogger) dao.APIKeyDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.APIKeyDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockAPIKeyDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockAPIKeyDAO)(nil).WithLogger), arg0)
+}
+
+// MockProjectMemberDAO is a mock of ProjectMemberDAO interface.
+type MockProjectMemberDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectMemberDAOMockRecorder
+}
+
+// MockProjectMemberDAOMockRecorder is the mock recorder for MockProjectMemberDAO.
+type MockProjectMemberDAOMockRecorder struct {
+	mock *MockProjectMemberDAO
+}
+
+// NewMockProjectMemberDAO creates a new mock instance.
+func NewMockProjectMemberDAO(ctrl *gomock.Controller) *MockProjectMemberDAO {
+	mock := &MockProjectMemberDAO{ctrl: ctrl}
+	mock.recorder = &MockProjectMemberDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectMemberDAO) EXPECT() *MockProjectMemberDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockProjectMemberDAO) Delete(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProjectMemberDAOMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProjectMemberDAO)(nil).Delete), arg0, arg1)
+}
+
+// DeleteByProjectID mocks base method.
+func (m *MockProjectMemberDAO) DeleteByProjectID(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByProjectID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByProjectID indicates an expected call of DeleteByProjectID.
+func (mr *MockProjectMemberDAOMockRecorder) DeleteByProjectID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByProjectID", reflect.TypeOf((*MockProjectMemberDAO)(nil).DeleteByProjectID), arg0)
+}
+
+// Get mocks base method.
+func (m *MockProjectMemberDAO) Get(arg0, arg1 int) (dao.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(dao.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProjectMemberDAOMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProjectMemberDAO)(nil).Get), arg0, arg1)
+}
+
+// ListByProjectID mocks base method.
+func (m *MockProjectMemberDAO) ListByProjectID(arg0 int) ([]dao.ProjectMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProjectID", arg0)
+	ret0, _ := ret[0].([]dao.ProjectMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProjectID indicates an expected call of ListByProjectID.
+func (mr *MockProjectMemberDAOMockRecorder) ListByProjectID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProjectID", reflect.TypeOf((*MockProjectMemberDAO)(nil).ListByProjectID), arg0)
+}
+
+// Set mocks base method.
+func (m *MockProjectMemberDAO) Set(arg0 *dao.ProjectMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockProjectMemberDAOMockRecorder) Set(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockProjectMemberDAO)(nil).Set), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockProjectMemberDAO) WithLogger(arg0 *zap.SugaredLogger) dao.ProjectMemberDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.ProjectMemberDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockProjectMemberDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockProjectMemberDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockProjectMemberDAO) WithTenant(arg0 string) dao.ProjectMemberDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.ProjectMemberDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockProjectMemberDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockProjectMemberDAO)(nil).WithTenant), arg0)
+}
+
+// MockTaskShareDAO is a mock of TaskShareDAO interface.
+type MockTaskShareDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskShareDAOMockRecorder
+}
+
+// MockTaskShareDAOMockRecorder is the mock recorder for MockTaskShareDAO.
+type MockTaskShareDAOMockRecorder struct {
+	mock *MockTaskShareDAO
+}
+
+// NewMockTaskShareDAO creates a new mock instance.
+func NewMockTaskShareDAO(ctrl *gomock.Controller) *MockTaskShareDAO {
+	mock := &MockTaskShareDAO{ctrl: ctrl}
+	mock.recorder = &MockTaskShareDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskShareDAO) EXPECT() *MockTaskShareDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTaskShareDAO) Delete(arg0, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskShareDAOMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskShareDAO)(nil).Delete), arg0, arg1)
+}
+
+// DeleteByTaskID mocks base method.
+func (m *MockTaskShareDAO) DeleteByTaskID(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTaskID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTaskID indicates an expected call of DeleteByTaskID.
+func (mr *MockTaskShareDAOMockRecorder) DeleteByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTaskID", reflect.TypeOf((*MockTaskShareDAO)(nil).DeleteByTaskID), arg0)
+}
+
+// Get mocks base method.
+func (m *MockTaskShareDAO) Get(arg0, arg1 int) (dao.TaskShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(dao.TaskShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTaskShareDAOMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTaskShareDAO)(nil).Get), arg0, arg1)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockTaskShareDAO) ListByTaskID(arg0 int) ([]dao.TaskShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0)
+	ret0, _ := ret[0].([]dao.TaskShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockTaskShareDAOMockRecorder) ListByTaskID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockTaskShareDAO)(nil).ListByTaskID), arg0)
+}
+
+// ListByUserID mocks base method.
+func (m *MockTaskShareDAO) ListByUserID(arg0 int) ([]dao.TaskShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", arg0)
+	ret0, _ := ret[0].([]dao.TaskShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockTaskShareDAOMockRecorder) ListByUserID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockTaskShareDAO)(nil).ListByUserID), arg0)
+}
+
+// Set mocks base method.
+func (m *MockTaskShareDAO) Set(arg0 *dao.TaskShare) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockTaskShareDAOMockRecorder) Set(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockTaskShareDAO)(nil).Set), arg0)
+}
+
+// WithLogger mocks base method.
+func (m *MockTaskShareDAO) WithLogger(arg0 *zap.SugaredLogger) dao.TaskShareDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithLogger", arg0)
+	ret0, _ := ret[0].(dao.TaskShareDAO)
+	return ret0
+}
+
+// WithLogger indicates an expected call of WithLogger.
+func (mr *MockTaskShareDAOMockRecorder) WithLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithLogger", reflect.TypeOf((*MockTaskShareDAO)(nil).WithLogger), arg0)
+}
+
+// WithTenant mocks base method.
+func (m *MockTaskShareDAO) WithTenant(arg0 string) dao.TaskShareDAO {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithTenant", arg0)
+	ret0, _ := ret[0].(dao.TaskShareDAO)
+	return ret0
+}
+
+// WithTenant indicates an expected call of WithTenant.
+func (mr *MockTaskShareDAOMockRecorder) WithTenant(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskDAO)(nil).Update), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTenant", reflect.TypeOf((*MockTaskShareDAO)(nil).WithTenant), arg0)
 }
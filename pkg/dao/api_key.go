@@ -0,0 +1,56 @@
+package dao
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, so they are told apart from the JWTs.
+const APIKeyPrefix = "gogo_"
+
+// APIKey is a long-lived credential of a user, only the hash of the key is
+// stored.
+type APIKey struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	// KeyHash is the hex encoded SHA-256 of the key, see HashAPIKey.
+	KeyHash string `json:"-"`
+	// CreatedAt is managed by the APIKeyDAO.
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKey returns a random key along with its hash.
+func NewAPIKey() (key string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	key = APIKeyPrefix + hex.EncodeToString(b)
+	return key, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash to look up the key by, the keys have enough
+// entropy to go without a salt.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type APIKeyDAO interface {
+	LoggerScoper[APIKeyDAO]
+
+	ListByUserID(userID int) ([]APIKey, error)
+	GetByID(id int) (APIKey, error)
+	GetByHash(hash string) (APIKey, error)
+	// Create stores a new API key, the ID and the managed timestamps are
+	// assigned by the APIKeyDAO.
+	Create(key APIKey) (APIKey, error)
+	// Revoke marks the key as revoked, the revoked keys are kept so they are
+	// still listed.
+	Revoke(id int) (APIKey, error)
+}
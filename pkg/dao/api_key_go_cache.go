@@ -0,0 +1,135 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(APIKey{})
+}
+
+const (
+	cacheKeyNextAPIKeyID = "cacheKeyNextAPIKeyID"
+	cacheKeyPrefixAPIKey = "apikey:"
+)
+
+type goCacheAPIKeyDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+}
+
+func NewGoCacheAPIKeyDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheAPIKeyDAO {
+	return &goCacheAPIKeyDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheAPIKeyDAO) WithLogger(logger *zap.SugaredLogger) APIKeyDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheAPIKeyDAO) ListByUserID(userID int) ([]APIKey, error) {
+	return dao.list(func(key APIKey) bool {
+		return key.UserID == userID
+	}), nil
+}
+
+func (dao *goCacheAPIKeyDAO) GetByID(id int) (APIKey, error) {
+	item, found := dao.cache.Get(apiKeyCacheKey(id))
+	if !found {
+		return APIKey{}, ErrResourceNotFound
+	}
+
+	key, ok := item.(APIKey)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(APIKey)")
+		return APIKey{}, errors.New("type assertion failed")
+	}
+
+	return key, nil
+}
+
+func (dao *goCacheAPIKeyDAO) GetByHash(hash string) (APIKey, error) {
+	keys := dao.list(func(key APIKey) bool {
+		return key.KeyHash == hash
+	})
+	if len(keys) == 0 {
+		return APIKey{}, ErrResourceNotFound
+	}
+
+	return keys[0], nil
+}
+
+func (dao *goCacheAPIKeyDAO) Create(key APIKey) (APIKey, error) {
+	// the counter is missing until the first key is created
+	_ = dao.cache.Add(cacheKeyNextAPIKeyID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextAPIKeyID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return APIKey{}, err
+	}
+
+	key.ID = int(id)
+	key.CreatedAt = now()
+	key.RevokedAt = nil
+	dao.cache.SetDefault(apiKeyCacheKey(key.ID), key)
+
+	return key, nil
+}
+
+func (dao *goCacheAPIKeyDAO) Revoke(id int) (APIKey, error) {
+	key, err := dao.GetByID(id)
+	if err != nil {
+		return APIKey{}, err
+	}
+
+	if key.RevokedAt == nil {
+		revokedAt := now()
+		key.RevokedAt = &revokedAt
+		dao.cache.SetDefault(apiKeyCacheKey(id), key)
+	}
+
+	return key, nil
+}
+
+// list returns the keys matched by the filter, sorted by ID ascending.
+func (dao *goCacheAPIKeyDAO) list(filter func(key APIKey) bool) []APIKey {
+	keys := make([]APIKey, 0)
+	for cacheKey, item := range dao.cache.Items() {
+		if !strings.HasPrefix(cacheKey, cacheKeyPrefixAPIKey) {
+			continue
+		}
+
+		key, ok := item.Object.(APIKey)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(APIKey)")
+			continue
+		}
+
+		if filter(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
+
+func apiKeyCacheKey(id int) string {
+	return cacheKeyPrefixAPIKey + strconv.Itoa(id)
+}
+
+var _ APIKeyDAO = (*goCacheAPIKeyDAO)(nil)
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheAPIKeyDAO", func() {
+	var (
+		dao    *goCacheAPIKeyDAO
+		secret string
+		key    APIKey
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheAPIKeyDAO(zap.NewNop().Sugar(), NewGoCache())
+
+		var hash string
+		var err error
+		secret, hash, err = NewAPIKey()
+		Expect(err).NotTo(HaveOccurred())
+		key = APIKey{UserID: 1, Name: "ci", KeyHash: hash}
+	})
+
+	It("should create keys with the prefix", func() {
+		Expect(strings.HasPrefix(secret, APIKeyPrefix)).To(BeTrue())
+		Expect(key.KeyHash).NotTo(ContainSubstring(secret))
+	})
+
+	It("should get the key by the hash of the secret", func() {
+		created, err := dao.Create(key)
+		Expect(err).NotTo(HaveOccurred())
+
+		stored, err := dao.GetByHash(HashAPIKey(secret))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored).To(Equal(created))
+
+		_, err = dao.GetByHash(HashAPIKey(secret + "0"))
+		Expect(err).To(Equal(ErrResourceNotFound))
+	})
+
+	It("should keep the revoked keys", func() {
+		created, err := dao.Create(key)
+		Expect(err).NotTo(HaveOccurred())
+
+		revoked, err := dao.Revoke(created.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revoked.RevokedAt).NotTo(BeNil())
+
+		keys, err := dao.ListByUserID(key.UserID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]APIKey{revoked}))
+	})
+})
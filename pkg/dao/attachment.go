@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"time"
+)
+
+type Attachment struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"task_id"`
+	Filename    string    `json:"filename"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	SHA256      string    `json:"sha256"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentDAO stores the metadata of attachments, the content is kept in a
+// BlobStore addressed by the SHA256 of the attachment.
+type AttachmentDAO interface {
+	TenantScoper[AttachmentDAO]
+	LoggerScoper[AttachmentDAO]
+
+	// ListByTaskID returns the attachments of the task in ascending order.
+	ListByTaskID(taskID int) ([]Attachment, error)
+	GetByID(id int) (Attachment, error)
+	// CountBySHA256 returns the number of attachments sharing the blob.
+	CountBySHA256(digest string) (int, error)
+	// Create stores a new attachment, the ID and CreatedAt are assigned by the
+	// AttachmentDAO.
+	Create(attachment Attachment) (Attachment, error)
+	Delete(id int) error
+	DeleteByTaskID(taskID int) error
+}
@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// blobAttachmentDAO decorates an AttachmentDAO to delete the blobs which are
+// no longer referred by any attachment.
+type blobAttachmentDAO struct {
+	AttachmentDAO
+	logger *zap.SugaredLogger
+	blobs  BlobStore
+	// mu is shared by the DAOs of all tenants, since the blobs are shared by
+	// the tenants too.
+	mu *sync.Mutex
+}
+
+func NewBlobAttachmentDAO(logger *zap.SugaredLogger, attachmentDAO AttachmentDAO, blobs BlobStore) *blobAttachmentDAO {
+	return &blobAttachmentDAO{
+		AttachmentDAO: attachmentDAO,
+		logger:        logger,
+		blobs:         blobs,
+		mu:            &sync.Mutex{},
+	}
+}
+
+func (dao *blobAttachmentDAO) WithTenant(tenant string) AttachmentDAO {
+	scoped := *dao
+	scoped.AttachmentDAO = ScopeTenant(dao.AttachmentDAO, tenant)
+	return &scoped
+}
+
+func (dao *blobAttachmentDAO) WithLogger(logger *zap.SugaredLogger) AttachmentDAO {
+	scoped := *dao
+	scoped.AttachmentDAO = ScopeLogger(dao.AttachmentDAO, logger)
+	scoped.blobs = ScopeLogger(dao.blobs, logger)
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *blobAttachmentDAO) Create(attachment Attachment) (Attachment, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	// the blob may be released by a deletion after it was put
+	blob, err := dao.blobs.Open(attachment.SHA256)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("open blob failed: %w", err)
+	}
+	blob.Close()
+
+	return dao.AttachmentDAO.Create(attachment)
+}
+
+func (dao *blobAttachmentDAO) Delete(id int) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	attachment, err := dao.AttachmentDAO.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := dao.AttachmentDAO.Delete(id); err != nil {
+		return err
+	}
+
+	dao.releaseBlob(attachment.SHA256)
+
+	return nil
+}
+
+func (dao *blobAttachmentDAO) DeleteByTaskID(taskID int) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	attachments, err := dao.AttachmentDAO.ListByTaskID(taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := dao.AttachmentDAO.DeleteByTaskID(taskID); err != nil {
+		return err
+	}
+
+	for i := range attachments {
+		dao.releaseBlob(attachments[i].SHA256)
+	}
+
+	return nil
+}
+
+// releaseBlob deletes the blob if it is not referred anymore, a failure is
+// only logged since the attachment has gone.
+func (dao *blobAttachmentDAO) releaseBlob(digest string) {
+	count, err := dao.AttachmentDAO.CountBySHA256(digest)
+	if err != nil {
+		dao.logger.Errorf("attachmentDAO.CountBySHA256 failed, err=%v, digest=%v", err, digest)
+		return
+	} else if count > 0 {
+		return
+	}
+
+	if err := dao.blobs.Delete(digest); err != nil {
+		dao.logger.Errorf("blobStore.Delete failed, err=%v, digest=%v", err, digest)
+	}
+}
+
+var _ AttachmentDAO = (*blobAttachmentDAO)(nil)
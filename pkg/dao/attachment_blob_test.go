@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("BlobAttachmentDAO", func() {
+	var (
+		dir    string
+		blobs  *localBlobStore
+		dao    *blobAttachmentDAO
+		digest string
+		first  Attachment
+		second Attachment
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+
+		var err error
+		dir, err = os.MkdirTemp("", "blobs")
+		Expect(err).NotTo(HaveOccurred())
+		blobs, err = NewLocalBlobStore(logger, dir)
+		Expect(err).NotTo(HaveOccurred())
+		dao = NewBlobAttachmentDAO(logger, NewGoCacheAttachmentDAO(logger, NewGoCache()), blobs)
+
+		digest, _, err = blobs.Put(strings.NewReader("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		first, err = dao.Create(Attachment{TaskID: 1, SHA256: digest})
+		Expect(err).NotTo(HaveOccurred())
+		second, err = dao.Create(Attachment{TaskID: 2, SHA256: digest})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should keep the blob referred by other attachments", func() {
+		Expect(dao.Delete(first.ID)).To(Succeed())
+
+		blob, err := blobs.Open(digest)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blob.Close()).To(Succeed())
+	})
+
+	It("should delete the blob not referred anymore", func() {
+		Expect(dao.Delete(first.ID)).To(Succeed())
+		Expect(dao.DeleteByTaskID(second.TaskID)).To(Succeed())
+
+		_, err := blobs.Open(digest)
+		Expect(err).To(Equal(ErrResourceNotFound))
+	})
+
+	It("should not create attachment if the blob is missing", func() {
+		_, err := dao.Create(Attachment{TaskID: 1, SHA256: strings.Repeat("a", 64)})
+		Expect(err).To(MatchError(ErrResourceNotFound))
+	})
+})
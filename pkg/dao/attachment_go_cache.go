@@ -0,0 +1,150 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(Attachment{})
+}
+
+const (
+	cacheKeyNextAttachmentID = "cacheKeyNextAttachmentID"
+	cacheKeyPrefixAttachment = "attachment:"
+)
+
+type goCacheAttachmentDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheAttachmentDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheAttachmentDAO {
+	return &goCacheAttachmentDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheAttachmentDAO) WithTenant(tenant string) AttachmentDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheAttachmentDAO) WithLogger(logger *zap.SugaredLogger) AttachmentDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheAttachmentDAO) ListByTaskID(taskID int) ([]Attachment, error) {
+	attachments := dao.list(func(attachment Attachment) bool {
+		return attachment.TaskID == taskID
+	})
+	return attachments, nil
+}
+
+func (dao *goCacheAttachmentDAO) GetByID(id int) (Attachment, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return Attachment{}, ErrResourceNotFound
+	}
+
+	attachment, ok := item.(Attachment)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(Attachment)")
+		return Attachment{}, errors.New("type assertion failed")
+	}
+
+	return attachment, nil
+}
+
+// CountBySHA256 counts the attachments of all tenants, since the blobs are
+// shared by them.
+func (dao *goCacheAttachmentDAO) CountBySHA256(digest string) (int, error) {
+	count := 0
+	for _, item := range dao.cache.Items() {
+		if attachment, ok := item.Object.(Attachment); ok && attachment.SHA256 == digest {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (dao *goCacheAttachmentDAO) Create(attachment Attachment) (Attachment, error) {
+	// the counter is missing until the first attachment is created
+	_ = dao.cache.Add(cacheKeyNextAttachmentID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextAttachmentID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return Attachment{}, err
+	}
+
+	attachment.ID = int(id)
+	attachment.CreatedAt = now()
+	dao.cache.SetDefault(dao.cacheKey(attachment.ID), attachment)
+
+	return attachment, nil
+}
+
+func (dao *goCacheAttachmentDAO) Delete(id int) error {
+	dao.cache.Delete(dao.cacheKey(id))
+
+	return nil
+}
+
+func (dao *goCacheAttachmentDAO) DeleteByTaskID(taskID int) error {
+	attachments := dao.list(func(attachment Attachment) bool {
+		return attachment.TaskID == taskID
+	})
+
+	for i := range attachments {
+		dao.cache.Delete(dao.cacheKey(attachments[i].ID))
+	}
+
+	return nil
+}
+
+// list returns the attachments matched by the filter, sorted by ID ascending.
+func (dao *goCacheAttachmentDAO) list(filter func(attachment Attachment) bool) []Attachment {
+	attachments := make([]Attachment, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixAttachment) {
+			continue
+		}
+
+		attachment, ok := item.Object.(Attachment)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Attachment)")
+			continue
+		}
+
+		if filter(attachment) {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].ID < attachments[j].ID
+	})
+
+	return attachments
+}
+
+func (dao *goCacheAttachmentDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + attachmentCacheKey(id)
+}
+
+func attachmentCacheKey(id int) string {
+	return cacheKeyPrefixAttachment + strconv.Itoa(id)
+}
+
+var _ AttachmentDAO = (*goCacheAttachmentDAO)(nil)
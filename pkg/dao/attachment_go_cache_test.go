@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheAttachmentDAO", func() {
+	var (
+		dao    *goCacheAttachmentDAO
+		taskID int
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheAttachmentDAO(zap.NewNop().Sugar(), NewGoCache())
+		taskID = rand.Int()
+	})
+
+	Describe("Create", func() {
+		It("should assign ID and CreatedAt", func() {
+			attachment, err := dao.Create(Attachment{TaskID: taskID, Filename: "a.png", SHA256: strings.Repeat("a", 64)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attachment.ID).To(Equal(1))
+			Expect(attachment.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+
+			stored, err := dao.GetByID(attachment.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(attachment))
+		})
+	})
+
+	Describe("ListByTaskID", func() {
+		It("should return the attachments of the task in order", func() {
+			first, err := dao.Create(Attachment{TaskID: taskID, Filename: "first"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = dao.Create(Attachment{TaskID: taskID + 1, Filename: "other"})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := dao.Create(Attachment{TaskID: taskID, Filename: "second"})
+			Expect(err).NotTo(HaveOccurred())
+
+			attachments, err := dao.ListByTaskID(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attachments).To(Equal([]Attachment{first, second}))
+		})
+	})
+
+	Describe("CountBySHA256", func() {
+		It("should count the attachments sharing the blob", func() {
+			digest := strings.Repeat("a", 64)
+			_, err := dao.Create(Attachment{TaskID: taskID, SHA256: digest})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = dao.Create(Attachment{TaskID: taskID + 1, SHA256: digest})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = dao.Create(Attachment{TaskID: taskID, SHA256: strings.Repeat("b", 64)})
+			Expect(err).NotTo(HaveOccurred())
+
+			count, err := dao.CountBySHA256(digest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+
+	Describe("DeleteByTaskID", func() {
+		It("should delete the attachments of the task only", func() {
+			_, err := dao.Create(Attachment{TaskID: taskID})
+			Expect(err).NotTo(HaveOccurred())
+			other, err := dao.Create(Attachment{TaskID: taskID + 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dao.DeleteByTaskID(taskID)).To(Succeed())
+
+			attachments, err := dao.ListByTaskID(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(attachments).To(BeEmpty())
+			_, err = dao.GetByID(other.ID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
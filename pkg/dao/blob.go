@@ -0,0 +1,37 @@
+package dao
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrInvalidDigest = errors.New("invalid digest")
+)
+
+// BlobStore stores the content of attachments. The blobs are addressed by the
+// hex encoded SHA256 of their content, so identical uploads share one blob.
+type BlobStore interface {
+	LoggerScoper[BlobStore]
+
+	// Put stores the content read from r and returns its digest and size. The
+	// content is discarded if the reading fails.
+	Put(r io.Reader) (digest string, size int64, err error)
+	// Open returns the content of the blob, the caller should close it.
+	Open(digest string) (io.ReadCloser, error)
+	Delete(digest string) error
+}
+
+func validDigest(digest string) bool {
+	if len(digest) != 64 {
+		return false
+	}
+
+	for _, r := range digest {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
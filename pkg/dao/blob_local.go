@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+)
+
+// localBlobStore keeps the blobs as files under a directory, the files are
+// sharded by the first two characters of the digest.
+type localBlobStore struct {
+	logger *zap.SugaredLogger
+	dir    string
+}
+
+func NewLocalBlobStore(logger *zap.SugaredLogger, dir string) (*localBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &localBlobStore{
+		logger: logger,
+		dir:    dir,
+	}, nil
+}
+
+func (store *localBlobStore) WithLogger(logger *zap.SugaredLogger) BlobStore {
+	scoped := *store
+	scoped.logger = logger
+	return &scoped
+}
+
+func (store *localBlobStore) Put(r io.Reader) (string, int64, error) {
+	// write to a temporary file first since the digest is unknown until the
+	// content is fully read
+	tmp, err := os.CreateTemp(store.dir, ".upload-*")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	path := store.path(digest)
+	if _, err := os.Stat(path); err == nil {
+		// the same content is stored already
+		return digest, size, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", 0, err
+	}
+
+	return digest, size, nil
+}
+
+func (store *localBlobStore) Open(digest string) (io.ReadCloser, error) {
+	if !validDigest(digest) {
+		return nil, ErrInvalidDigest
+	}
+
+	file, err := os.Open(store.path(digest))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrResourceNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (store *localBlobStore) Delete(digest string) error {
+	if !validDigest(digest) {
+		return ErrInvalidDigest
+	}
+
+	err := os.Remove(store.path(digest))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *localBlobStore) path(digest string) string {
+	return filepath.Join(store.dir, digest[:2], digest)
+}
+
+var _ BlobStore = (*localBlobStore)(nil)
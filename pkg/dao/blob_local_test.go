@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing/iotest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("LocalBlobStore", func() {
+	var (
+		dir   string
+		store *localBlobStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "blobs")
+		Expect(err).NotTo(HaveOccurred())
+		store, err = NewLocalBlobStore(zap.NewNop().Sugar(), dir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("Put", func() {
+		It("should store the content by its digest", func() {
+			digest, size, err := store.Put(strings.NewReader("hello"))
+			Expect(err).NotTo(HaveOccurred())
+			sum := sha256.Sum256([]byte("hello"))
+			Expect(digest).To(Equal(hex.EncodeToString(sum[:])))
+			Expect(size).To(Equal(int64(5)))
+
+			blob, err := store.Open(digest)
+			Expect(err).NotTo(HaveOccurred())
+			defer blob.Close()
+			content, err := io.ReadAll(blob)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal("hello"))
+		})
+
+		It("should store the same content once", func() {
+			first, _, err := store.Put(strings.NewReader("hello"))
+			Expect(err).NotTo(HaveOccurred())
+			second, _, err := store.Put(strings.NewReader("hello"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+
+			files, err := os.ReadDir(filepath.Join(dir, first[:2]))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		It("should discard the content if reading fails", func() {
+			_, _, err := store.Put(io.MultiReader(strings.NewReader("hello"), iotest.ErrReader(io.ErrUnexpectedEOF)))
+			Expect(err).To(HaveOccurred())
+
+			files, err := os.ReadDir(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(BeEmpty())
+		})
+	})
+
+	Describe("Open", func() {
+		It("should get ErrResourceNotFound if blob not exists", func() {
+			_, err := store.Open(strings.Repeat("a", 64))
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+
+		It("should get ErrInvalidDigest if digest is not sha256", func() {
+			_, err := store.Open("../../etc/passwd")
+			Expect(err).To(Equal(ErrInvalidDigest))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the blob", func() {
+			digest, _, err := store.Put(strings.NewReader("hello"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store.Delete(digest)).To(Succeed())
+
+			_, err = store.Open(digest)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+})
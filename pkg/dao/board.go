@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"time"
+)
+
+// BoardGroupBy is the task field which a board groups the tasks by.
+type BoardGroupBy string
+
+const (
+	BoardGroupByStatus BoardGroupBy = "status"
+	BoardGroupByColumn BoardGroupBy = "column"
+)
+
+// Board shows the tasks as cards in columns, the tasks of a project only if
+// ProjectID is given.
+type Board struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	ProjectID int           `json:"project_id,omitempty"`
+	GroupBy   BoardGroupBy  `json:"group_by"`
+	Columns   []BoardColumn `json:"columns"`
+	// CreatedAt and UpdatedAt are managed by the BoardDAO.
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BoardColumn holds the tasks whose grouping field equals to the Key.
+type BoardColumn struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// WIPLimit is the maximum number of cards in the column, 0 means no
+	// limit.
+	WIPLimit int `json:"wip_limit,omitempty"`
+}
+
+// BoardStatusKeys are the column keys of the boards grouping by status.
+var BoardStatusKeys = map[string]TaskStatus{
+	"incomplete": TaskStatusIncomplete,
+	"complete":   TaskStatusComplete,
+}
+
+// Shows reports whether the task is shown on the board.
+func (b Board) Shows(task Task) bool {
+	return b.ProjectID == 0 || task.ProjectID == b.ProjectID
+}
+
+// ColumnKeyOf returns the key of the column which the task is in. The tasks
+// without a custom column are in the first column.
+func (b Board) ColumnKeyOf(task Task) string {
+	if b.GroupBy == BoardGroupByStatus {
+		for key, status := range BoardStatusKeys {
+			if status == task.Status {
+				return key
+			}
+		}
+		return ""
+	}
+
+	if task.Column == "" && len(b.Columns) > 0 {
+		return b.Columns[0].Key
+	}
+	return task.Column
+}
+
+// Clone returns a deep copy of the board so that the columns are not shared.
+func (b Board) Clone() Board {
+	if b.Columns != nil {
+		b.Columns = append([]BoardColumn(nil), b.Columns...)
+	}
+	return b
+}
+
+type BoardDAO interface {
+	TenantScoper[BoardDAO]
+	LoggerScoper[BoardDAO]
+
+	List() ([]Board, error)
+	GetByID(id int) (Board, error)
+	// Create stores a new board, the ID and the managed timestamps are
+	// assigned by the BoardDAO.
+	Create(board Board) (Board, error)
+	// Update stores the board and writes the managed timestamps back to it.
+	Update(board *Board) error
+	Delete(id int) error
+}
@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(Board{})
+}
+
+const (
+	cacheKeyNextBoardID = "cacheKeyNextBoardID"
+	cacheKeyPrefixBoard = "board:"
+)
+
+type goCacheBoardDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheBoardDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheBoardDAO {
+	return &goCacheBoardDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheBoardDAO) WithTenant(tenant string) BoardDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheBoardDAO) WithLogger(logger *zap.SugaredLogger) BoardDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheBoardDAO) List() ([]Board, error) {
+	boards := make([]Board, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixBoard) {
+			continue
+		}
+
+		board, ok := item.Object.(Board)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Board)")
+			continue
+		}
+
+		boards = append(boards, board.Clone())
+	}
+
+	sort.Slice(boards, func(i, j int) bool {
+		return boards[i].ID < boards[j].ID
+	})
+
+	return boards, nil
+}
+
+func (dao *goCacheBoardDAO) GetByID(id int) (Board, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return Board{}, ErrResourceNotFound
+	}
+
+	board, ok := item.(Board)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(Board)")
+		return Board{}, errors.New("type assertion failed")
+	}
+
+	return board.Clone(), nil
+}
+
+func (dao *goCacheBoardDAO) Create(board Board) (Board, error) {
+	// the counter is missing until the first board is created
+	_ = dao.cache.Add(cacheKeyNextBoardID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextBoardID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return Board{}, err
+	}
+
+	board.ID = int(id)
+	board.CreatedAt = now()
+	board.UpdatedAt = board.CreatedAt
+	dao.cache.SetDefault(dao.cacheKey(board.ID), board.Clone())
+
+	return board, nil
+}
+
+func (dao *goCacheBoardDAO) Update(board *Board) error {
+	if board == nil {
+		return errors.New("input board is nil")
+	}
+
+	current, err := dao.GetByID(board.ID)
+	if err != nil {
+		return err
+	}
+
+	board.CreatedAt = current.CreatedAt
+	board.UpdatedAt = now()
+	dao.cache.SetDefault(dao.cacheKey(board.ID), board.Clone())
+
+	return nil
+}
+
+func (dao *goCacheBoardDAO) Delete(id int) error {
+	dao.cache.Delete(dao.cacheKey(id))
+
+	return nil
+}
+
+func (dao *goCacheBoardDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + boardCacheKey(id)
+}
+
+func boardCacheKey(id int) string {
+	return cacheKeyPrefixBoard + strconv.Itoa(id)
+}
+
+var _ BoardDAO = (*goCacheBoardDAO)(nil)
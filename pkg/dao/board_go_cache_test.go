@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheBoardDAO", func() {
+	var (
+		dao   *goCacheBoardDAO
+		board Board
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheBoardDAO(zap.NewNop().Sugar(), NewGoCache())
+		board = Board{
+			Name:    gofakeit.Noun(),
+			GroupBy: BoardGroupByColumn,
+			Columns: []BoardColumn{{Key: "todo"}, {Key: "doing", WIPLimit: 2}},
+		}
+	})
+
+	Describe("Create", func() {
+		It("should assign ID and the managed timestamps", func() {
+			created, err := dao.Create(board)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ID).To(Equal(1))
+			Expect(created.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+
+			stored, err := dao.GetByID(created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(created))
+		})
+
+		It("should not share the columns with the caller", func() {
+			created, err := dao.Create(board)
+			Expect(err).NotTo(HaveOccurred())
+			board.Columns[0].Key = "changed"
+
+			stored, err := dao.GetByID(created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Columns[0].Key).To(Equal("todo"))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should store the board", func() {
+			created, err := dao.Create(board)
+			Expect(err).NotTo(HaveOccurred())
+
+			created.Columns = append(created.Columns, BoardColumn{Key: "done"})
+			Expect(dao.Update(&created)).To(Succeed())
+
+			boards, err := dao.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(boards).To(Equal([]Board{created}))
+		})
+
+		It("should get ErrResourceNotFound if board not exists", func() {
+			err := dao.Update(&Board{ID: rand.Int()})
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidChecklistOrder = errors.New("invalid checklist order")
+)
+
+type ChecklistItem struct {
+	ID   int    `json:"id"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// AddChecklistItem appends an item to the checklist of the task.
+func (t *Task) AddChecklistItem(text string) ChecklistItem {
+	id := 1
+	for i := range t.Checklist {
+		if t.Checklist[i].ID >= id {
+			id = t.Checklist[i].ID + 1
+		}
+	}
+
+	item := ChecklistItem{
+		ID:   id,
+		Text: text,
+	}
+	t.Checklist = append(t.Checklist, item)
+
+	return item
+}
+
+// ChecklistItem returns the checklist item of the task by ID, the returned
+// item can be modified in place.
+func (t *Task) ChecklistItem(id int) (*ChecklistItem, error) {
+	for i := range t.Checklist {
+		if t.Checklist[i].ID == id {
+			return &t.Checklist[i], nil
+		}
+	}
+	return nil, ErrResourceNotFound
+}
+
+func (t *Task) DeleteChecklistItem(id int) error {
+	for i := range t.Checklist {
+		if t.Checklist[i].ID == id {
+			t.Checklist = append(t.Checklist[:i:i], t.Checklist[i+1:]...)
+			return nil
+		}
+	}
+	return ErrResourceNotFound
+}
+
+// ReorderChecklist sorts the checklist by the given item IDs, which must
+// contain every item exactly once.
+func (t *Task) ReorderChecklist(ids []int) error {
+	if len(ids) != len(t.Checklist) {
+		return ErrInvalidChecklistOrder
+	}
+
+	checklist := make([]ChecklistItem, 0, len(ids))
+	for _, id := range ids {
+		item, err := t.ChecklistItem(id)
+		if err != nil {
+			return ErrInvalidChecklistOrder
+		}
+		checklist = append(checklist, *item)
+	}
+
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return ErrInvalidChecklistOrder
+		}
+		seen[id] = true
+	}
+
+	t.Checklist = checklist
+	return nil
+}
+
+// ChecklistProgress returns the number of done items and all items.
+func (t Task) ChecklistProgress() (done, total int) {
+	for i := range t.Checklist {
+		if t.Checklist[i].Done {
+			done++
+		}
+	}
+	return done, len(t.Checklist)
+}
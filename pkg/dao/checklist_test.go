@@ -0,0 +1,67 @@
+package dao
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checklist", func() {
+	var (
+		task Task
+	)
+
+	BeforeEach(func() {
+		task = Task{ID: 1}
+		task.AddChecklistItem("first")
+		task.AddChecklistItem("second")
+		task.AddChecklistItem("third")
+	})
+
+	It("should assign increasing item IDs", func() {
+		Expect(task.Checklist).To(Equal([]ChecklistItem{
+			{ID: 1, Text: "first"},
+			{ID: 2, Text: "second"},
+			{ID: 3, Text: "third"},
+		}))
+	})
+
+	It("should modify item in place", func() {
+		item, err := task.ChecklistItem(2)
+		Expect(err).NotTo(HaveOccurred())
+		item.Done = true
+
+		done, total := task.ChecklistProgress()
+		Expect(done).To(Equal(1))
+		Expect(total).To(Equal(3))
+	})
+
+	It("should not affect the clone", func() {
+		clone := task.Clone()
+		item, err := clone.ChecklistItem(1)
+		Expect(err).NotTo(HaveOccurred())
+		item.Done = true
+
+		Expect(task.Checklist[0].Done).To(BeFalse())
+	})
+
+	It("should delete item", func() {
+		Expect(task.DeleteChecklistItem(2)).To(Succeed())
+		Expect(task.Checklist).To(HaveLen(2))
+
+		_, err := task.ChecklistItem(2)
+		Expect(err).To(Equal(ErrResourceNotFound))
+		Expect(task.DeleteChecklistItem(2)).To(Equal(ErrResourceNotFound))
+	})
+
+	It("should reorder items", func() {
+		Expect(task.ReorderChecklist([]int{3, 1, 2})).To(Succeed())
+		Expect(task.Checklist[0].Text).To(Equal("third"))
+		Expect(task.Checklist[2].Text).To(Equal("second"))
+	})
+
+	It("should reject order without every item exactly once", func() {
+		Expect(task.ReorderChecklist([]int{3, 1})).To(Equal(ErrInvalidChecklistOrder))
+		Expect(task.ReorderChecklist([]int{3, 3, 1})).To(Equal(ErrInvalidChecklistOrder))
+		Expect(task.ReorderChecklist([]int{3, 1, 4})).To(Equal(ErrInvalidChecklistOrder))
+	})
+})
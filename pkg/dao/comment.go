@@ -0,0 +1,30 @@
+package dao
+
+import (
+	"time"
+)
+
+type Comment struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"task_id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+type CommentDAO interface {
+	TenantScoper[CommentDAO]
+	LoggerScoper[CommentDAO]
+
+	// ListByTaskID returns the comments of the task in ascending order.
+	ListByTaskID(taskID int) ([]Comment, error)
+	GetByID(id int) (Comment, error)
+	// Create stores a new comment, the ID and CreatedAt are assigned by the
+	// CommentDAO.
+	Create(comment Comment) (Comment, error)
+	// Update stores the body of the comment and sets EditedAt.
+	Update(comment *Comment) error
+	Delete(id int) error
+	DeleteByTaskID(taskID int) error
+}
@@ -0,0 +1,158 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(Comment{})
+}
+
+const (
+	cacheKeyNextCommentID = "cacheKeyNextCommentID"
+	cacheKeyPrefixComment = "comment:"
+)
+
+type goCacheCommentDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheCommentDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheCommentDAO {
+	return &goCacheCommentDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheCommentDAO) WithTenant(tenant string) CommentDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheCommentDAO) WithLogger(logger *zap.SugaredLogger) CommentDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheCommentDAO) ListByTaskID(taskID int) ([]Comment, error) {
+	comments := dao.list(func(comment Comment) bool {
+		return comment.TaskID == taskID
+	})
+	return comments, nil
+}
+
+func (dao *goCacheCommentDAO) GetByID(id int) (Comment, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return Comment{}, ErrResourceNotFound
+	}
+
+	comment, ok := item.(Comment)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(Comment)")
+		return Comment{}, errors.New("type assertion failed")
+	}
+
+	return comment, nil
+}
+
+func (dao *goCacheCommentDAO) Create(comment Comment) (Comment, error) {
+	// the counter is missing until the first comment is created
+	_ = dao.cache.Add(cacheKeyNextCommentID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextCommentID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return Comment{}, err
+	}
+
+	comment.ID = int(id)
+	comment.CreatedAt = now()
+	comment.EditedAt = nil
+	dao.cache.SetDefault(dao.cacheKey(comment.ID), comment)
+
+	return comment, nil
+}
+
+func (dao *goCacheCommentDAO) Update(comment *Comment) error {
+	if comment == nil {
+		return errors.New("input comment is nil")
+	}
+
+	current, err := dao.GetByID(comment.ID)
+	if err != nil {
+		return err
+	}
+
+	editedAt := now()
+	current.Body = comment.Body
+	current.EditedAt = &editedAt
+	dao.cache.SetDefault(dao.cacheKey(current.ID), current)
+	*comment = current
+
+	return nil
+}
+
+func (dao *goCacheCommentDAO) Delete(id int) error {
+	dao.cache.Delete(dao.cacheKey(id))
+
+	return nil
+}
+
+func (dao *goCacheCommentDAO) DeleteByTaskID(taskID int) error {
+	comments := dao.list(func(comment Comment) bool {
+		return comment.TaskID == taskID
+	})
+
+	for i := range comments {
+		dao.cache.Delete(dao.cacheKey(comments[i].ID))
+	}
+
+	return nil
+}
+
+// list returns the comments matched by the filter, sorted by ID ascending.
+func (dao *goCacheCommentDAO) list(filter func(comment Comment) bool) []Comment {
+	comments := make([]Comment, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixComment) {
+			continue
+		}
+
+		comment, ok := item.Object.(Comment)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Comment)")
+			continue
+		}
+
+		if filter(comment) {
+			comments = append(comments, comment)
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].ID < comments[j].ID
+	})
+
+	return comments
+}
+
+func (dao *goCacheCommentDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + commentCacheKey(id)
+}
+
+func commentCacheKey(id int) string {
+	return cacheKeyPrefixComment + strconv.Itoa(id)
+}
+
+var _ CommentDAO = (*goCacheCommentDAO)(nil)
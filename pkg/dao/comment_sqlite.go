@@ -0,0 +1,180 @@
+package dao
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const sqliteCommentSchema = `
+CREATE TABLE IF NOT EXISTS comments (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	tenant     TEXT    NOT NULL,
+	task_id    INTEGER NOT NULL,
+	author     TEXT    NOT NULL,
+	body       TEXT    NOT NULL,
+	created_at INTEGER NOT NULL,
+	edited_at  INTEGER
+);
+CREATE INDEX IF NOT EXISTS comments_tenant_task_id ON comments (tenant, task_id);
+`
+
+const sqliteCommentColumns = "id, task_id, author, body, created_at, edited_at"
+
+// sqliteCommentDAO stores the comments in SQLite, the timestamps are kept as
+// Unix nanoseconds.
+type sqliteCommentDAO struct {
+	logger *zap.SugaredLogger
+	db     *sql.DB
+	tenant string
+}
+
+// NewSQLiteCommentDAO creates the comments table in db if it is missing.
+func NewSQLiteCommentDAO(logger *zap.SugaredLogger, db *sql.DB) (*sqliteCommentDAO, error) {
+	if _, err := db.Exec(sqliteCommentSchema); err != nil {
+		return nil, err
+	}
+
+	return &sqliteCommentDAO{
+		logger: logger,
+		db:     db,
+	}, nil
+}
+
+func (dao *sqliteCommentDAO) WithTenant(tenant string) CommentDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *sqliteCommentDAO) WithLogger(logger *zap.SugaredLogger) CommentDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *sqliteCommentDAO) Ping() error {
+	return dao.db.Ping()
+}
+
+func (dao *sqliteCommentDAO) ListByTaskID(taskID int) ([]Comment, error) {
+	rows, err := dao.db.Query("SELECT "+sqliteCommentColumns+" FROM comments WHERE tenant = ? AND task_id = ? ORDER BY id", dao.tenant, taskID)
+	if err != nil {
+		dao.logger.Errorf("db.Query failed, err=%v, taskID=%v", err, taskID)
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]Comment, 0)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			dao.logger.Errorf("rows.Scan failed, err=%v, taskID=%v", err, taskID)
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+func (dao *sqliteCommentDAO) GetByID(id int) (Comment, error) {
+	row := dao.db.QueryRow("SELECT "+sqliteCommentColumns+" FROM comments WHERE tenant = ? AND id = ?", dao.tenant, id)
+	comment, err := scanComment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Comment{}, ErrResourceNotFound
+	} else if err != nil {
+		dao.logger.Errorf("row.Scan failed, err=%v, commentID=%v", err, id)
+		return Comment{}, err
+	}
+
+	return comment, nil
+}
+
+func (dao *sqliteCommentDAO) Create(comment Comment) (Comment, error) {
+	comment.CreatedAt = now()
+	comment.EditedAt = nil
+	result, err := dao.db.Exec("INSERT INTO comments (tenant, task_id, author, body, created_at) VALUES (?, ?, ?, ?, ?)",
+		dao.tenant, comment.TaskID, comment.Author, comment.Body, comment.CreatedAt.UnixNano())
+	if err != nil {
+		dao.logger.Errorf("db.Exec failed, err=%v, taskID=%v", err, comment.TaskID)
+		return Comment{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		dao.logger.Errorf("result.LastInsertId failed, err=%v", err)
+		return Comment{}, err
+	}
+	comment.ID = int(id)
+
+	return comment, nil
+}
+
+func (dao *sqliteCommentDAO) Update(comment *Comment) error {
+	if comment == nil {
+		return errors.New("input comment is nil")
+	}
+
+	result, err := dao.db.Exec("UPDATE comments SET body = ?, edited_at = ? WHERE tenant = ? AND id = ?",
+		comment.Body, now().UnixNano(), dao.tenant, comment.ID)
+	if err != nil {
+		dao.logger.Errorf("db.Exec failed, err=%v, commentID=%v", err, comment.ID)
+		return err
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return ErrResourceNotFound
+	}
+
+	current, err := dao.GetByID(comment.ID)
+	if err != nil {
+		return err
+	}
+	*comment = current
+
+	return nil
+}
+
+func (dao *sqliteCommentDAO) Delete(id int) error {
+	if _, err := dao.db.Exec("DELETE FROM comments WHERE tenant = ? AND id = ?", dao.tenant, id); err != nil {
+		dao.logger.Errorf("db.Exec failed, err=%v, commentID=%v", err, id)
+		return err
+	}
+
+	return nil
+}
+
+func (dao *sqliteCommentDAO) DeleteByTaskID(taskID int) error {
+	if _, err := dao.db.Exec("DELETE FROM comments WHERE tenant = ? AND task_id = ?", dao.tenant, taskID); err != nil {
+		dao.logger.Errorf("db.Exec failed, err=%v, taskID=%v", err, taskID)
+		return err
+	}
+
+	return nil
+}
+
+func scanComment(row interface{ Scan(dest ...any) error }) (Comment, error) {
+	var (
+		comment   Comment
+		createdAt int64
+		editedAt  sql.NullInt64
+	)
+	if err := row.Scan(&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &createdAt, &editedAt); err != nil {
+		return Comment{}, err
+	}
+
+	comment.CreatedAt = time.Unix(0, createdAt).UTC()
+	if editedAt.Valid {
+		t := time.Unix(0, editedAt.Int64).UTC()
+		comment.EditedAt = &t
+	}
+
+	return comment, nil
+}
+
+var _ CommentDAO = (*sqliteCommentDAO)(nil)
@@ -0,0 +1,110 @@
+package dao
+
+import (
+	"database/sql"
+	"math/rand"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("SQLiteCommentDAO", func() {
+	var (
+		db     *sql.DB
+		dao    *sqliteCommentDAO
+		taskID int
+	)
+
+	BeforeEach(func() {
+		var err error
+		db, err = OpenSQLite(":memory:")
+		Expect(err).NotTo(HaveOccurred())
+		dao, err = NewSQLiteCommentDAO(zap.NewNop().Sugar(), db)
+		Expect(err).NotTo(HaveOccurred())
+		taskID = rand.Int()
+	})
+
+	AfterEach(func() {
+		Expect(db.Close()).To(Succeed())
+	})
+
+	Describe("Create", func() {
+		It("should assign ID and CreatedAt", func() {
+			comment, err := dao.Create(Comment{TaskID: taskID, Author: "alice", Body: gofakeit.Sentence(3)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comment.ID).To(Equal(1))
+			Expect(comment.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+			Expect(comment.EditedAt).To(BeNil())
+
+			stored, err := dao.GetByID(comment.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(comment))
+		})
+	})
+
+	Describe("ListByTaskID", func() {
+		It("should return the comments of the task in order", func() {
+			first, err := dao.Create(Comment{TaskID: taskID, Body: "first"})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = dao.Create(Comment{TaskID: taskID + 1, Body: "other"})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := dao.Create(Comment{TaskID: taskID, Body: "second"})
+			Expect(err).NotTo(HaveOccurred())
+
+			comments, err := dao.ListByTaskID(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(Equal([]Comment{first, second}))
+		})
+
+		It("should keep the comments of the tenants apart", func() {
+			_, err := dao.Create(Comment{TaskID: taskID, Body: "default"})
+			Expect(err).NotTo(HaveOccurred())
+
+			comments, err := dao.WithTenant("acme").ListByTaskID(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(BeEmpty())
+		})
+	})
+
+	Describe("Update", func() {
+		It("should update body and set EditedAt", func() {
+			comment, err := dao.Create(Comment{TaskID: taskID, Author: "alice", Body: "before"})
+			Expect(err).NotTo(HaveOccurred())
+
+			edited := Comment{ID: comment.ID, Body: "after"}
+			Expect(dao.Update(&edited)).To(Succeed())
+			Expect(edited.Author).To(Equal("alice"))
+			Expect(edited.Body).To(Equal("after"))
+			Expect(edited.EditedAt).NotTo(BeNil())
+		})
+
+		It("should get ErrResourceNotFound if comment not exists", func() {
+			err := dao.Update(&Comment{ID: rand.Int()})
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the comment", func() {
+			comment, err := dao.Create(Comment{TaskID: taskID, Body: gofakeit.Sentence(3)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dao.Delete(comment.ID)).To(Succeed())
+
+			_, err = dao.GetByID(comment.ID)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+
+		It("should delete the comments of the task", func() {
+			_, err := dao.Create(Comment{TaskID: taskID, Body: gofakeit.Sentence(3)})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dao.DeleteByTaskID(taskID)).To(Succeed())
+
+			comments, err := dao.ListByTaskID(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeString CustomFieldType = "string"
+	CustomFieldTypeNumber CustomFieldType = "number"
+	CustomFieldTypeEnum   CustomFieldType = "enum"
+	CustomFieldTypeDate   CustomFieldType = "date"
+	CustomFieldTypeBool   CustomFieldType = "bool"
+)
+
+// CustomFieldDateLayout is the layout of the date values, they sort in the
+// same order as the dates.
+const CustomFieldDateLayout = "2006-01-02"
+
+// CustomField defines an extra field of the tasks in a project.
+type CustomField struct {
+	Key      string          `json:"key"`
+	Name     string          `json:"name"`
+	Type     CustomFieldType `json:"type"`
+	Required bool            `json:"required"`
+	// Options are the allowed values of an enum field.
+	Options []string `json:"options,omitempty"`
+}
+
+// Validate checks the definition itself.
+func (f CustomField) Validate() error {
+	if f.Key == "" {
+		return errors.New("custom field key is required")
+	}
+
+	switch f.Type {
+	case CustomFieldTypeString, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeBool:
+		return nil
+	case CustomFieldTypeEnum:
+		if len(f.Options) == 0 {
+			return fmt.Errorf("custom field %v: options are required", f.Key)
+		}
+		return nil
+	default:
+		return fmt.Errorf("custom field %v: unsupported type %v", f.Key, f.Type)
+	}
+}
+
+// ValidateValue checks the value decoded from JSON against the definition.
+func (f CustomField) ValidateValue(value interface{}) error {
+	valid := false
+	switch f.Type {
+	case CustomFieldTypeString:
+		_, valid = value.(string)
+	case CustomFieldTypeNumber:
+		_, valid = value.(float64)
+	case CustomFieldTypeBool:
+		_, valid = value.(bool)
+	case CustomFieldTypeDate:
+		if s, ok := value.(string); ok {
+			_, err := time.Parse(CustomFieldDateLayout, s)
+			valid = err == nil
+		}
+	case CustomFieldTypeEnum:
+		if s, ok := value.(string); ok {
+			for _, option := range f.Options {
+				valid = valid || s == option
+			}
+		}
+	}
+
+	if !valid {
+		return fmt.Errorf("custom field %v: invalid %v value %v", f.Key, f.Type, value)
+	}
+	return nil
+}
+
+// ValidateFields checks the custom field values of a task against the schema
+// of its project.
+func (p Project) ValidateFields(fields map[string]interface{}) error {
+	definitions := make(map[string]CustomField, len(p.CustomFields))
+	for _, definition := range p.CustomFields {
+		definitions[definition.Key] = definition
+	}
+
+	for key, value := range fields {
+		definition, ok := definitions[key]
+		if !ok {
+			return fmt.Errorf("custom field %v: not defined", key)
+		}
+		if err := definition.ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	for _, definition := range p.CustomFields {
+		if _, ok := fields[definition.Key]; definition.Required && !ok {
+			return fmt.Errorf("custom field %v: required", definition.Key)
+		}
+	}
+
+	return nil
+}
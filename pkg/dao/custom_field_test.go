@@ -0,0 +1,72 @@
+package dao
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CustomField", func() {
+	Describe("Validate", func() {
+		It("should accept the supported types", func() {
+			for _, t := range []CustomFieldType{CustomFieldTypeString, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeBool} {
+				Expect(CustomField{Key: "k", Type: t}.Validate()).To(Succeed())
+			}
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeEnum, Options: []string{"a"}}.Validate()).To(Succeed())
+		})
+
+		It("should reject the invalid definitions", func() {
+			Expect(CustomField{Type: CustomFieldTypeString}.Validate()).NotTo(Succeed())
+			Expect(CustomField{Key: "k", Type: "color"}.Validate()).NotTo(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeEnum}.Validate()).NotTo(Succeed())
+		})
+	})
+
+	Describe("ValidateValue", func() {
+		It("should check the value by the type", func() {
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeString}.ValidateValue("x")).To(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeString}.ValidateValue(1.0)).NotTo(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeNumber}.ValidateValue(1.5)).To(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeNumber}.ValidateValue("1")).NotTo(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeBool}.ValidateValue(true)).To(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeDate}.ValidateValue("2022-02-28")).To(Succeed())
+			Expect(CustomField{Key: "k", Type: CustomFieldTypeDate}.ValidateValue("2022-02-30")).NotTo(Succeed())
+		})
+
+		It("should only accept the options of an enum", func() {
+			field := CustomField{Key: "k", Type: CustomFieldTypeEnum, Options: []string{"low", "high"}}
+			Expect(field.ValidateValue("high")).To(Succeed())
+			Expect(field.ValidateValue("medium")).NotTo(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Project.ValidateFields", func() {
+	var project Project
+
+	BeforeEach(func() {
+		project = Project{
+			CustomFields: []CustomField{
+				{Key: "estimate", Type: CustomFieldTypeNumber, Required: true},
+				{Key: "due", Type: CustomFieldTypeDate},
+			},
+		}
+	})
+
+	It("should accept the valid fields", func() {
+		Expect(project.ValidateFields(map[string]interface{}{"estimate": 3.0})).To(Succeed())
+		Expect(project.ValidateFields(map[string]interface{}{"estimate": 3.0, "due": "2022-03-01"})).To(Succeed())
+	})
+
+	It("should reject the missing required fields", func() {
+		Expect(project.ValidateFields(map[string]interface{}{"due": "2022-03-01"})).To(MatchError("custom field estimate: required"))
+	})
+
+	It("should reject the undefined fields", func() {
+		Expect(project.ValidateFields(map[string]interface{}{"estimate": 3.0, "owner": "x"})).To(MatchError("custom field owner: not defined"))
+	})
+
+	It("should reject any field without a schema", func() {
+		Expect(Project{}.ValidateFields(nil)).To(Succeed())
+		Expect(Project{}.ValidateFields(map[string]interface{}{"due": "2022-03-01"})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"go.uber.org/zap"
+)
+
+// LoggerScoper is implemented by the DAOs which log, WithLogger returns the
+// DAO logging by logger.
+type LoggerScoper[T any] interface {
+	WithLogger(logger *zap.SugaredLogger) T
+}
+
+// ScopeLogger returns the DAO logging by logger, such as the logger carrying
+// the ID of a request.
+func ScopeLogger[T LoggerScoper[T]](dao T, logger *zap.SugaredLogger) T {
+	return dao.WithLogger(logger)
+}
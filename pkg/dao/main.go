@@ -6,4 +6,7 @@ import (
 
 var (
 	ErrResourceNotFound = errors.New("resource not found")
+	// ErrPermissionDenied is returned if the resource is visible to the user
+	// but can not be changed by the user.
+	ErrPermissionDenied = errors.New("permission denied")
 )
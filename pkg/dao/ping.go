@@ -0,0 +1,14 @@
+package dao
+
+import (
+	"errors"
+)
+
+// ErrNotLoaded is returned by Ping if the store has not been loaded yet.
+var ErrNotLoaded = errors.New("store is not loaded")
+
+// Pinger is implemented by the DAOs which can tell whether they are able to
+// serve, Ping returns nil if they are.
+type Pinger interface {
+	Ping() error
+}
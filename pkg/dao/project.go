@@ -0,0 +1,48 @@
+package dao
+
+import (
+	"time"
+)
+
+// Project groups tasks, an archived project keeps its tasks but takes no new
+// ones.
+type Project struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// CustomFields is the schema of the custom fields of the tasks.
+	CustomFields []CustomField `json:"custom_fields,omitempty"`
+	// CreatedAt and UpdatedAt are managed by the ProjectDAO.
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+// Clone returns a deep copy of the project so that the custom fields are not
+// shared.
+func (p Project) Clone() Project {
+	if p.CustomFields != nil {
+		fields := make([]CustomField, len(p.CustomFields))
+		for i, field := range p.CustomFields {
+			field.Options = append([]string(nil), field.Options...)
+			fields[i] = field
+		}
+		p.CustomFields = fields
+	}
+	return p
+}
+
+type ProjectDAO interface {
+	TenantScoper[ProjectDAO]
+	LoggerScoper[ProjectDAO]
+
+	// List returns the projects in ascending order, including the archived
+	// ones.
+	List() ([]Project, error)
+	GetByID(id int) (Project, error)
+	// Create stores a new project, the ID and the managed timestamps are
+	// assigned by the ProjectDAO.
+	Create(project Project) (Project, error)
+	// Update stores the project and writes the managed timestamps back to it.
+	Update(project *Project) error
+	Delete(id int) error
+}
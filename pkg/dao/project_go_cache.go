@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(Project{})
+}
+
+const (
+	cacheKeyNextProjectID = "cacheKeyNextProjectID"
+	cacheKeyPrefixProject = "project:"
+)
+
+type goCacheProjectDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheProjectDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheProjectDAO {
+	return &goCacheProjectDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheProjectDAO) WithTenant(tenant string) ProjectDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheProjectDAO) WithLogger(logger *zap.SugaredLogger) ProjectDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheProjectDAO) List() ([]Project, error) {
+	projects := make([]Project, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixProject) {
+			continue
+		}
+
+		project, ok := item.Object.(Project)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Project)")
+			continue
+		}
+
+		projects = append(projects, project.Clone())
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].ID < projects[j].ID
+	})
+
+	return projects, nil
+}
+
+func (dao *goCacheProjectDAO) GetByID(id int) (Project, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return Project{}, ErrResourceNotFound
+	}
+
+	project, ok := item.(Project)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(Project)")
+		return Project{}, errors.New("type assertion failed")
+	}
+
+	return project.Clone(), nil
+}
+
+func (dao *goCacheProjectDAO) Create(project Project) (Project, error) {
+	// the counter is missing until the first project is created
+	_ = dao.cache.Add(cacheKeyNextProjectID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextProjectID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return Project{}, err
+	}
+
+	project.ID = int(id)
+	project.CreatedAt = now()
+	project.UpdatedAt = project.CreatedAt
+	dao.cache.SetDefault(dao.cacheKey(project.ID), project.Clone())
+
+	return project, nil
+}
+
+func (dao *goCacheProjectDAO) Update(project *Project) error {
+	if project == nil {
+		return errors.New("input project is nil")
+	}
+
+	current, err := dao.GetByID(project.ID)
+	if err != nil {
+		return err
+	}
+
+	project.CreatedAt = current.CreatedAt
+	project.UpdatedAt = now()
+	dao.cache.SetDefault(dao.cacheKey(project.ID), project.Clone())
+
+	return nil
+}
+
+func (dao *goCacheProjectDAO) Delete(id int) error {
+	dao.cache.Delete(dao.cacheKey(id))
+
+	return nil
+}
+
+func (dao *goCacheProjectDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + projectCacheKey(id)
+}
+
+func projectCacheKey(id int) string {
+	return cacheKeyPrefixProject + strconv.Itoa(id)
+}
+
+var _ ProjectDAO = (*goCacheProjectDAO)(nil)
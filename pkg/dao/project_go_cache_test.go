@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheProjectDAO", func() {
+	var dao *goCacheProjectDAO
+
+	BeforeEach(func() {
+		dao = NewGoCacheProjectDAO(zap.NewNop().Sugar(), NewGoCache())
+	})
+
+	Describe("Create", func() {
+		It("should assign ID and the managed timestamps", func() {
+			project, err := dao.Create(Project{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(project.ID).To(Equal(1))
+			Expect(project.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+			Expect(project.UpdatedAt).To(Equal(project.CreatedAt))
+
+			stored, err := dao.GetByID(project.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(project))
+		})
+	})
+
+	Describe("List", func() {
+		It("should return the projects in order", func() {
+			first, err := dao.Create(Project{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+			archivedAt := time.Now().UTC()
+			second, err := dao.Create(Project{Name: gofakeit.Noun(), ArchivedAt: &archivedAt})
+			Expect(err).NotTo(HaveOccurred())
+
+			projects, err := dao.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(projects).To(Equal([]Project{first, second}))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should keep CreatedAt and update UpdatedAt", func() {
+			project, err := dao.Create(Project{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := Project{ID: project.ID, Name: "renamed"}
+			Expect(dao.Update(&updated)).To(Succeed())
+			Expect(updated.CreatedAt).To(Equal(project.CreatedAt))
+			Expect(updated.UpdatedAt).NotTo(BeTemporally("<", project.UpdatedAt))
+
+			stored, err := dao.GetByID(project.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(updated))
+		})
+
+		It("should get ErrResourceNotFound if project not exists", func() {
+			err := dao.Update(&Project{ID: rand.Int()})
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should delete the project", func() {
+			project, err := dao.Create(Project{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dao.Delete(project.ID)).To(Succeed())
+
+			_, err = dao.GetByID(project.ID)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+})
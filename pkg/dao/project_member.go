@@ -0,0 +1,31 @@
+package dao
+
+import (
+	"time"
+)
+
+// ProjectMember grants a user the role within a project, which takes the
+// place of the role of the user for the tasks of the project.
+type ProjectMember struct {
+	ProjectID int  `json:"project_id"`
+	UserID    int  `json:"user_id"`
+	Role      Role `json:"role"`
+	// CreatedAt and UpdatedAt are managed by the ProjectMemberDAO.
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ProjectMemberDAO interface {
+	TenantScoper[ProjectMemberDAO]
+	LoggerScoper[ProjectMemberDAO]
+
+	// ListByProjectID returns the members of the project in ascending order
+	// of the user IDs.
+	ListByProjectID(projectID int) ([]ProjectMember, error)
+	Get(projectID, userID int) (ProjectMember, error)
+	// Set adds the member or updates the role of an existing one, the
+	// managed timestamps are written back to it.
+	Set(member *ProjectMember) error
+	Delete(projectID, userID int) error
+	DeleteByProjectID(projectID int) error
+}
@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(ProjectMember{})
+}
+
+const (
+	cacheKeyPrefixProjectMember = "member:"
+)
+
+type goCacheProjectMemberDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheProjectMemberDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheProjectMemberDAO {
+	return &goCacheProjectMemberDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheProjectMemberDAO) WithTenant(tenant string) ProjectMemberDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheProjectMemberDAO) WithLogger(logger *zap.SugaredLogger) ProjectMemberDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheProjectMemberDAO) ListByProjectID(projectID int) ([]ProjectMember, error) {
+	return dao.list(func(member ProjectMember) bool {
+		return member.ProjectID == projectID
+	}), nil
+}
+
+func (dao *goCacheProjectMemberDAO) Get(projectID, userID int) (ProjectMember, error) {
+	item, found := dao.cache.Get(dao.cacheKey(projectID, userID))
+	if !found {
+		return ProjectMember{}, ErrResourceNotFound
+	}
+
+	member, ok := item.(ProjectMember)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(ProjectMember)")
+		return ProjectMember{}, errors.New("type assertion failed")
+	}
+
+	return member, nil
+}
+
+func (dao *goCacheProjectMemberDAO) Set(member *ProjectMember) error {
+	if member == nil {
+		return errors.New("input member is nil")
+	}
+
+	member.UpdatedAt = now()
+	member.CreatedAt = member.UpdatedAt
+	if current, err := dao.Get(member.ProjectID, member.UserID); err == nil {
+		member.CreatedAt = current.CreatedAt
+	}
+	dao.cache.SetDefault(dao.cacheKey(member.ProjectID, member.UserID), *member)
+
+	return nil
+}
+
+func (dao *goCacheProjectMemberDAO) Delete(projectID, userID int) error {
+	dao.cache.Delete(dao.cacheKey(projectID, userID))
+
+	return nil
+}
+
+func (dao *goCacheProjectMemberDAO) DeleteByProjectID(projectID int) error {
+	members := dao.list(func(member ProjectMember) bool {
+		return member.ProjectID == projectID
+	})
+	for i := range members {
+		dao.cache.Delete(dao.cacheKey(members[i].ProjectID, members[i].UserID))
+	}
+
+	return nil
+}
+
+// list returns the members matched by the filter, sorted by the project IDs
+// and then the user IDs.
+func (dao *goCacheProjectMemberDAO) list(filter func(member ProjectMember) bool) []ProjectMember {
+	members := make([]ProjectMember, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixProjectMember) {
+			continue
+		}
+
+		member, ok := item.Object.(ProjectMember)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(ProjectMember)")
+			continue
+		}
+
+		if filter(member) {
+			members = append(members, member)
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].ProjectID != members[j].ProjectID {
+			return members[i].ProjectID < members[j].ProjectID
+		}
+		return members[i].UserID < members[j].UserID
+	})
+
+	return members
+}
+
+func (dao *goCacheProjectMemberDAO) cacheKey(projectID, userID int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + projectMemberCacheKey(projectID, userID)
+}
+
+func projectMemberCacheKey(projectID, userID int) string {
+	return fmt.Sprintf("%v%v:%v", cacheKeyPrefixProjectMember, projectID, userID)
+}
+
+var _ ProjectMemberDAO = (*goCacheProjectMemberDAO)(nil)
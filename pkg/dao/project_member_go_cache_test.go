@@ -0,0 +1,85 @@
+package dao
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheProjectMemberDAO", func() {
+	var (
+		dao *goCacheProjectMemberDAO
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheProjectMemberDAO(zap.NewNop().Sugar(), NewGoCache())
+	})
+
+	Describe("Set", func() {
+		It("should add the member", func() {
+			member := ProjectMember{ProjectID: 1, UserID: 2, Role: RoleEditor}
+			Expect(dao.Set(&member)).To(Succeed())
+			Expect(member.CreatedAt).NotTo(BeZero())
+
+			stored, err := dao.Get(1, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(member))
+		})
+
+		It("should update the role and keep the creation time", func() {
+			member := ProjectMember{ProjectID: 1, UserID: 2, Role: RoleEditor}
+			Expect(dao.Set(&member)).To(Succeed())
+			createdAt := member.CreatedAt
+
+			member = ProjectMember{ProjectID: 1, UserID: 2, Role: RoleViewer}
+			Expect(dao.Set(&member)).To(Succeed())
+
+			stored, err := dao.Get(1, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Role).To(Equal(RoleViewer))
+			Expect(stored.CreatedAt).To(Equal(createdAt))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should get ErrResourceNotFound if the user is not a member", func() {
+			_, err := dao.Get(1, 2)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+
+	Describe("DeleteByProjectID", func() {
+		It("should only delete the members of the project", func() {
+			for _, member := range []ProjectMember{
+				{ProjectID: 1, UserID: 3, Role: RoleViewer},
+				{ProjectID: 1, UserID: 2, Role: RoleEditor},
+				{ProjectID: 2, UserID: 2, Role: RoleAdmin},
+			} {
+				member := member
+				Expect(dao.Set(&member)).To(Succeed())
+			}
+
+			members, err := dao.ListByProjectID(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(HaveLen(2))
+			Expect(members[0].UserID).To(Equal(2))
+
+			Expect(dao.DeleteByProjectID(1)).To(Succeed())
+			members, err = dao.ListByProjectID(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(members).To(BeEmpty())
+
+			_, err = dao.Get(2, 2)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Role", func() {
+	It("should include the lower roles", func() {
+		Expect(RoleAdmin.Includes(RoleEditor)).To(BeTrue())
+		Expect(RoleEditor.Includes(RoleEditor)).To(BeTrue())
+		Expect(RoleViewer.Includes(RoleEditor)).To(BeFalse())
+		Expect(Role("owner").Includes(RoleViewer)).To(BeFalse())
+	})
+})
@@ -0,0 +1,92 @@
+package dao
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankDigits are the digits of the ranks in ascending order.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var (
+	ErrInvalidRank = errors.New("no rank between the given ranks")
+)
+
+// RankBetween returns a rank which sorts after a and before b, so an item is
+// moved without renumbering the others. The empty a and b are the lower and
+// upper ends. It fails with ErrInvalidRank if a does not sort before b.
+//
+// The generated ranks never end with the lowest digit, so there is always a
+// rank before any of them. Appending, with the empty b, keeps the ranks short,
+// see rankAfter.
+func RankBetween(a, b string) (string, error) {
+	if b == "" {
+		return rankAfter(a), nil
+	}
+	if a >= b {
+		return "", ErrInvalidRank
+	}
+
+	rank := make([]byte, 0, len(a)+1)
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(a) {
+			lo = rankDigitIndex(a[i])
+		}
+
+		if i >= len(b) {
+			// b ends with the lowest digit, nothing sorts between
+			return "", ErrInvalidRank
+		}
+
+		hi := rankDigitIndex(b[i])
+		if hi-lo > 1 {
+			return string(append(rank, rankDigits[(lo+hi)/2])), nil
+		}
+
+		rank = append(rank, rankDigits[lo])
+		if hi-lo == 1 {
+			// the rank sorts before b already, the rest only has to sort
+			// after the rest of a
+			var rest string
+			if i+1 < len(a) {
+				rest = a[i+1:]
+			}
+			return string(rank) + rankAfter(rest), nil
+		}
+	}
+}
+
+// rankAfter returns a rank which sorts after a. It counts up at the length of
+// a, and once a is all the highest digits it doubles the length to count on,
+// so n appended ranks take O(log n) digits.
+func rankAfter(a string) string {
+	for i := len(a) - 1; i >= 0; i-- {
+		if d := rankDigitIndex(a[i]); d+1 < len(rankDigits) {
+			rank := a[:i] + string(rankDigits[d+1])
+			if i == len(a)-1 {
+				return rank
+			}
+			// the digits after i carry over to the lowest digit, the last one
+			// is bumped so that the rank keeps its length and does not end
+			// with the lowest digit
+			return rank + strings.Repeat(string(rankDigits[0]), len(a)-i-2) + string(rankDigits[1])
+		}
+	}
+
+	if a == "" {
+		return string(rankDigits[1])
+	}
+	return a + strings.Repeat(string(rankDigits[0]), len(a)-1) + string(rankDigits[1])
+}
+
+func rankDigitIndex(c byte) int {
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	default:
+		return 0
+	}
+}
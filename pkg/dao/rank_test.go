@@ -0,0 +1,85 @@
+package dao
+
+import (
+	"math/rand"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RankBetween", func() {
+	It("should return a rank between the given ranks", func() {
+		rank, err := RankBetween("1", "2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rank > "1" && rank < "2").To(BeTrue())
+	})
+
+	It("should return a rank for the ends", func() {
+		first, err := RankBetween("", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		before, err := RankBetween("", first)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(before < first).To(BeTrue())
+
+		after, err := RankBetween(first, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after > first).To(BeTrue())
+	})
+
+	It("should keep the ranks short when appending", func() {
+		rank := ""
+		for i := 0; i < 10000; i++ {
+			next, err := RankBetween(rank, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next > rank).To(BeTrue())
+			Expect(next).NotTo(HaveSuffix("0"))
+			rank = next
+		}
+		Expect(len(rank)).To(BeNumerically("<=", 8))
+	})
+
+	It("should keep the ranks short when appending after a moved item", func() {
+		rank, err := RankBetween("1", "2")
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 1000; i++ {
+			next, err := RankBetween(rank, "2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next > rank && next < "2").To(BeTrue())
+			rank = next
+		}
+		Expect(len(rank)).To(BeNumerically("<=", 6))
+	})
+
+	It("should keep the order for any insertion", func() {
+		ranks := []string{}
+		for i := 0; i < 1000; i++ {
+			pos := rand.Intn(len(ranks) + 1)
+			var a, b string
+			if pos > 0 {
+				a = ranks[pos-1]
+			}
+			if pos < len(ranks) {
+				b = ranks[pos]
+			}
+
+			rank, err := RankBetween(a, b)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rank).NotTo(HaveSuffix("0"))
+			ranks = append(ranks[:pos], append([]string{rank}, ranks[pos:]...)...)
+		}
+
+		Expect(sort.StringsAreSorted(ranks)).To(BeTrue())
+		for i := 1; i < len(ranks); i++ {
+			Expect(ranks[i]).NotTo(Equal(ranks[i-1]))
+		}
+	})
+
+	It("should get ErrInvalidRank if a does not sort before b", func() {
+		_, err := RankBetween("2", "1")
+		Expect(err).To(Equal(ErrInvalidRank))
+		_, err = RankBetween("1", "1")
+		Expect(err).To(Equal(ErrInvalidRank))
+	})
+})
@@ -0,0 +1,84 @@
+package dao
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Revision struct {
+	TaskID    int            `json:"task_id"`
+	Number    int            `json:"number"`
+	Action    RevisionAction `json:"action"`
+	Actor     string         `json:"actor"`
+	CreatedAt time.Time      `json:"created_at"`
+	// Before and After are the task snapshots around the operation, Before is
+	// nil for creation and After is nil for deletion.
+	Before *Task `json:"before,omitempty"`
+	After  *Task `json:"after,omitempty"`
+}
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate  RevisionAction = "create"
+	RevisionActionUpdate  RevisionAction = "update"
+	RevisionActionDelete  RevisionAction = "delete"
+	RevisionActionRestore RevisionAction = "restore"
+)
+
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Changes returns the task fields which differ between Before and After, the
+// fields are named by their json tags. The ID and UpdatedAt are left out since
+// they are implied by the revision itself.
+func (r Revision) Changes() []FieldChange {
+	var before, after reflect.Value
+	if r.Before != nil {
+		before = reflect.ValueOf(*r.Before)
+	}
+	if r.After != nil {
+		after = reflect.ValueOf(*r.After)
+	}
+
+	changes := make([]FieldChange, 0)
+	taskType := reflect.TypeOf(Task{})
+	for i := 0; i < taskType.NumField(); i++ {
+		field := taskType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || name == "id" || name == "updated_at" {
+			continue
+		}
+
+		change := FieldChange{Field: name}
+		if before.IsValid() {
+			change.Before = before.Field(i).Interface()
+		}
+		if after.IsValid() {
+			change.After = after.Field(i).Interface()
+		}
+
+		if before.IsValid() && after.IsValid() && reflect.DeepEqual(change.Before, change.After) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+type RevisionDAO interface {
+	TenantScoper[RevisionDAO]
+	LoggerScoper[RevisionDAO]
+
+	// ListByTaskID returns the revisions of the task in ascending order.
+	ListByTaskID(taskID int) ([]Revision, error)
+	GetByTaskIDAndNumber(taskID, number int) (Revision, error)
+	// Create assigns the next revision number of the task to the revision.
+	Create(revision *Revision) error
+	DeleteByTaskID(taskID int) error
+}
@@ -0,0 +1,119 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sync"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register([]Revision{})
+}
+
+type goCacheRevisionDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+	// mu is shared by the DAOs of all tenants
+	mu *sync.Mutex
+}
+
+func NewGoCacheRevisionDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheRevisionDAO {
+	return &goCacheRevisionDAO{
+		logger: logger,
+		cache:  cache,
+		mu:     &sync.Mutex{},
+	}
+}
+
+func (dao *goCacheRevisionDAO) WithTenant(tenant string) RevisionDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheRevisionDAO) WithLogger(logger *zap.SugaredLogger) RevisionDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheRevisionDAO) ListByTaskID(taskID int) ([]Revision, error) {
+	revisions, err := dao.list(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]Revision(nil), revisions...), nil
+}
+
+func (dao *goCacheRevisionDAO) GetByTaskIDAndNumber(taskID, number int) (Revision, error) {
+	revisions, err := dao.list(taskID)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	if number < 1 || number > len(revisions) {
+		return Revision{}, ErrResourceNotFound
+	}
+
+	return revisions[number-1], nil
+}
+
+func (dao *goCacheRevisionDAO) Create(revision *Revision) error {
+	if revision == nil {
+		return errors.New("input revision is nil")
+	}
+
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	revisions, err := dao.list(revision.TaskID)
+	if err != nil {
+		return err
+	}
+
+	revision.Number = len(revisions) + 1
+	revisions = append(revisions[:len(revisions):len(revisions)], *revision)
+	dao.cache.SetDefault(dao.cacheKey(revision.TaskID), revisions)
+
+	return nil
+}
+
+func (dao *goCacheRevisionDAO) DeleteByTaskID(taskID int) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	dao.cache.Delete(dao.cacheKey(taskID))
+
+	return nil
+}
+
+func (dao *goCacheRevisionDAO) list(taskID int) ([]Revision, error) {
+	item, found := dao.cache.Get(dao.cacheKey(taskID))
+	if !found {
+		return nil, nil
+	}
+
+	revisions, ok := item.([]Revision)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.([]Revision)")
+		return nil, errors.New("type assertion failed")
+	}
+
+	return revisions, nil
+}
+
+func (dao *goCacheRevisionDAO) cacheKey(taskID int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + revisionCacheKey(taskID)
+}
+
+func revisionCacheKey(taskID int) string {
+	return fmt.Sprintf("revision:%d", taskID)
+}
+
+var _ RevisionDAO = (*goCacheRevisionDAO)(nil)
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"math/rand"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheRevisionDAO", func() {
+	var (
+		dao    *goCacheRevisionDAO
+		taskID int
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheRevisionDAO(zap.NewNop().Sugar(), NewGoCache())
+		taskID = rand.Int()
+	})
+
+	Describe("Create", func() {
+		It("should assign sequential numbers per task", func() {
+			for i := 1; i <= 3; i++ {
+				revision := Revision{TaskID: taskID, Action: RevisionActionUpdate}
+				Expect(dao.Create(&revision)).To(Succeed())
+				Expect(revision.Number).To(Equal(i))
+			}
+
+			other := Revision{TaskID: taskID + 1, Action: RevisionActionCreate}
+			Expect(dao.Create(&other)).To(Succeed())
+			Expect(other.Number).To(Equal(1))
+		})
+	})
+
+	Describe("GetByTaskIDAndNumber", func() {
+		BeforeEach(func() {
+			revision := Revision{TaskID: taskID, Action: RevisionActionCreate}
+			Expect(dao.Create(&revision)).To(Succeed())
+		})
+
+		It("should return the revision", func() {
+			revision, err := dao.GetByTaskIDAndNumber(taskID, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision.Action).To(Equal(RevisionActionCreate))
+		})
+
+		It("should get ErrResourceNotFound for unknown number", func() {
+			_, err := dao.GetByTaskIDAndNumber(taskID, 2)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+
+	Describe("DeleteByTaskID", func() {
+		It("should delete all revisions of the task", func() {
+			revision := Revision{TaskID: taskID, Action: RevisionActionCreate}
+			Expect(dao.Create(&revision)).To(Succeed())
+			Expect(dao.DeleteByTaskID(taskID)).To(Succeed())
+
+			revisions, err := dao.ListByTaskID(taskID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revisions).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package dao
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// SnapshotStore is implemented by the DAOs which keep their data in the memory
+// and save it to a file.
+type SnapshotStore interface {
+	Save(w io.Writer) error
+}
+
+// Snapshotter saves the snapshots of the store to the file and reports the
+// size and the duration of the last one.
+type Snapshotter struct {
+	logger    *zap.SugaredLogger
+	store     SnapshotStore
+	filename  string
+	size      prometheus.Gauge
+	duration  prometheus.Gauge
+	timestamp prometheus.Gauge
+}
+
+// NewSnapshotter registers the metrics of the snapshots to registerer.
+func NewSnapshotter(logger *zap.SugaredLogger, store SnapshotStore, filename string, registerer prometheus.Registerer) *Snapshotter {
+	snapshotter := &Snapshotter{
+		logger:   logger,
+		store:    store,
+		filename: filename,
+		size: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gogo_snapshot_size_bytes",
+			Help: "Size of the last snapshot saved.",
+		}),
+		duration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gogo_snapshot_duration_seconds",
+			Help: "Time taken to save the last snapshot.",
+		}),
+		timestamp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "gogo_snapshot_timestamp_seconds",
+			Help: "Unix time the last snapshot was saved at.",
+		}),
+	}
+	registerer.MustRegister(snapshotter.size, snapshotter.duration, snapshotter.timestamp)
+	return snapshotter
+}
+
+// Save saves a snapshot, the metrics are left as they are if it fails.
+func (s *Snapshotter) Save() error {
+	start := time.Now()
+	size, err := s.save()
+	if err != nil {
+		return err
+	}
+	s.duration.Set(time.Since(start).Seconds())
+	s.timestamp.Set(float64(start.Unix()))
+	s.size.Set(float64(size))
+
+	return nil
+}
+
+// save writes the snapshot to a temporary file next to the file and renames
+// it over the file once it is synced, so a crash while saving leaves the last
+// snapshot whole. It returns the size of the snapshot.
+func (s *Snapshotter) save() (int64, error) {
+	file, err := os.CreateTemp(filepath.Dir(s.filename), filepath.Base(s.filename)+".tmp-*")
+	if err != nil {
+		return 0, err
+	}
+	// the temporary file is gone already once it is renamed
+	defer os.Remove(file.Name())
+
+	if err := s.store.Save(file); err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	if err := file.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(file.Name(), s.filename); err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// Ping checks that the directory of the file is writable, so the snapshots
+// can be saved.
+func (s *Snapshotter) Ping() error {
+	file, err := os.CreateTemp(filepath.Dir(s.filename), ".ping-*")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
+
+// SavePeriodically saves a snapshot every interval until ctx is done, so less
+// is lost if the process is killed.
+func (s *Snapshotter) SavePeriodically(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Save(); err != nil {
+				s.logger.Errorf("snapshotter.Save failed, err=%v, filename=%v", err, s.filename)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Snapshotter", func() {
+	It("should report the size of the last snapshot", func() {
+		dir, err := os.MkdirTemp("", "snapshot")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		taskDAO := NewGoCacheTaskDAO(logger, cache)
+		_, err = taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		filename := filepath.Join(dir, "storage.gocache")
+		snapshotter := NewSnapshotter(logger, taskDAO, filename, prometheus.NewRegistry())
+		Expect(snapshotter.Save()).To(Succeed())
+
+		info, err := os.Stat(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(testutil.ToFloat64(snapshotter.size)).To(Equal(float64(info.Size())))
+		Expect(testutil.ToFloat64(snapshotter.timestamp)).NotTo(BeZero())
+	})
+
+	It("should keep the last snapshot whole if saving fails", func() {
+		dir, err := os.MkdirTemp("", "snapshot")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		filename := filepath.Join(dir, "storage.gocache")
+		Expect(os.WriteFile(filename, []byte("last snapshot"), 0o600)).To(Succeed())
+
+		logger := zap.NewNop().Sugar()
+		snapshotter := NewSnapshotter(logger, failingSnapshotStore{}, filename, prometheus.NewRegistry())
+		Expect(snapshotter.Save()).NotTo(Succeed())
+
+		data, err := os.ReadFile(filename)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("last snapshot"))
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("should fail to ping if the directory is missing", func() {
+		logger := zap.NewNop().Sugar()
+		Expect(NewSnapshotter(logger, NewGoCacheTaskDAO(logger, NewGoCache()), os.TempDir()+"/storage.gocache", prometheus.NewRegistry()).Ping()).To(Succeed())
+		Expect(NewSnapshotter(logger, NewGoCacheTaskDAO(logger, NewGoCache()), "/nonexistent/storage.gocache", prometheus.NewRegistry()).Ping()).NotTo(Succeed())
+	})
+
+	It("should leave the metrics if it fails", func() {
+		logger := zap.NewNop().Sugar()
+		snapshotter := NewSnapshotter(logger, NewGoCacheTaskDAO(logger, NewGoCache()), "/nonexistent/storage.gocache", prometheus.NewRegistry())
+		Expect(snapshotter.Save()).NotTo(Succeed())
+		Expect(testutil.ToFloat64(snapshotter.timestamp)).To(BeZero())
+	})
+})
+
+// failingSnapshotStore fails halfway through writing the snapshot.
+type failingSnapshotStore struct{}
+
+func (failingSnapshotStore) Save(w io.Writer) error {
+	if _, err := w.Write([]byte("partial")); err != nil {
+		return err
+	}
+	return errors.New("disk full")
+}
@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"database/sql"
+
+	// the pure Go driver, so the build needs no cgo
+	_ "modernc.org/sqlite"
+)
+
+// OpenSQLite opens the SQLite database at path, which is created if missing.
+// There is a single connection, as SQLite serializes the writes anyway and an
+// in-memory database lives as long as its connection.
+func OpenSQLite(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
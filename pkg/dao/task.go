@@ -1,9 +1,47 @@
 package dao
 
+import (
+	"context"
+	"time"
+)
+
 type Task struct {
 	ID     int        `json:"id"`
 	Name   string     `json:"name"`
 	Status TaskStatus `json:"status"`
+	// Description is in Markdown.
+	Description string `json:"description"`
+	// Rank orders the tasks manually by comparing lexicographically, the
+	// TaskDAO places a new task last unless it is given.
+	Rank string `json:"rank,omitempty"`
+	// Column is the custom board column the task is in.
+	Column string `json:"column,omitempty"`
+	// ProjectID is the project the task belongs to, 0 means none.
+	ProjectID int `json:"project_id,omitempty"`
+	// ParentID is the task which the task is a subtask of, 0 means none.
+	ParentID int      `json:"parent_id,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// OwnerID is the user who created the task, 0 means none, such tasks are
+	// only visible to the admins.
+	OwnerID int `json:"owner_id,omitempty"`
+	// AssigneeID is the user responsible for the task, 0 means none.
+	AssigneeID int `json:"assignee_id,omitempty"`
+	// WatcherIDs are the users following the task.
+	WatcherIDs []int `json:"watcher_ids,omitempty"`
+	// Assignee and Watchers are the usernames the users were referenced by
+	// before, they are only kept for MigrateUsers to read the old snapshots.
+	Assignee string   `json:"-"`
+	Watchers []string `json:"-"`
+	// Fields are the values of the custom fields defined by the project,
+	// keyed by the field keys.
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Checklist is the ordered small steps of the task.
+	Checklist []ChecklistItem `json:"checklist,omitempty"`
+	// CreatedAt, UpdatedAt and CompletedAt are managed by the TaskDAO.
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
 
 type TaskStatus int
@@ -14,9 +52,119 @@ const (
 )
 
 type TaskDAO interface {
-	List() ([]Task, error)
-	GetByID(id int) (Task, error)
-	Create(namg string) (Task, error)
-	Delete(id int) error
-	Update(task *Task) error
+	TenantScoper[TaskDAO]
+	LoggerScoper[TaskDAO]
+
+	// List returns the tasks which are not in the trash.
+	List(ctx context.Context) ([]Task, error)
+	GetByID(ctx context.Context, id int) (Task, error)
+	// Create stores a new task, the ID and the managed timestamps are
+	// assigned by the TaskDAO, so is the Rank if it is empty.
+	Create(ctx context.Context, task Task) (Task, error)
+	// Delete moves the task to the trash, it can be restored until purged.
+	Delete(ctx context.Context, id int) error
+	// CreateTree stores the task and its subtasks in one batch, the ParentIDs
+	// of the subtasks are assigned as well. The created tasks are returned
+	// parent first, in the order of the tree.
+	CreateTree(ctx context.Context, tree TaskTree) ([]Task, error)
+	// Update stores the task and writes the managed timestamps back to it,
+	// CompletedAt is set or cleared when the status changes.
+	Update(ctx context.Context, task *Task) error
+
+	ListTrashed(ctx context.Context) ([]Task, error)
+	Restore(ctx context.Context, id int) (Task, error)
+	// Purge permanently removes a trashed task.
+	Purge(ctx context.Context, id int) error
+	// PurgeTrashedBefore permanently removes the tasks trashed before t and
+	// returns the IDs of the removed tasks.
+	PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error)
+}
+
+// TaskTree is a task with its subtasks.
+type TaskTree struct {
+	Task     Task
+	Subtasks []TaskTree
+}
+
+// Flatten returns the tasks of the tree parent first, along with the index of
+// the parent of each task, -1 for the root.
+func (t TaskTree) Flatten() (tasks []Task, parents []int) {
+	var walk func(tree TaskTree, parent int)
+	walk = func(tree TaskTree, parent int) {
+		index := len(tasks)
+		tasks = append(tasks, tree.Task.Clone())
+		parents = append(parents, parent)
+		for _, subtree := range tree.Subtasks {
+			walk(subtree, index)
+		}
+	}
+	walk(t, -1)
+
+	return tasks, parents
+}
+
+// Clone returns a deep copy of the task, so modifying either one does not
+// affect the other.
+func (t Task) Clone() Task {
+	if t.Checklist != nil {
+		t.Checklist = append([]ChecklistItem(nil), t.Checklist...)
+	}
+	if t.Tags != nil {
+		t.Tags = append([]string(nil), t.Tags...)
+	}
+	if t.WatcherIDs != nil {
+		t.WatcherIDs = append([]int(nil), t.WatcherIDs...)
+	}
+	if t.Watchers != nil {
+		t.Watchers = append([]string(nil), t.Watchers...)
+	}
+	if t.Fields != nil {
+		fields := make(map[string]interface{}, len(t.Fields))
+		for key, value := range t.Fields {
+			fields[key] = value
+		}
+		t.Fields = fields
+	}
+	return t
+}
+
+// IsWatchedBy reports whether the user is one of the watchers of the task.
+func (t Task) IsWatchedBy(userID int) bool {
+	for _, watcherID := range t.WatcherIDs {
+		if watcherID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch adds the user to the watchers, it reports false if the user is
+// watching already.
+func (t *Task) Watch(userID int) bool {
+	if t.IsWatchedBy(userID) {
+		return false
+	}
+	t.WatcherIDs = append(t.WatcherIDs, userID)
+	return true
+}
+
+// Unwatch removes the user from the watchers, it reports false if the user is
+// not watching.
+func (t *Task) Unwatch(userID int) bool {
+	for i, watcherID := range t.WatcherIDs {
+		if watcherID == userID {
+			t.WatcherIDs = append(t.WatcherIDs[:i:i], t.WatcherIDs[i+1:]...)
+			if len(t.WatcherIDs) == 0 {
+				t.WatcherIDs = nil
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// now returns the current time for the managed timestamps, it has no monotonic
+// clock reading so it is equal to itself after being persisted.
+func now() time.Time {
+	return time.Now().UTC()
 }
@@ -0,0 +1,130 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TaskDependentDAO is implemented by the DAOs whose data belongs to tasks,
+// Dependent adapts the DAO interfaces to it.
+type TaskDependentDAO interface {
+	TenantScoper[TaskDependentDAO]
+	LoggerScoper[TaskDependentDAO]
+
+	DeleteByTaskID(taskID int) error
+}
+
+// scopedDependentDAO is a DAO interface whose data belongs to tasks and to a
+// tenant.
+type scopedDependentDAO[T any] interface {
+	TenantScoper[T]
+	LoggerScoper[T]
+
+	DeleteByTaskID(taskID int) error
+}
+
+// Dependent returns the TaskDependentDAO of dao, whose scoping returns the DAO
+// interface T.
+func Dependent[T scopedDependentDAO[T]](dao T) TaskDependentDAO {
+	return dependentDAO[T]{dao: dao}
+}
+
+type dependentDAO[T scopedDependentDAO[T]] struct {
+	dao T
+}
+
+func (dependent dependentDAO[T]) WithTenant(tenant string) TaskDependentDAO {
+	return dependentDAO[T]{dao: ScopeTenant(dependent.dao, tenant)}
+}
+
+func (dependent dependentDAO[T]) WithLogger(logger *zap.SugaredLogger) TaskDependentDAO {
+	return dependentDAO[T]{dao: ScopeLogger(dependent.dao, logger)}
+}
+
+func (dependent dependentDAO[T]) DeleteByTaskID(taskID int) error {
+	return dependent.dao.DeleteByTaskID(taskID)
+}
+
+// cascadeTaskDAO decorates a TaskDAO to delete the data belonging to the
+// purged tasks. The trashed tasks keep their data so they can be restored.
+type cascadeTaskDAO struct {
+	taskDecorator
+	logger     *zap.SugaredLogger
+	dependents []TaskDependentDAO
+}
+
+func NewCascadeTaskDAO(logger *zap.SugaredLogger, taskDAO TaskDAO, dependents ...TaskDependentDAO) *cascadeTaskDAO {
+	return &cascadeTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		logger:        logger,
+		dependents:    dependents,
+	}
+}
+
+func (dao *cascadeTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *cascadeTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *cascadeTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *cascadeTaskDAO) scopeTenant(tenant string) {
+	dependents := make([]TaskDependentDAO, 0, len(dao.dependents))
+	for _, dependent := range dao.dependents {
+		dependents = append(dependents, ScopeTenant(dependent, tenant))
+	}
+	dao.dependents = dependents
+}
+
+func (dao *cascadeTaskDAO) scopeLogger(logger *zap.SugaredLogger) {
+	dependents := make([]TaskDependentDAO, 0, len(dao.dependents))
+	for _, dependent := range dao.dependents {
+		dependents = append(dependents, ScopeLogger(dependent, logger))
+	}
+	dao.dependents = dependents
+	dao.logger = logger
+}
+
+func (dao *cascadeTaskDAO) Purge(ctx context.Context, id int) error {
+	if err := dao.TaskDAO.Purge(ctx, id); err != nil {
+		return err
+	}
+
+	dao.deleteDependents(id)
+
+	return nil
+}
+
+// PurgeTrashedBefore deletes the data of the tasks the TaskDAO purged only, a
+// task restored meanwhile keeps its data.
+func (dao *cascadeTaskDAO) PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error) {
+	ids, err := dao.TaskDAO.PurgeTrashedBefore(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		dao.deleteDependents(id)
+	}
+
+	return ids, nil
+}
+
+// deleteDependents deletes the data belonging to the purged task, a failure is
+// only logged since the task has gone.
+func (dao *cascadeTaskDAO) deleteDependents(taskID int) {
+	for _, dependent := range dao.dependents {
+		if err := dependent.DeleteByTaskID(taskID); err != nil {
+			dao.logger.Errorf("DeleteByTaskID failed, err=%v, taskID=%v", err, taskID)
+		}
+	}
+}
+
+var _ TaskDAO = (*cascadeTaskDAO)(nil)
@@ -0,0 +1,85 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("CascadeTaskDAO", func() {
+	var (
+		commentDAO *goCacheCommentDAO
+		taskDAO    TaskDAO
+		task       Task
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		commentDAO = NewGoCacheCommentDAO(logger, cache)
+		taskDAO = NewCascadeTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), Dependent[CommentDAO](commentDAO))
+
+		var err error
+		task, err = taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = commentDAO.Create(Comment{TaskID: task.ID, Body: gofakeit.Sentence(3)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskDAO.Delete(context.Background(), task.ID)).To(Succeed())
+	})
+
+	It("should keep the comments of trashed tasks", func() {
+		comments, err := commentDAO.ListByTaskID(task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(HaveLen(1))
+	})
+
+	It("should delete the comments of purged task", func() {
+		Expect(taskDAO.Purge(context.Background(), task.ID)).To(Succeed())
+
+		comments, err := commentDAO.ListByTaskID(task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(BeEmpty())
+	})
+
+	It("should delete the comments of tasks purged by retention", func() {
+		ids, err := taskDAO.PurgeTrashedBefore(context.Background(), time.Now().Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids).To(Equal([]int{task.ID}))
+
+		comments, err := commentDAO.ListByTaskID(task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(BeEmpty())
+	})
+
+	It("should keep the comments of tasks restored while purging", func() {
+		logger := zap.NewNop().Sugar()
+		taskDAO = NewCascadeTaskDAO(logger, restoringTaskDAO{TaskDAO: taskDAO, id: task.ID}, Dependent[CommentDAO](commentDAO))
+
+		ids, err := taskDAO.PurgeTrashedBefore(context.Background(), time.Now().Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids).To(BeEmpty())
+
+		comments, err := commentDAO.ListByTaskID(task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(HaveLen(1))
+	})
+})
+
+// restoringTaskDAO restores the task right before purging, as a restore
+// racing the purge would.
+type restoringTaskDAO struct {
+	TaskDAO
+	id int
+}
+
+func (dao restoringTaskDAO) PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error) {
+	if _, err := dao.TaskDAO.Restore(ctx, dao.id); err != nil {
+		return nil, err
+	}
+	return dao.TaskDAO.PurgeTrashedBefore(ctx, t)
+}
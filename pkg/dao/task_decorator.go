@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"go.uber.org/zap"
+)
+
+// taskDecorator is embedded by the TaskDAOs decorating another TaskDAO. Their
+// WithActor, WithTenant and WithLogger go through decoratorWithActor,
+// decoratorWithTenant and decoratorWithLogger, which scope the decorated
+// TaskDAO and call the scope hook for the state of the decorator itself, such
+// as its own DAOs. The hooks do nothing unless the decorator overrides them.
+type taskDecorator struct {
+	TaskDAO
+}
+
+func (d *taskDecorator) decorated() *taskDecorator {
+	return d
+}
+
+func (d *taskDecorator) scopeActor(actor string) {}
+
+func (d *taskDecorator) scopeTenant(tenant string) {}
+
+func (d *taskDecorator) scopeLogger(logger *zap.SugaredLogger) {}
+
+// Tenants returns the tenants known to the decorated TaskDAO.
+func (d *taskDecorator) Tenants() ([]string, error) {
+	return ListTenants(d.TaskDAO)
+}
+
+// taskDecoratorOf is the pointer to a decorator D embedding taskDecorator.
+type taskDecoratorOf[D any] interface {
+	*D
+	TaskDAO
+	decorated() *taskDecorator
+	scopeActor(actor string)
+	scopeTenant(tenant string)
+	scopeLogger(logger *zap.SugaredLogger)
+}
+
+// scopeDecorator returns a copy of the decorator whose decorated TaskDAO is
+// scoped by scope.
+func scopeDecorator[D any, P taskDecoratorOf[D]](dao P, scope func(taskDAO TaskDAO) TaskDAO) P {
+	scoped := P(new(D))
+	*scoped = *dao
+	scoped.decorated().TaskDAO = scope(dao.decorated().TaskDAO)
+	return scoped
+}
+
+// decoratorWithActor is the WithActor of the decorators.
+func decoratorWithActor[D any, P taskDecoratorOf[D]](dao P, actor string) TaskDAO {
+	scoped := scopeDecorator(dao, func(taskDAO TaskDAO) TaskDAO {
+		return WithActor(taskDAO, actor)
+	})
+	scoped.scopeActor(actor)
+	return scoped
+}
+
+// decoratorWithTenant is the WithTenant of the decorators.
+func decoratorWithTenant[D any, P taskDecoratorOf[D]](dao P, tenant string) TaskDAO {
+	scoped := scopeDecorator(dao, func(taskDAO TaskDAO) TaskDAO {
+		return ScopeTenant(taskDAO, tenant)
+	})
+	scoped.scopeTenant(tenant)
+	return scoped
+}
+
+// decoratorWithLogger is the WithLogger of the decorators.
+func decoratorWithLogger[D any, P taskDecoratorOf[D]](dao P, logger *zap.SugaredLogger) TaskDAO {
+	scoped := scopeDecorator(dao, func(taskDAO TaskDAO) TaskDAO {
+		return ScopeLogger(taskDAO, logger)
+	})
+	scoped.scopeLogger(logger)
+	return scoped
+}
@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("taskDecorator", func() {
+	var (
+		taskDAO *quotaTaskDAO
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		taskDAO = NewQuotaTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), 0, nil)
+	})
+
+	It("should scope a copy of the decorator along with the decorated TaskDAO", func() {
+		acme := ScopeTenant[TaskDAO](taskDAO, "acme")
+		_, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(acme.(*quotaTaskDAO).tenant).To(Equal("acme"))
+		Expect(taskDAO.tenant).To(Equal(DefaultTenant))
+		tasks, err := taskDAO.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(BeEmpty())
+	})
+
+	It("should list the tenants of the decorated TaskDAO", func() {
+		_, err := ScopeTenant[TaskDAO](taskDAO, "acme").Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		tenants, err := ListTenants(taskDAO)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tenants).To(Equal([]string{DefaultTenant, "acme"}))
+	})
+})
@@ -1,10 +1,14 @@
 package dao
 
 import (
+	"context"
 	"encoding/gob"
 	"errors"
+	"io"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	gocache "github.com/patrickmn/go-cache"
@@ -14,100 +18,368 @@ import (
 type goCacheTaskDAO struct {
 	logger *zap.SugaredLogger
 	cache  *gocache.Cache
+	tenant string
+
+	// mu serializes the writes, so that the appended tasks do not get the
+	// same rank and a restored task is not purged, it is shared by the DAOs
+	// of all tenants
+	mu *sync.Mutex
 }
 
 const (
 	cacheKeyNextTaskID = "cacheKeyNextTaskID"
 )
 
-func NewGoCacheTaskDAO(logger *zap.SugaredLogger) *goCacheTaskDAO {
+// NewGoCache creates the cache which is shared by the go-cache DAOs, so all
+// data is saved into and loaded from a single snapshot file.
+func NewGoCache() *gocache.Cache {
+	return gocache.New(gocache.NoExpiration, 10*time.Minute)
+}
+
+func NewGoCacheTaskDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheTaskDAO {
 	return &goCacheTaskDAO{
 		logger: logger,
-		cache:  gocache.New(gocache.NoExpiration, 10*time.Minute),
+		cache:  cache,
+		mu:     &sync.Mutex{},
 	}
 }
 
-func (dao *goCacheTaskDAO) List() ([]Task, error) {
-	items := dao.cache.Items()
-	tasks := make([]Task, 0, len(items))
-	for key, item := range items {
-		if key == cacheKeyNextTaskID {
+// WithTenant returns the TaskDAO of the tenant, whose tasks and ID sequence
+// are kept apart from the other tenants.
+func (dao *goCacheTaskDAO) WithTenant(tenant string) TaskDAO {
+	return dao.withTenant(tenant)
+}
+
+func (dao *goCacheTaskDAO) withTenant(tenant string) *goCacheTaskDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+// Tenants returns the default tenant and the tenants having tasks, in
+// ascending order.
+func (dao *goCacheTaskDAO) Tenants() ([]string, error) {
+	found := map[string]bool{DefaultTenant: true}
+	for key := range dao.cache.Items() {
+		if !strings.HasPrefix(key, cacheKeyPrefixTenant) {
 			continue
 		}
 
-		task, ok := item.Object.(Task)
-		if !ok {
-			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Task)")
-			continue
+		tenant, id, ok := strings.Cut(strings.TrimPrefix(key, cacheKeyPrefixTenant), ":")
+		if ok && isTaskCacheKey(id) {
+			found[tenant] = true
 		}
+	}
 
-		tasks = append(tasks, task)
+	tenants := make([]string, 0, len(found))
+	for tenant := range found {
+		tenants = append(tenants, tenant)
 	}
+	sort.Strings(tenants)
 
-	sort.Slice(tasks, func(i, j int) bool {
-		return tasks[i].ID > tasks[j].ID
-	})
+	return tenants, nil
+}
 
-	return tasks, nil
+func (dao *goCacheTaskDAO) List(ctx context.Context) ([]Task, error) {
+	return dao.list(func(task Task) bool {
+		return task.DeletedAt == nil
+	}), nil
 }
 
-func (dao *goCacheTaskDAO) GetByID(id int) (Task, error) {
-	key := strconv.Itoa(id)
-	item, found := dao.cache.Get(key)
-	if !found {
-		return Task{}, ErrResourceNotFound
+func (dao *goCacheTaskDAO) GetByID(ctx context.Context, id int) (Task, error) {
+	task, err := dao.get(id)
+	if err != nil {
+		return Task{}, err
 	}
 
-	task, ok := item.(Task)
-	if !ok {
-		dao.logger.Errorf("gocache.Get type assertion failed: item.(Task)")
-		return Task{}, errors.New("type assertion failed")
+	if task.DeletedAt != nil {
+		return Task{}, ErrResourceNotFound
 	}
 
 	return task, nil
 }
 
-func (dao *goCacheTaskDAO) Create(name string) (Task, error) {
-	id, err := dao.cache.IncrementInt64(cacheKeyNextTaskID, 1)
+func (dao *goCacheTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	id, err := dao.nextID(1)
 	if err != nil {
-		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
 		return Task{}, err
 	}
 
-	task := Task{
-		ID:     int(id),
-		Name:   name,
-		Status: TaskStatusIncomplete,
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if task.Rank == "" {
+		task.Rank, err = RankBetween(dao.lastRank(), "")
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	createdAt := now()
+	task.ID = int(id)
+	task.CreatedAt = createdAt
+	task.UpdatedAt = createdAt
+	task.CompletedAt = nil
+	task.DeletedAt = nil
+	if task.Status == TaskStatusComplete {
+		task.CompletedAt = &createdAt
 	}
-	key := strconv.Itoa(task.ID)
-	dao.cache.SetDefault(key, task)
+	dao.cache.SetDefault(dao.cacheKey(task.ID), task.Clone())
 
 	return task, nil
 }
 
-func (dao *goCacheTaskDAO) Delete(id int) error {
-	key := strconv.Itoa(id)
-	dao.cache.Delete(key)
+func (dao *goCacheTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	tasks, parents := tree.Flatten()
+
+	// the IDs are reserved at once so the tree is stored only if all of them
+	// are assigned
+	lastID, err := dao.nextID(len(tasks))
+	if err != nil {
+		return nil, err
+	}
+
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	rank := dao.lastRank()
+	createdAt := now()
+	for i := range tasks {
+		task := &tasks[i]
+		task.ID = int(lastID) - len(tasks) + 1 + i
+		if parents[i] >= 0 {
+			task.ParentID = tasks[parents[i]].ID
+		}
+		if task.Rank == "" {
+			rank, err = RankBetween(rank, "")
+			if err != nil {
+				return nil, err
+			}
+			task.Rank = rank
+		}
+		task.CreatedAt = createdAt
+		task.UpdatedAt = createdAt
+		task.CompletedAt = nil
+		task.DeletedAt = nil
+		if task.Status == TaskStatusComplete {
+			task.CompletedAt = &createdAt
+		}
+	}
+
+	for i := range tasks {
+		dao.cache.SetDefault(dao.cacheKey(tasks[i].ID), tasks[i].Clone())
+	}
+
+	return tasks, nil
+}
+
+func (dao *goCacheTaskDAO) Delete(ctx context.Context, id int) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	task, err := dao.get(id)
+	if errors.Is(err, ErrResourceNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if task.DeletedAt != nil {
+		return nil
+	}
+
+	deletedAt := now()
+	task.DeletedAt = &deletedAt
+	dao.cache.SetDefault(dao.cacheKey(id), task)
 
 	return nil
 }
 
-func (dao *goCacheTaskDAO) Update(task *Task) error {
+func (dao *goCacheTaskDAO) Update(ctx context.Context, task *Task) error {
 	if task == nil {
 		return errors.New("input task is nil")
 	}
 
-	key := strconv.Itoa(task.ID)
-	if _, found := dao.cache.Get(key); !found {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	current, err := dao.GetByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	updatedAt := now()
+	task.CreatedAt = current.CreatedAt
+	task.UpdatedAt = updatedAt
+	task.CompletedAt = current.CompletedAt
+	task.DeletedAt = current.DeletedAt
+	if task.Status != current.Status {
+		task.CompletedAt = nil
+		if task.Status == TaskStatusComplete {
+			task.CompletedAt = &updatedAt
+		}
+	}
+
+	dao.cache.SetDefault(dao.cacheKey(task.ID), task.Clone())
+
+	return nil
+}
+
+func (dao *goCacheTaskDAO) ListTrashed(ctx context.Context) ([]Task, error) {
+	return dao.list(func(task Task) bool {
+		return task.DeletedAt != nil
+	}), nil
+}
+
+func (dao *goCacheTaskDAO) Restore(ctx context.Context, id int) (Task, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	task, err := dao.get(id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	if task.DeletedAt == nil {
+		return Task{}, ErrResourceNotFound
+	}
+
+	task.DeletedAt = nil
+	dao.cache.SetDefault(dao.cacheKey(id), task)
+
+	return task, nil
+}
+
+func (dao *goCacheTaskDAO) Purge(ctx context.Context, id int) error {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	task, err := dao.get(id)
+	if err != nil {
+		return err
+	}
+
+	if task.DeletedAt == nil {
 		return ErrResourceNotFound
 	}
-	dao.cache.SetDefault(key, *task)
+
+	dao.cache.Delete(dao.cacheKey(id))
 
 	return nil
 }
 
-func (dao *goCacheTaskDAO) Save(filename string) error {
-	return dao.cache.SaveFile(filename)
+func (dao *goCacheTaskDAO) PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	tasks := dao.list(func(task Task) bool {
+		return task.DeletedAt != nil && task.DeletedAt.Before(t)
+	})
+
+	ids := make([]int, 0, len(tasks))
+	for i := range tasks {
+		dao.cache.Delete(dao.cacheKey(tasks[i].ID))
+		ids = append(ids, tasks[i].ID)
+	}
+
+	return ids, nil
+}
+
+// list returns the stored tasks matched by the filter, sorted by ID descending.
+func (dao *goCacheTaskDAO) list(filter func(task Task) bool) []Task {
+	items := dao.cache.Items()
+	tasks := make([]Task, 0, len(items))
+	for key, item := range items {
+		prefix := tenantCacheKeyPrefix(dao.tenant)
+		if !strings.HasPrefix(key, prefix) || !isTaskCacheKey(strings.TrimPrefix(key, prefix)) {
+			continue
+		}
+
+		task, ok := item.Object.(Task)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Task)")
+			continue
+		}
+
+		if filter(task) {
+			tasks = append(tasks, task.Clone())
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID > tasks[j].ID
+	})
+
+	return tasks
+}
+
+// lastRank returns the highest rank of the stored tasks, the trashed tasks are
+// included so they are still in order once restored.
+func (dao *goCacheTaskDAO) lastRank() string {
+	var rank string
+	prefix := tenantCacheKeyPrefix(dao.tenant)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, prefix) || !isTaskCacheKey(strings.TrimPrefix(key, prefix)) {
+			continue
+		}
+
+		// only the rank is read, so the task is neither cloned nor sorted
+		if task, ok := item.Object.(Task); ok && task.Rank > rank {
+			rank = task.Rank
+		}
+	}
+	return rank
+}
+
+// isTaskCacheKey reports whether the key holds a task, other DAOs sharing the
+// cache use non-numeric keys.
+func isTaskCacheKey(key string) bool {
+	_, err := strconv.Atoi(key)
+	return err == nil
+}
+
+// nextID reserves n IDs from the sequence of the tenant and returns the last
+// one.
+func (dao *goCacheTaskDAO) nextID(n int) (int64, error) {
+	key := tenantCacheKeyPrefix(dao.tenant) + cacheKeyNextTaskID
+	// the counter of a tenant is missing until its first task is created
+	_ = dao.cache.Add(key, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(key, int64(n))
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v, tenant=%v", err, dao.tenant)
+		return 0, err
+	}
+	return id, nil
+}
+
+func (dao *goCacheTaskDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + strconv.Itoa(id)
+}
+
+// get returns the stored task regardless of whether it is in the trash.
+func (dao *goCacheTaskDAO) get(id int) (Task, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return Task{}, ErrResourceNotFound
+	}
+
+	task, ok := item.(Task)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(Task)")
+		return Task{}, errors.New("type assertion failed")
+	}
+
+	return task.Clone(), nil
+}
+
+func (dao *goCacheTaskDAO) Save(w io.Writer) error {
+	return dao.cache.Save(w)
 }
 
 func (dao *goCacheTaskDAO) Load(filename string) error {
@@ -116,13 +388,106 @@ func (dao *goCacheTaskDAO) Load(filename string) error {
 		dao.logger.Warnf("gocache.LoadFile failed, err=%v", err)
 	}
 
-	tasks, err := dao.List()
+	if _, found := dao.cache.Get(cacheKeyNextTaskID); !found {
+		dao.cache.SetDefault(cacheKeyNextTaskID, int64(0))
+	}
+
+	tenants, err := dao.Tenants()
 	if err != nil {
 		return err
 	}
 
-	if len(tasks) == 0 {
-		dao.cache.SetDefault(cacheKeyNextTaskID, int64(0))
+	for _, tenant := range tenants {
+		if err := dao.withTenant(tenant).rankUnrankedTasks(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateUsers moves the usernames of the assignees and the watchers stored
+// before the users were referenced by IDs to AssigneeID and WatcherIDs, of the
+// tasks of all tenants. The usernames of no user are dropped.
+func (dao *goCacheTaskDAO) MigrateUsers(userDAO UserDAO) error {
+	tenants, err := dao.Tenants()
+	if err != nil {
+		return err
+	}
+
+	userIDs := make(map[string]int)
+	userIDOf := func(username string) (int, error) {
+		if id, found := userIDs[username]; found {
+			return id, nil
+		}
+
+		user, err := userDAO.GetByUsername(username)
+		if errors.Is(err, ErrResourceNotFound) {
+			dao.logger.Warnf("the user of the task is gone, username=%v", username)
+		} else if err != nil {
+			return 0, err
+		}
+		userIDs[username] = user.ID
+		return user.ID, nil
+	}
+
+	for _, tenant := range tenants {
+		scoped := dao.withTenant(tenant)
+		tasks := scoped.list(func(task Task) bool {
+			return task.Assignee != "" || len(task.Watchers) > 0
+		})
+
+		for i := range tasks {
+			task := &tasks[i]
+			if task.Assignee != "" {
+				if task.AssigneeID, err = userIDOf(task.Assignee); err != nil {
+					return err
+				}
+			}
+			for _, watcher := range task.Watchers {
+				watcherID, err := userIDOf(watcher)
+				if err != nil {
+					return err
+				}
+				if watcherID != 0 {
+					task.Watch(watcherID)
+				}
+			}
+
+			task.Assignee, task.Watchers = "", nil
+			scoped.cache.SetDefault(scoped.cacheKey(task.ID), *task)
+		}
+	}
+
+	return nil
+}
+
+// Ping fails with ErrNotLoaded until Load completes, so the tasks are not
+// served before the snapshot is in.
+func (dao *goCacheTaskDAO) Ping() error {
+	if _, found := dao.cache.Get(cacheKeyNextTaskID); !found {
+		return ErrNotLoaded
+	}
+	return nil
+}
+
+// rankUnrankedTasks places the tasks stored without a rank last, in the order
+// they were created.
+func (dao *goCacheTaskDAO) rankUnrankedTasks() error {
+	tasks := dao.list(func(task Task) bool {
+		return task.Rank == ""
+	})
+
+	rank := dao.lastRank()
+	for i := len(tasks) - 1; i >= 0; i-- {
+		var err error
+		rank, err = RankBetween(rank, "")
+		if err != nil {
+			return err
+		}
+
+		tasks[i].Rank = rank
+		dao.cache.SetDefault(dao.cacheKey(tasks[i].ID), tasks[i])
 	}
 
 	return nil
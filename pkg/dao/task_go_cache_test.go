@@ -1,9 +1,13 @@
 package dao
 
 import (
+	"context"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v5"
@@ -24,6 +28,7 @@ var _ = Describe("GoCacheTaskDAO", func() {
 		dao = &goCacheTaskDAO{
 			logger: logger,
 			cache:  gocache.New(gocache.NoExpiration, 10*time.Minute),
+			mu:     &sync.Mutex{},
 		}
 		dao.cache.SetDefault(cacheKeyNextTaskID, int64(0))
 	})
@@ -44,7 +49,7 @@ var _ = Describe("GoCacheTaskDAO", func() {
 		)
 
 		JustBeforeEach(func() {
-			tasks, err = dao.List()
+			tasks, err = dao.List(context.Background())
 		})
 
 		Context("have some tasks", func() {
@@ -109,7 +114,7 @@ var _ = Describe("GoCacheTaskDAO", func() {
 		)
 
 		JustBeforeEach(func() {
-			task, err = dao.GetByID(taskID)
+			task, err = dao.GetByID(context.Background(), taskID)
 		})
 
 		Context("task exists", func() {
@@ -156,7 +161,7 @@ var _ = Describe("GoCacheTaskDAO", func() {
 		)
 
 		JustBeforeEach(func() {
-			task, err = dao.Create(taskName)
+			task, err = dao.Create(context.Background(), Task{Name: taskName})
 		})
 
 		Context("create task successfully", func() {
@@ -174,12 +179,75 @@ var _ = Describe("GoCacheTaskDAO", func() {
 				Expect(task.Status).To(Equal(TaskStatusIncomplete))
 			})
 
+			It("should set the timestamps", func() {
+				Expect(task.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(task.UpdatedAt).To(Equal(task.CreatedAt))
+				Expect(task.CompletedAt).To(BeNil())
+			})
+
 			It("should not get an error", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 	})
 
+	Describe("Create concurrently", func() {
+		It("should give the tasks distinct ranks", func() {
+			var wg sync.WaitGroup
+			ranks := make([]string, 50)
+			for i := range ranks {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					task, err := dao.Create(context.Background(), Task{Name: gofakeit.Noun()})
+					Expect(err).NotTo(HaveOccurred())
+					ranks[i] = task.Rank
+				}(i)
+			}
+			wg.Wait()
+
+			sort.Strings(ranks)
+			for i := 1; i < len(ranks); i++ {
+				Expect(ranks[i]).NotTo(Equal(ranks[i-1]))
+			}
+		})
+	})
+
+	Describe("CreateTree", func() {
+		It("should create the tasks parent first with the parent IDs", func() {
+			first, err := dao.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+
+			tasks, err := dao.CreateTree(context.Background(), TaskTree{
+				Task: Task{Name: "root"},
+				Subtasks: []TaskTree{
+					{Task: Task{Name: "a"}, Subtasks: []TaskTree{{Task: Task{Name: "a1"}}}},
+					{Task: Task{Name: "b"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(HaveLen(4))
+
+			names := []string{"root", "a", "a1", "b"}
+			parentIDs := []int{0, tasks[0].ID, tasks[1].ID, tasks[0].ID}
+			for i, task := range tasks {
+				Expect(task.ID).To(Equal(first.ID + 1 + i))
+				Expect(task.Name).To(Equal(names[i]))
+				Expect(task.ParentID).To(Equal(parentIDs[i]))
+				Expect(task.Rank > first.Rank).To(BeTrue())
+				if i > 0 {
+					Expect(task.Rank > tasks[i-1].Rank).To(BeTrue())
+				}
+
+				stored, err := dao.GetByID(context.Background(), task.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(Equal(task))
+			}
+		})
+	})
+
 	Describe("Delete", func() {
 		var (
 			taskID int
@@ -187,7 +255,7 @@ var _ = Describe("GoCacheTaskDAO", func() {
 		)
 
 		JustBeforeEach(func() {
-			err = dao.Delete(taskID)
+			err = dao.Delete(context.Background(), taskID)
 		})
 
 		Context("task exists", func() {
@@ -207,9 +275,13 @@ var _ = Describe("GoCacheTaskDAO", func() {
 				dao.cache.Delete(strconv.Itoa(taskID))
 			})
 
-			It("should delete task from storage", func() {
-				_, found := dao.cache.Get(strconv.Itoa(taskID))
-				Expect(found).To(BeFalse())
+			It("should move task to the trash", func() {
+				item, found := dao.cache.Get(strconv.Itoa(taskID))
+				Expect(found).To(BeTrue())
+				Expect(item.(Task).DeletedAt).NotTo(BeNil())
+
+				_, err := dao.GetByID(context.Background(), taskID)
+				Expect(err).To(Equal(ErrResourceNotFound))
 			})
 
 			It("should not get an error", func() {
@@ -235,18 +307,25 @@ var _ = Describe("GoCacheTaskDAO", func() {
 		)
 
 		JustBeforeEach(func() {
-			err = dao.Update(task)
+			err = dao.Update(context.Background(), task)
 		})
 
 		Context("task exists", func() {
+			var (
+				createdAt time.Time
+			)
+
 			BeforeEach(func() {
+				createdAt = time.Now().Add(-time.Hour).UTC()
 				task = &Task{
-					ID:     rand.Int(),
-					Name:   gofakeit.Noun(),
-					Status: TaskStatusIncomplete,
+					ID:        rand.Int(),
+					Name:      gofakeit.Noun(),
+					Status:    TaskStatusIncomplete,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
 				}
 
-				dao.cache.SetDefault(strconv.Itoa(task.ID), task)
+				dao.cache.SetDefault(strconv.Itoa(task.ID), *task)
 
 				task.Name = task.Name + "_v2"
 				task.Status = TaskStatusComplete
@@ -266,6 +345,23 @@ var _ = Describe("GoCacheTaskDAO", func() {
 				Expect(cacheTask).Should(Equal(*task))
 			})
 
+			It("should keep CreatedAt and renew UpdatedAt", func() {
+				Expect(task.CreatedAt).To(Equal(createdAt))
+				Expect(task.UpdatedAt).To(BeTemporally(">", createdAt))
+			})
+
+			It("should set CompletedAt on completion", func() {
+				Expect(task.CompletedAt).NotTo(BeNil())
+				Expect(*task.CompletedAt).To(Equal(task.UpdatedAt))
+			})
+
+			It("should clear CompletedAt on reopening", func() {
+				reopened := *task
+				reopened.Status = TaskStatusIncomplete
+				Expect(dao.Update(context.Background(), &reopened)).To(Succeed())
+				Expect(reopened.CompletedAt).To(BeNil())
+			})
+
 			It("should not error", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -277,4 +373,176 @@ var _ = Describe("GoCacheTaskDAO", func() {
 			})
 		})
 	})
+
+	Describe("Trash", func() {
+		var (
+			cacheTask Task
+		)
+
+		BeforeEach(func() {
+			deletedAt := time.Now().Add(-time.Hour)
+			cacheTask = Task{
+				ID:        rand.Int(),
+				Name:      gofakeit.Noun(),
+				Status:    TaskStatus(rand.Int() % 2),
+				DeletedAt: &deletedAt,
+			}
+			dao.cache.SetDefault(strconv.Itoa(cacheTask.ID), cacheTask)
+		})
+
+		AfterEach(func() {
+			dao.cache.Delete(strconv.Itoa(cacheTask.ID))
+		})
+
+		It("should exclude trashed task from List", func() {
+			tasks, err := dao.List(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(BeEmpty())
+		})
+
+		It("should list trashed task", func() {
+			tasks, err := dao.ListTrashed(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(Equal([]Task{cacheTask}))
+		})
+
+		It("should not update trashed task", func() {
+			task := cacheTask
+			err := dao.Update(context.Background(), &task)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+
+		Describe("Restore", func() {
+			It("should restore trashed task", func() {
+				task, err := dao.Restore(context.Background(), cacheTask.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.DeletedAt).To(BeNil())
+
+				stored, err := dao.GetByID(context.Background(), cacheTask.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stored).To(Equal(task))
+			})
+
+			It("should get ErrResourceNotFound if task is not in the trash", func() {
+				_, err := dao.Restore(context.Background(), cacheTask.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = dao.Restore(context.Background(), cacheTask.ID)
+				Expect(err).To(Equal(ErrResourceNotFound))
+			})
+		})
+
+		Describe("Purge", func() {
+			It("should remove trashed task from storage", func() {
+				err := dao.Purge(context.Background(), cacheTask.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found := dao.cache.Get(strconv.Itoa(cacheTask.ID))
+				Expect(found).To(BeFalse())
+			})
+
+			It("should get ErrResourceNotFound if task is not in the trash", func() {
+				_, err := dao.Restore(context.Background(), cacheTask.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = dao.Purge(context.Background(), cacheTask.ID)
+				Expect(err).To(Equal(ErrResourceNotFound))
+			})
+		})
+
+		Describe("PurgeTrashedBefore", func() {
+			It("should remove tasks trashed before the given time", func() {
+				ids, err := dao.PurgeTrashedBefore(context.Background(), time.Now())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(Equal([]int{cacheTask.ID}))
+
+				_, found := dao.cache.Get(strconv.Itoa(cacheTask.ID))
+				Expect(found).To(BeFalse())
+			})
+
+			It("should keep tasks trashed after the given time", func() {
+				ids, err := dao.PurgeTrashedBefore(context.Background(), time.Now().Add(-2*time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ids).To(BeEmpty())
+
+				_, found := dao.cache.Get(strconv.Itoa(cacheTask.ID))
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Rank", func() {
+		It("should place new tasks last", func() {
+			first, err := dao.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := dao.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first.Rank).NotTo(BeEmpty())
+			Expect(second.Rank > first.Rank).To(BeTrue())
+		})
+
+		It("should keep the given rank", func() {
+			task, err := dao.Create(context.Background(), Task{Name: gofakeit.Noun(), Rank: "5"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Rank).To(Equal("5"))
+		})
+
+		It("should rank the unranked tasks on load", func() {
+			ranked, err := dao.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+			dao.cache.SetDefault("100", Task{ID: 100, Name: gofakeit.Noun()})
+			dao.cache.SetDefault("101", Task{ID: 101, Name: gofakeit.Noun()})
+
+			Expect(dao.Load(filepath.Join(os.TempDir(), "not-exist.gocache"))).To(Succeed())
+
+			older, err := dao.GetByID(context.Background(), 100)
+			Expect(err).NotTo(HaveOccurred())
+			newer, err := dao.GetByID(context.Background(), 101)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(older.Rank > ranked.Rank).To(BeTrue())
+			Expect(newer.Rank > older.Rank).To(BeTrue())
+		})
+
+		It("should rank the unranked tasks of each tenant on load", func() {
+			dao.cache.SetDefault("tenant:acme:100", Task{ID: 100, Name: gofakeit.Noun()})
+
+			Expect(dao.Load(filepath.Join(os.TempDir(), "not-exist.gocache"))).To(Succeed())
+
+			task, err := dao.WithTenant("acme").GetByID(context.Background(), 100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Rank).NotTo(BeEmpty())
+		})
+	})
+
+	Describe("MigrateUsers", func() {
+		It("should move the usernames of each tenant to the user IDs", func() {
+			userDAO := NewGoCacheUserDAO(logger, dao.cache)
+			alice, err := userDAO.Create(User{Username: "alice"})
+			Expect(err).NotTo(HaveOccurred())
+			bob, err := userDAO.Create(User{Username: "bob", Tenant: "acme"})
+			Expect(err).NotTo(HaveOccurred())
+			dao.cache.SetDefault("1", Task{ID: 1, Assignee: "alice", Watchers: []string{"alice", "gone"}})
+			dao.cache.SetDefault("tenant:acme:1", Task{ID: 1, Assignee: "gone", Watchers: []string{"bob"}})
+
+			Expect(dao.MigrateUsers(userDAO)).To(Succeed())
+
+			task, err := dao.GetByID(context.Background(), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task).To(Equal(Task{ID: 1, AssigneeID: alice.ID, WatcherIDs: []int{alice.ID}}))
+			task, err = dao.WithTenant("acme").GetByID(context.Background(), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task).To(Equal(Task{ID: 1, WatcherIDs: []int{bob.ID}}))
+		})
+	})
+
+	Describe("Ping", func() {
+		It("should fail until loaded", func() {
+			dao := NewGoCacheTaskDAO(zap.NewNop().Sugar(), NewGoCache())
+			Expect(dao.Ping()).To(Equal(ErrNotLoaded))
+
+			Expect(dao.Load(filepath.Join(os.TempDir(), "not-exist.gocache"))).To(Succeed())
+			Expect(dao.Ping()).To(Succeed())
+		})
+	})
 })
@@ -0,0 +1,197 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// metricsTaskDAO decorates a TaskDAO to report the latency and the errors of
+// each method. ErrResourceNotFound is not counted as an error, since it is a
+// normal answer to the requests of the missing tasks.
+type metricsTaskDAO struct {
+	taskDecorator
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewMetricsTaskDAO registers the metrics of taskDAO to registerer.
+func NewMetricsTaskDAO(taskDAO TaskDAO, registerer prometheus.Registerer) *metricsTaskDAO {
+	dao := &metricsTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gogo_task_dao_duration_seconds",
+			Help:    "Latency of the TaskDAO methods.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gogo_task_dao_errors_total",
+			Help: "Errors returned by the TaskDAO methods.",
+		}, []string{"method"}),
+	}
+	registerer.MustRegister(dao.duration, dao.errors)
+	return dao
+}
+
+func (dao *metricsTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *metricsTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *metricsTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *metricsTaskDAO) List(ctx context.Context) ([]Task, error) {
+	defer dao.observe("List", time.Now())
+	tasks, err := dao.TaskDAO.List(ctx)
+	return tasks, dao.count("List", err)
+}
+
+func (dao *metricsTaskDAO) GetByID(ctx context.Context, id int) (Task, error) {
+	defer dao.observe("GetByID", time.Now())
+	task, err := dao.TaskDAO.GetByID(ctx, id)
+	return task, dao.count("GetByID", err)
+}
+
+func (dao *metricsTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	defer dao.observe("Create", time.Now())
+	task, err := dao.TaskDAO.Create(ctx, task)
+	return task, dao.count("Create", err)
+}
+
+func (dao *metricsTaskDAO) Delete(ctx context.Context, id int) error {
+	defer dao.observe("Delete", time.Now())
+	return dao.count("Delete", dao.TaskDAO.Delete(ctx, id))
+}
+
+func (dao *metricsTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	defer dao.observe("CreateTree", time.Now())
+	tasks, err := dao.TaskDAO.CreateTree(ctx, tree)
+	return tasks, dao.count("CreateTree", err)
+}
+
+func (dao *metricsTaskDAO) Update(ctx context.Context, task *Task) error {
+	defer dao.observe("Update", time.Now())
+	return dao.count("Update", dao.TaskDAO.Update(ctx, task))
+}
+
+func (dao *metricsTaskDAO) ListTrashed(ctx context.Context) ([]Task, error) {
+	defer dao.observe("ListTrashed", time.Now())
+	tasks, err := dao.TaskDAO.ListTrashed(ctx)
+	return tasks, dao.count("ListTrashed", err)
+}
+
+func (dao *metricsTaskDAO) Restore(ctx context.Context, id int) (Task, error) {
+	defer dao.observe("Restore", time.Now())
+	task, err := dao.TaskDAO.Restore(ctx, id)
+	return task, dao.count("Restore", err)
+}
+
+func (dao *metricsTaskDAO) Purge(ctx context.Context, id int) error {
+	defer dao.observe("Purge", time.Now())
+	return dao.count("Purge", dao.TaskDAO.Purge(ctx, id))
+}
+
+func (dao *metricsTaskDAO) PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error) {
+	defer dao.observe("PurgeTrashedBefore", time.Now())
+	ids, err := dao.TaskDAO.PurgeTrashedBefore(ctx, t)
+	return ids, dao.count("PurgeTrashedBefore", err)
+}
+
+func (dao *metricsTaskDAO) observe(method string, start time.Time) {
+	dao.duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// count counts err unless it is nil or ErrResourceNotFound, err is returned
+// as is.
+func (dao *metricsTaskDAO) count(method string, err error) error {
+	if err != nil && !errors.Is(err, ErrResourceNotFound) {
+		dao.errors.WithLabelValues(method).Inc()
+	}
+	return err
+}
+
+var _ TaskDAO = (*metricsTaskDAO)(nil)
+
+// taskCollector reports the number of the tasks of all tenants by status, the
+// tasks are counted on each scrape.
+type taskCollector struct {
+	taskDAO TaskDAO
+	tasks   *prometheus.Desc
+	errors  prometheus.Counter
+}
+
+// NewTaskCollector returns the collector of the tasks of taskDAO, which is not
+// scoped to a tenant.
+func NewTaskCollector(taskDAO TaskDAO) *taskCollector {
+	return &taskCollector{
+		taskDAO: taskDAO,
+		tasks: prometheus.NewDesc("gogo_tasks",
+			"Number of the tasks by status, the trashed tasks are counted apart.",
+			[]string{"status"}, nil),
+		errors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "gogo_task_collector_errors_total",
+			Help: "Failures to count the tasks.",
+		}),
+	}
+}
+
+func (collector *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.tasks
+	collector.errors.Describe(ch)
+}
+
+func (collector *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	counts, err := collector.count()
+	if err != nil {
+		collector.errors.Inc()
+	} else {
+		for _, status := range []string{"incomplete", "complete", "trashed"} {
+			ch <- prometheus.MustNewConstMetric(collector.tasks, prometheus.GaugeValue, float64(counts[status]), status)
+		}
+	}
+	collector.errors.Collect(ch)
+}
+
+// count counts the tasks in the background, since a scrape does not carry a
+// context.
+func (collector *taskCollector) count() (map[string]int, error) {
+	ctx := context.Background()
+	tenants, err := ListTenants(collector.taskDAO)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, tenant := range tenants {
+		taskDAO := ScopeTenant(collector.taskDAO, tenant)
+		tasks, err := taskDAO.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range tasks {
+			if tasks[i].Status == TaskStatusComplete {
+				counts["complete"]++
+			} else {
+				counts["incomplete"]++
+			}
+		}
+
+		trashed, err := taskDAO.ListTrashed(ctx)
+		if err != nil {
+			return nil, err
+		}
+		counts["trashed"] += len(trashed)
+	}
+
+	return counts, nil
+}
+
+var _ prometheus.Collector = (*taskCollector)(nil)
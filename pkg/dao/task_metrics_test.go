@@ -0,0 +1,89 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("MetricsTaskDAO", func() {
+	var (
+		taskDAO *metricsTaskDAO
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		taskDAO = NewMetricsTaskDAO(NewGoCacheTaskDAO(logger, cache), prometheus.NewRegistry())
+	})
+
+	It("should observe the latency of each method", func() {
+		_, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = taskDAO.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testutil.CollectAndCount(taskDAO.duration)).To(Equal(2))
+	})
+
+	It("should count the errors but not the missing tasks", func() {
+		_, err := taskDAO.GetByID(context.Background(), 1)
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		Expect(taskDAO.Update(context.Background(), nil)).NotTo(Succeed())
+
+		Expect(testutil.ToFloat64(taskDAO.errors.WithLabelValues("GetByID"))).To(BeZero())
+		Expect(testutil.ToFloat64(taskDAO.errors.WithLabelValues("Update"))).To(Equal(1.0))
+	})
+
+	It("should keep the metrics of the tenants together", func() {
+		_, err := ScopeTenant[TaskDAO](taskDAO, "acme").List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(testutil.CollectAndCount(taskDAO.duration)).To(Equal(1))
+	})
+})
+
+var _ = Describe("TaskCollector", func() {
+	It("should count the tasks of all tenants by status", func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		taskDAO := NewGoCacheTaskDAO(logger, cache)
+
+		_, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		task, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskDAO.Delete(context.Background(), task.ID)).To(Succeed())
+		acme := ScopeTenant[TaskDAO](taskDAO, "acme")
+		_, err = acme.Create(context.Background(), Task{Name: gofakeit.Noun(), Status: TaskStatusComplete})
+		Expect(err).NotTo(HaveOccurred())
+
+		collector := NewTaskCollector(taskDAO)
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collector)
+		count, err := testutil.GatherAndCount(registry, "gogo_tasks")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(3))
+
+		families, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		values := make(map[string]float64)
+		for _, family := range families {
+			if family.GetName() != "gogo_tasks" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				values[metric.GetLabel()[0].GetValue()] = metric.GetGauge().GetValue()
+			}
+		}
+		Expect(values).To(Equal(map[string]float64{"incomplete": 1, "complete": 1, "trashed": 1}))
+	})
+})
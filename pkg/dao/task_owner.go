@@ -0,0 +1,270 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// OwnerScoper is implemented by the TaskDAOs which can restrict the tasks to
+// the ones owned by or shared with a user.
+type OwnerScoper interface {
+	WithOwner(userID int) TaskDAO
+}
+
+// WithOwner returns the TaskDAO restricted to the tasks of the user, or
+// taskDAO itself if it does not know about the owners.
+func WithOwner(taskDAO TaskDAO, userID int) TaskDAO {
+	if scoper, ok := taskDAO.(OwnerScoper); ok {
+		return scoper.WithOwner(userID)
+	}
+	return taskDAO
+}
+
+// AccessGetter is implemented by the TaskDAOs which know the access of the user
+// to the tasks.
+type AccessGetter interface {
+	GetWithAccess(ctx context.Context, id int) (Task, ShareAccess, error)
+}
+
+// GetWithAccess returns the task along with the access of the user of taskDAO
+// to it, which is ShareAccessWrite if taskDAO does not know about the owners.
+func GetWithAccess(ctx context.Context, taskDAO TaskDAO, id int) (Task, ShareAccess, error) {
+	if getter, ok := taskDAO.(AccessGetter); ok {
+		return getter.GetWithAccess(ctx, id)
+	}
+
+	task, err := taskDAO.GetByID(ctx, id)
+	if err != nil {
+		return Task{}, "", err
+	}
+	return task, ShareAccessWrite, nil
+}
+
+// ownerTaskDAO decorates a TaskDAO to restrict the users to the tasks they own
+// or are shared with them. A user can read the tasks shared with read access,
+// update the ones shared with write access, and only the owner can delete,
+// restore or purge a task.
+type ownerTaskDAO struct {
+	taskDecorator
+	logger   *zap.SugaredLogger
+	shareDAO TaskShareDAO
+	// userID is the user the tasks are restricted to, 0 means unrestricted.
+	userID int
+}
+
+func NewOwnerTaskDAO(logger *zap.SugaredLogger, taskDAO TaskDAO, shareDAO TaskShareDAO) *ownerTaskDAO {
+	return &ownerTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		logger:        logger,
+		shareDAO:      shareDAO,
+	}
+}
+
+func (dao *ownerTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *ownerTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *ownerTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *ownerTaskDAO) scopeTenant(tenant string) {
+	dao.shareDAO = ScopeTenant(dao.shareDAO, tenant)
+}
+
+func (dao *ownerTaskDAO) scopeLogger(logger *zap.SugaredLogger) {
+	dao.shareDAO = ScopeLogger(dao.shareDAO, logger)
+	dao.logger = logger
+}
+
+func (dao *ownerTaskDAO) WithOwner(userID int) TaskDAO {
+	scoped := *dao
+	scoped.userID = userID
+	return &scoped
+}
+
+func (dao *ownerTaskDAO) List(ctx context.Context) ([]Task, error) {
+	tasks, err := dao.TaskDAO.List(ctx)
+	if err != nil || dao.userID == 0 {
+		return tasks, err
+	}
+
+	shares, err := dao.shareDAO.ListByUserID(dao.userID)
+	if err != nil {
+		return nil, err
+	}
+	shared := make(map[int]bool, len(shares))
+	for i := range shares {
+		shared[shares[i].TaskID] = true
+	}
+
+	visible := make([]Task, 0, len(tasks))
+	for i := range tasks {
+		if tasks[i].OwnerID == dao.userID || shared[tasks[i].ID] {
+			visible = append(visible, tasks[i])
+		}
+	}
+
+	return visible, nil
+}
+
+func (dao *ownerTaskDAO) GetByID(ctx context.Context, id int) (Task, error) {
+	task, _, err := dao.getVisible(ctx, id)
+	return task, err
+}
+
+func (dao *ownerTaskDAO) GetWithAccess(ctx context.Context, id int) (Task, ShareAccess, error) {
+	return dao.getVisible(ctx, id)
+}
+
+func (dao *ownerTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	if dao.userID != 0 {
+		task.OwnerID = dao.userID
+	}
+	return dao.TaskDAO.Create(ctx, task)
+}
+
+func (dao *ownerTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	if dao.userID != 0 {
+		tree = ownTaskTree(tree, dao.userID)
+	}
+	return dao.TaskDAO.CreateTree(ctx, tree)
+}
+
+func (dao *ownerTaskDAO) Update(ctx context.Context, task *Task) error {
+	if task == nil {
+		return errors.New("input task is nil")
+	}
+
+	current, access, err := dao.getVisible(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if access != ShareAccessWrite {
+		return ErrPermissionDenied
+	}
+
+	// the owner stays the same, the tasks are handed over by sharing
+	task.OwnerID = current.OwnerID
+	return dao.TaskDAO.Update(ctx, task)
+}
+
+func (dao *ownerTaskDAO) Delete(ctx context.Context, id int) error {
+	if dao.userID == 0 {
+		return dao.TaskDAO.Delete(ctx, id)
+	}
+
+	task, _, err := dao.getVisible(ctx, id)
+	if errors.Is(err, ErrResourceNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if task.OwnerID != dao.userID {
+		return ErrPermissionDenied
+	}
+
+	return dao.TaskDAO.Delete(ctx, id)
+}
+
+func (dao *ownerTaskDAO) ListTrashed(ctx context.Context) ([]Task, error) {
+	tasks, err := dao.TaskDAO.ListTrashed(ctx)
+	if err != nil || dao.userID == 0 {
+		return tasks, err
+	}
+
+	owned := make([]Task, 0, len(tasks))
+	for i := range tasks {
+		if tasks[i].OwnerID == dao.userID {
+			owned = append(owned, tasks[i])
+		}
+	}
+
+	return owned, nil
+}
+
+func (dao *ownerTaskDAO) Restore(ctx context.Context, id int) (Task, error) {
+	if err := dao.checkTrashedOwner(ctx, id); err != nil {
+		return Task{}, err
+	}
+	return dao.TaskDAO.Restore(ctx, id)
+}
+
+func (dao *ownerTaskDAO) Purge(ctx context.Context, id int) error {
+	if err := dao.checkTrashedOwner(ctx, id); err != nil {
+		return err
+	}
+	return dao.TaskDAO.Purge(ctx, id)
+}
+
+// PurgeTrashedBefore is left to the unrestricted TaskDAO, since it removes the
+// tasks of all users.
+func (dao *ownerTaskDAO) PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error) {
+	if dao.userID != 0 {
+		return nil, ErrPermissionDenied
+	}
+	return dao.TaskDAO.PurgeTrashedBefore(ctx, t)
+}
+
+// getVisible returns the task along with the access of the user to it, it
+// fails with ErrResourceNotFound if the task is not visible to the user.
+func (dao *ownerTaskDAO) getVisible(ctx context.Context, id int) (Task, ShareAccess, error) {
+	task, err := dao.TaskDAO.GetByID(ctx, id)
+	if err != nil {
+		return Task{}, "", err
+	}
+	if dao.userID == 0 || task.OwnerID == dao.userID {
+		return task, ShareAccessWrite, nil
+	}
+
+	share, err := dao.shareDAO.Get(id, dao.userID)
+	if errors.Is(err, ErrResourceNotFound) {
+		return Task{}, "", ErrResourceNotFound
+	} else if err != nil {
+		return Task{}, "", err
+	}
+
+	return task, share.Access, nil
+}
+
+// checkTrashedOwner fails with ErrResourceNotFound unless the trashed task is
+// owned by the user, the shares do not reach into the trash.
+func (dao *ownerTaskDAO) checkTrashedOwner(ctx context.Context, id int) error {
+	if dao.userID == 0 {
+		return nil
+	}
+
+	trashed, err := dao.TaskDAO.ListTrashed(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range trashed {
+		if trashed[i].ID == id && trashed[i].OwnerID == dao.userID {
+			return nil
+		}
+	}
+
+	return ErrResourceNotFound
+}
+
+// ownTaskTree returns a copy of the tree whose tasks are all owned by the user.
+func ownTaskTree(tree TaskTree, userID int) TaskTree {
+	owned := TaskTree{
+		Task:     tree.Task,
+		Subtasks: make([]TaskTree, 0, len(tree.Subtasks)),
+	}
+	owned.Task.OwnerID = userID
+	for i := range tree.Subtasks {
+		owned.Subtasks = append(owned.Subtasks, ownTaskTree(tree.Subtasks[i], userID))
+	}
+	return owned
+}
+
+var _ TaskDAO = (*ownerTaskDAO)(nil)
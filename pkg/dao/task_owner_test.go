@@ -0,0 +1,131 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("OwnerTaskDAO", func() {
+	var (
+		taskDAO  TaskDAO
+		shareDAO TaskShareDAO
+		alice    TaskDAO
+		bob      TaskDAO
+		task     Task
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		shareDAO = NewGoCacheTaskShareDAO(logger, cache)
+		taskDAO = NewOwnerTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), shareDAO)
+		alice = WithOwner(taskDAO, 1)
+		bob = WithOwner(taskDAO, 2)
+
+		var err error
+		task, err = alice.Create(context.Background(), Task{Name: gofakeit.Noun(), OwnerID: 2})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should make the user own the created tasks", func() {
+		Expect(task.OwnerID).To(Equal(1))
+
+		tasks, err := alice.CreateTree(context.Background(), TaskTree{
+			Task:     Task{Name: gofakeit.Noun()},
+			Subtasks: []TaskTree{{Task: Task{Name: gofakeit.Noun()}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(2))
+		Expect(tasks[1].OwnerID).To(Equal(1))
+	})
+
+	It("should hide the tasks of the others", func() {
+		tasks, err := bob.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(BeEmpty())
+
+		_, err = bob.GetByID(context.Background(), task.ID)
+		Expect(err).To(Equal(ErrResourceNotFound))
+
+		updated := task
+		Expect(bob.Update(context.Background(), &updated)).To(Equal(ErrResourceNotFound))
+		Expect(bob.Delete(context.Background(), task.ID)).To(Succeed())
+		_, err = alice.GetByID(context.Background(), task.ID)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should let the user read the task shared with read access", func() {
+		Expect(shareDAO.Set(&TaskShare{TaskID: task.ID, UserID: 2, Access: ShareAccessRead})).To(Succeed())
+
+		tasks, err := bob.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(1))
+
+		updated := task
+		updated.Name = gofakeit.Noun()
+		Expect(bob.Update(context.Background(), &updated)).To(Equal(ErrPermissionDenied))
+		Expect(bob.Delete(context.Background(), task.ID)).To(Equal(ErrPermissionDenied))
+
+		_, access, err := GetWithAccess(context.Background(), bob, task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(access).To(Equal(ShareAccessRead))
+		_, access, err = GetWithAccess(context.Background(), alice, task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(access).To(Equal(ShareAccessWrite))
+	})
+
+	It("should let the user update the task shared with write access, but not take it over", func() {
+		Expect(shareDAO.Set(&TaskShare{TaskID: task.ID, UserID: 2, Access: ShareAccessWrite})).To(Succeed())
+
+		updated := task
+		updated.Name = gofakeit.Noun()
+		updated.OwnerID = 2
+		Expect(bob.Update(context.Background(), &updated)).To(Succeed())
+		Expect(updated.OwnerID).To(Equal(1))
+		Expect(bob.Delete(context.Background(), task.ID)).To(Equal(ErrPermissionDenied))
+	})
+
+	It("should keep the trash of the owner", func() {
+		Expect(shareDAO.Set(&TaskShare{TaskID: task.ID, UserID: 2, Access: ShareAccessWrite})).To(Succeed())
+		Expect(alice.Delete(context.Background(), task.ID)).To(Succeed())
+
+		trashed, err := bob.ListTrashed(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trashed).To(BeEmpty())
+		_, err = bob.Restore(context.Background(), task.ID)
+		Expect(err).To(Equal(ErrResourceNotFound))
+		Expect(bob.Purge(context.Background(), task.ID)).To(Equal(ErrResourceNotFound))
+		_, err = bob.PurgeTrashedBefore(context.Background(), time.Now())
+		Expect(err).To(Equal(ErrPermissionDenied))
+
+		_, err = alice.Restore(context.Background(), task.ID)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not restrict the unscoped TaskDAO", func() {
+		_, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		tasks, err := taskDAO.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(2))
+	})
+
+	It("should scope the shares to the tenant", func() {
+		Expect(shareDAO.Set(&TaskShare{TaskID: task.ID, UserID: 2, Access: ShareAccessRead})).To(Succeed())
+
+		acme := WithOwner(ScopeTenant(taskDAO, "acme"), 1)
+		created, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.ID).To(Equal(task.ID))
+
+		_, err = WithOwner(ScopeTenant(taskDAO, "acme"), 2).GetByID(context.Background(), created.ID)
+		Expect(err).To(Equal(ErrResourceNotFound))
+	})
+})
@@ -0,0 +1,112 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ErrTaskQuotaExceeded is returned if the tenant would store more tasks than
+// its quota allows.
+var ErrTaskQuotaExceeded = errors.New("task quota exceeded")
+
+// quotaTaskDAO decorates a TaskDAO to limit the number of the tasks of each
+// tenant. The trashed tasks are counted as well, since they take the memory
+// until purged.
+type quotaTaskDAO struct {
+	taskDecorator
+	logger       *zap.SugaredLogger
+	defaultQuota int
+	quotas       map[string]int
+	tenant       string
+	// mu serializes the creations of all tenants, so the tasks are counted
+	// right
+	mu *sync.Mutex
+}
+
+// NewQuotaTaskDAO limits the tenants in quotas to their quotas and the others
+// to defaultQuota, 0 means unlimited.
+func NewQuotaTaskDAO(logger *zap.SugaredLogger, taskDAO TaskDAO, defaultQuota int, quotas map[string]int) *quotaTaskDAO {
+	return &quotaTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		logger:        logger,
+		defaultQuota:  defaultQuota,
+		quotas:        quotas,
+		mu:            &sync.Mutex{},
+	}
+}
+
+func (dao *quotaTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *quotaTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *quotaTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *quotaTaskDAO) scopeTenant(tenant string) {
+	dao.tenant = tenant
+}
+
+func (dao *quotaTaskDAO) scopeLogger(logger *zap.SugaredLogger) {
+	dao.logger = logger
+}
+
+func (dao *quotaTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if err := dao.checkQuota(ctx, 1); err != nil {
+		return Task{}, err
+	}
+
+	return dao.TaskDAO.Create(ctx, task)
+}
+
+func (dao *quotaTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	tasks, _ := tree.Flatten()
+	if err := dao.checkQuota(ctx, len(tasks)); err != nil {
+		return nil, err
+	}
+
+	return dao.TaskDAO.CreateTree(ctx, tree)
+}
+
+// checkQuota fails with ErrTaskQuotaExceeded if n more tasks exceed the quota
+// of the tenant.
+func (dao *quotaTaskDAO) checkQuota(ctx context.Context, n int) error {
+	quota, ok := dao.quotas[dao.tenant]
+	if !ok {
+		quota = dao.defaultQuota
+	}
+	if quota <= 0 {
+		return nil
+	}
+
+	tasks, err := dao.TaskDAO.List(ctx)
+	if err != nil {
+		return err
+	}
+	trashed, err := dao.TaskDAO.ListTrashed(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(tasks)+len(trashed)+n > quota {
+		dao.logger.Infof("task quota exceeded, tenant=%v, quota=%v", dao.tenant, quota)
+		return ErrTaskQuotaExceeded
+	}
+
+	return nil
+}
+
+var _ TaskDAO = (*quotaTaskDAO)(nil)
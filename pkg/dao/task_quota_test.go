@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("QuotaTaskDAO", func() {
+	var (
+		taskDAO TaskDAO
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		taskDAO = NewQuotaTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), 2, map[string]int{"acme": 3, "globex": 0})
+	})
+
+	It("should limit the tasks of the tenant, including the trashed ones", func() {
+		task, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskDAO.Delete(context.Background(), task.ID)).To(Succeed())
+		_, err = taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).To(Equal(ErrTaskQuotaExceeded))
+	})
+
+	It("should count the tasks of each tenant apart", func() {
+		acme := ScopeTenant(taskDAO, "acme")
+		for i := 0; i < 3; i++ {
+			_, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).To(Equal(ErrTaskQuotaExceeded))
+
+		_, err = taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not limit the tenant whose quota is 0", func() {
+		globex := ScopeTenant(taskDAO, "globex")
+		for i := 0; i < 5; i++ {
+			_, err := globex.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should reject the tree exceeding the quota as a whole", func() {
+		tree := TaskTree{
+			Task:     Task{Name: gofakeit.Noun()},
+			Subtasks: []TaskTree{{Task: Task{Name: gofakeit.Noun()}}, {Task: Task{Name: gofakeit.Noun()}}},
+		}
+		_, err := taskDAO.CreateTree(context.Background(), tree)
+		Expect(err).To(Equal(ErrTaskQuotaExceeded))
+
+		tasks, err := taskDAO.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(BeEmpty())
+	})
+})
@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"context"
+	"go.uber.org/zap"
+)
+
+// ActorScoper is implemented by the TaskDAOs which need to know who operates
+// on the tasks.
+type ActorScoper interface {
+	WithActor(actor string) TaskDAO
+}
+
+// WithActor returns the TaskDAO acting on behalf of actor, or taskDAO itself
+// if it does not care about the actor.
+func WithActor(taskDAO TaskDAO, actor string) TaskDAO {
+	if scoper, ok := taskDAO.(ActorScoper); ok {
+		return scoper.WithActor(actor)
+	}
+	return taskDAO
+}
+
+// revisionTaskDAO decorates a TaskDAO to record every mutation as a revision.
+type revisionTaskDAO struct {
+	taskDecorator
+	logger      *zap.SugaredLogger
+	revisionDAO RevisionDAO
+	actor       string
+}
+
+func NewRevisionTaskDAO(logger *zap.SugaredLogger, taskDAO TaskDAO, revisionDAO RevisionDAO) *revisionTaskDAO {
+	return &revisionTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		logger:        logger,
+		revisionDAO:   revisionDAO,
+	}
+}
+
+func (dao *revisionTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *revisionTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *revisionTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *revisionTaskDAO) scopeActor(actor string) {
+	dao.actor = actor
+}
+
+func (dao *revisionTaskDAO) scopeTenant(tenant string) {
+	dao.revisionDAO = ScopeTenant(dao.revisionDAO, tenant)
+}
+
+func (dao *revisionTaskDAO) scopeLogger(logger *zap.SugaredLogger) {
+	dao.revisionDAO = ScopeLogger(dao.revisionDAO, logger)
+	dao.logger = logger
+}
+
+func (dao *revisionTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	task, err := dao.TaskDAO.Create(ctx, task)
+	if err != nil {
+		return Task{}, err
+	}
+
+	after := task.Clone()
+	dao.record(task.ID, RevisionActionCreate, nil, &after)
+
+	return task, nil
+}
+
+func (dao *revisionTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	tasks, err := dao.TaskDAO.CreateTree(ctx, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tasks {
+		after := tasks[i].Clone()
+		dao.record(tasks[i].ID, RevisionActionCreate, nil, &after)
+	}
+
+	return tasks, nil
+}
+
+func (dao *revisionTaskDAO) Update(ctx context.Context, task *Task) error {
+	var before *Task
+	if task != nil {
+		if current, err := dao.TaskDAO.GetByID(ctx, task.ID); err == nil {
+			before = &current
+		}
+	}
+
+	if err := dao.TaskDAO.Update(ctx, task); err != nil {
+		return err
+	}
+
+	after := task.Clone()
+	dao.record(task.ID, RevisionActionUpdate, before, &after)
+
+	return nil
+}
+
+func (dao *revisionTaskDAO) Delete(ctx context.Context, id int) error {
+	current, err := dao.TaskDAO.GetByID(ctx, id)
+	if err != nil {
+		// there is nothing to record for the tasks which do not exist or
+		// have been deleted already
+		return dao.TaskDAO.Delete(ctx, id)
+	}
+
+	if err := dao.TaskDAO.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	dao.record(id, RevisionActionDelete, &current, nil)
+
+	return nil
+}
+
+func (dao *revisionTaskDAO) Restore(ctx context.Context, id int) (Task, error) {
+	task, err := dao.TaskDAO.Restore(ctx, id)
+	if err != nil {
+		return Task{}, err
+	}
+
+	after := task.Clone()
+	dao.record(id, RevisionActionRestore, nil, &after)
+
+	return task, nil
+}
+
+func (dao *revisionTaskDAO) record(taskID int, action RevisionAction, before, after *Task) {
+	revision := Revision{
+		TaskID:    taskID,
+		Action:    action,
+		Actor:     dao.actor,
+		CreatedAt: now(),
+		Before:    before,
+		After:     after,
+	}
+
+	// the task has been mutated, so a failed recording is only logged
+	if err := dao.revisionDAO.Create(&revision); err != nil {
+		dao.logger.Errorf("revisionDAO.Create failed, err=%v, taskID=%v, action=%v", err, taskID, action)
+	}
+}
+
+var _ TaskDAO = (*revisionTaskDAO)(nil)
@@ -0,0 +1,109 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("RevisionTaskDAO", func() {
+	var (
+		revisionDAO *goCacheRevisionDAO
+		taskDAO     TaskDAO
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		revisionDAO = NewGoCacheRevisionDAO(logger, cache)
+		cascadeTaskDAO := NewCascadeTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), Dependent[RevisionDAO](revisionDAO))
+		taskDAO = WithActor(NewRevisionTaskDAO(logger, cascadeTaskDAO, revisionDAO), "alice")
+	})
+
+	It("should record every mutation", func() {
+		task, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := task
+		updated.Status = TaskStatusComplete
+		Expect(taskDAO.Update(context.Background(), &updated)).To(Succeed())
+		Expect(taskDAO.Delete(context.Background(), task.ID)).To(Succeed())
+		_, err = taskDAO.Restore(context.Background(), task.ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		revisions, err := revisionDAO.ListByTaskID(task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revisions).To(HaveLen(4))
+
+		actions := make([]RevisionAction, 0, len(revisions))
+		for i := range revisions {
+			Expect(revisions[i].Number).To(Equal(i + 1))
+			Expect(revisions[i].Actor).To(Equal("alice"))
+			actions = append(actions, revisions[i].Action)
+		}
+		Expect(actions).To(Equal([]RevisionAction{
+			RevisionActionCreate,
+			RevisionActionUpdate,
+			RevisionActionDelete,
+			RevisionActionRestore,
+		}))
+
+		Expect(revisions[1].Changes()).To(Equal([]FieldChange{
+			{Field: "status", Before: TaskStatusIncomplete, After: TaskStatusComplete},
+			{Field: "completed_at", Before: (*time.Time)(nil), After: updated.CompletedAt},
+		}))
+	})
+
+	It("should record the creation of every task in a tree", func() {
+		tasks, err := taskDAO.CreateTree(context.Background(), TaskTree{
+			Task:     Task{Name: gofakeit.Noun()},
+			Subtasks: []TaskTree{{Task: Task{Name: gofakeit.Noun()}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(HaveLen(2))
+
+		for i := range tasks {
+			revisions, err := revisionDAO.ListByTaskID(tasks[i].ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revisions).To(HaveLen(1))
+			Expect(revisions[0].Action).To(Equal(RevisionActionCreate))
+			Expect(*revisions[0].After).To(Equal(tasks[i]))
+		}
+	})
+
+	It("should not list revisions as tasks", func() {
+		task, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		tasks, err := taskDAO.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tasks).To(Equal([]Task{task}))
+	})
+
+	It("should not record failed mutations", func() {
+		err := taskDAO.Update(context.Background(), &Task{ID: 1})
+		Expect(err).To(Equal(ErrResourceNotFound))
+
+		revisions, err := revisionDAO.ListByTaskID(1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revisions).To(BeEmpty())
+	})
+
+	It("should delete revisions of purged tasks", func() {
+		task, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskDAO.Delete(context.Background(), task.ID)).To(Succeed())
+
+		_, err = taskDAO.PurgeTrashedBefore(context.Background(), time.Now().Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+
+		revisions, err := revisionDAO.ListByTaskID(task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revisions).To(BeEmpty())
+	})
+})
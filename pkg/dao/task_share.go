@@ -0,0 +1,45 @@
+package dao
+
+import (
+	"time"
+)
+
+// ShareAccess is what a user can do to a task shared with the user.
+type ShareAccess string
+
+const (
+	ShareAccessRead  ShareAccess = "read"
+	ShareAccessWrite ShareAccess = "write"
+)
+
+func (a ShareAccess) Valid() bool {
+	return a == ShareAccessRead || a == ShareAccessWrite
+}
+
+// TaskShare grants a user the access to a task owned by another user.
+type TaskShare struct {
+	TaskID int         `json:"task_id"`
+	UserID int         `json:"user_id"`
+	Access ShareAccess `json:"access"`
+	// CreatedAt and UpdatedAt are managed by the TaskShareDAO.
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TaskShareDAO interface {
+	TenantScoper[TaskShareDAO]
+	LoggerScoper[TaskShareDAO]
+
+	// ListByTaskID returns the shares of the task in ascending order of the
+	// user IDs.
+	ListByTaskID(taskID int) ([]TaskShare, error)
+	// ListByUserID returns the shares with the user in ascending order of the
+	// task IDs.
+	ListByUserID(userID int) ([]TaskShare, error)
+	Get(taskID, userID int) (TaskShare, error)
+	// Set shares the task or updates the access of an existing share, the
+	// managed timestamps are written back to it.
+	Set(share *TaskShare) error
+	Delete(taskID, userID int) error
+	DeleteByTaskID(taskID int) error
+}
@@ -0,0 +1,144 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(TaskShare{})
+}
+
+const (
+	cacheKeyPrefixTaskShare = "share:"
+)
+
+type goCacheTaskShareDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheTaskShareDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheTaskShareDAO {
+	return &goCacheTaskShareDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheTaskShareDAO) WithTenant(tenant string) TaskShareDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheTaskShareDAO) WithLogger(logger *zap.SugaredLogger) TaskShareDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheTaskShareDAO) ListByTaskID(taskID int) ([]TaskShare, error) {
+	return dao.list(func(share TaskShare) bool {
+		return share.TaskID == taskID
+	}), nil
+}
+
+func (dao *goCacheTaskShareDAO) ListByUserID(userID int) ([]TaskShare, error) {
+	return dao.list(func(share TaskShare) bool {
+		return share.UserID == userID
+	}), nil
+}
+
+func (dao *goCacheTaskShareDAO) Get(taskID, userID int) (TaskShare, error) {
+	item, found := dao.cache.Get(dao.cacheKey(taskID, userID))
+	if !found {
+		return TaskShare{}, ErrResourceNotFound
+	}
+
+	share, ok := item.(TaskShare)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(TaskShare)")
+		return TaskShare{}, errors.New("type assertion failed")
+	}
+
+	return share, nil
+}
+
+func (dao *goCacheTaskShareDAO) Set(share *TaskShare) error {
+	if share == nil {
+		return errors.New("input share is nil")
+	}
+
+	share.UpdatedAt = now()
+	share.CreatedAt = share.UpdatedAt
+	if current, err := dao.Get(share.TaskID, share.UserID); err == nil {
+		share.CreatedAt = current.CreatedAt
+	}
+	dao.cache.SetDefault(dao.cacheKey(share.TaskID, share.UserID), *share)
+
+	return nil
+}
+
+func (dao *goCacheTaskShareDAO) Delete(taskID, userID int) error {
+	dao.cache.Delete(dao.cacheKey(taskID, userID))
+
+	return nil
+}
+
+func (dao *goCacheTaskShareDAO) DeleteByTaskID(taskID int) error {
+	shares := dao.list(func(share TaskShare) bool {
+		return share.TaskID == taskID
+	})
+	for i := range shares {
+		dao.cache.Delete(dao.cacheKey(shares[i].TaskID, shares[i].UserID))
+	}
+
+	return nil
+}
+
+// list returns the shares matched by the filter, sorted by the task IDs and
+// then the user IDs.
+func (dao *goCacheTaskShareDAO) list(filter func(share TaskShare) bool) []TaskShare {
+	shares := make([]TaskShare, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixTaskShare) {
+			continue
+		}
+
+		share, ok := item.Object.(TaskShare)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(TaskShare)")
+			continue
+		}
+
+		if filter(share) {
+			shares = append(shares, share)
+		}
+	}
+
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].TaskID != shares[j].TaskID {
+			return shares[i].TaskID < shares[j].TaskID
+		}
+		return shares[i].UserID < shares[j].UserID
+	})
+
+	return shares
+}
+
+func (dao *goCacheTaskShareDAO) cacheKey(taskID, userID int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + taskShareCacheKey(taskID, userID)
+}
+
+func taskShareCacheKey(taskID, userID int) string {
+	return fmt.Sprintf("%v%v:%v", cacheKeyPrefixTaskShare, taskID, userID)
+}
+
+var _ TaskShareDAO = (*goCacheTaskShareDAO)(nil)
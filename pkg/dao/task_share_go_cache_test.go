@@ -0,0 +1,73 @@
+package dao
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheTaskShareDAO", func() {
+	var (
+		dao *goCacheTaskShareDAO
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheTaskShareDAO(zap.NewNop().Sugar(), NewGoCache())
+	})
+
+	Describe("Set", func() {
+		It("should update the access and keep the creation time", func() {
+			share := TaskShare{TaskID: 1, UserID: 2, Access: ShareAccessRead}
+			Expect(dao.Set(&share)).To(Succeed())
+			createdAt := share.CreatedAt
+			Expect(createdAt).NotTo(BeZero())
+
+			share = TaskShare{TaskID: 1, UserID: 2, Access: ShareAccessWrite}
+			Expect(dao.Set(&share)).To(Succeed())
+
+			stored, err := dao.Get(1, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Access).To(Equal(ShareAccessWrite))
+			Expect(stored.CreatedAt).To(Equal(createdAt))
+		})
+	})
+
+	Describe("Get", func() {
+		It("should get ErrResourceNotFound if the task is not shared with the user", func() {
+			_, err := dao.Get(1, 2)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+
+		It("should not get the share of another tenant", func() {
+			share := TaskShare{TaskID: 1, UserID: 2, Access: ShareAccessRead}
+			Expect(dao.Set(&share)).To(Succeed())
+
+			_, err := ScopeTenant[TaskShareDAO](dao, "acme").Get(1, 2)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+
+	Describe("DeleteByTaskID", func() {
+		It("should only delete the shares of the task", func() {
+			for _, share := range []TaskShare{
+				{TaskID: 1, UserID: 3, Access: ShareAccessRead},
+				{TaskID: 1, UserID: 2, Access: ShareAccessWrite},
+				{TaskID: 2, UserID: 2, Access: ShareAccessRead},
+			} {
+				share := share
+				Expect(dao.Set(&share)).To(Succeed())
+			}
+
+			shares, err := dao.ListByTaskID(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shares).To(HaveLen(2))
+			Expect(shares[0].UserID).To(Equal(2))
+
+			Expect(dao.DeleteByTaskID(1)).To(Succeed())
+			shares, err = dao.ListByUserID(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shares).To(HaveLen(1))
+			Expect(shares[0].TaskID).To(Equal(2))
+		})
+	})
+})
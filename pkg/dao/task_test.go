@@ -0,0 +1,33 @@
+package dao
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Task", func() {
+	Describe("Watch", func() {
+		It("should add the watcher once", func() {
+			var task Task
+			Expect(task.Watch(1)).To(BeTrue())
+			Expect(task.Watch(1)).To(BeFalse())
+			Expect(task.WatcherIDs).To(Equal([]int{1}))
+			Expect(task.IsWatchedBy(1)).To(BeTrue())
+		})
+	})
+
+	Describe("Unwatch", func() {
+		It("should remove the watcher without touching the clones", func() {
+			task := Task{WatcherIDs: []int{1, 2}}
+			clone := task.Clone()
+
+			Expect(task.Unwatch(1)).To(BeTrue())
+			Expect(task.Unwatch(1)).To(BeFalse())
+			Expect(task.WatcherIDs).To(Equal([]int{2}))
+			Expect(clone.WatcherIDs).To(Equal([]int{1, 2}))
+
+			Expect(task.Unwatch(2)).To(BeTrue())
+			Expect(task.WatcherIDs).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	tracerName = "gogo-exercise/pkg/dao"
+)
+
+// tracingTaskDAO decorates a TaskDAO to record a span of each method, as a
+// child of the span in the context. ErrResourceNotFound is not recorded as an
+// error, as the metricsTaskDAO does not count it.
+type tracingTaskDAO struct {
+	taskDecorator
+	tracer trace.Tracer
+	tenant string
+}
+
+// NewTracingTaskDAO records the spans of taskDAO by the tracer of provider.
+func NewTracingTaskDAO(taskDAO TaskDAO, provider trace.TracerProvider) *tracingTaskDAO {
+	return &tracingTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		tracer:        provider.Tracer(tracerName),
+		tenant:        DefaultTenant,
+	}
+}
+
+func (dao *tracingTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *tracingTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *tracingTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *tracingTaskDAO) scopeTenant(tenant string) {
+	dao.tenant = tenant
+}
+
+func (dao *tracingTaskDAO) List(ctx context.Context) ([]Task, error) {
+	ctx, span := dao.start(ctx, "List")
+	tasks, err := dao.TaskDAO.List(ctx)
+	span.SetAttributes(attribute.Int("task.count", len(tasks)))
+	return tasks, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) GetByID(ctx context.Context, id int) (Task, error) {
+	ctx, span := dao.start(ctx, "GetByID", attribute.Int("task.id", id))
+	task, err := dao.TaskDAO.GetByID(ctx, id)
+	return task, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	ctx, span := dao.start(ctx, "Create")
+	task, err := dao.TaskDAO.Create(ctx, task)
+	span.SetAttributes(attribute.Int("task.id", task.ID))
+	return task, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) Delete(ctx context.Context, id int) error {
+	ctx, span := dao.start(ctx, "Delete", attribute.Int("task.id", id))
+	return dao.end(span, dao.TaskDAO.Delete(ctx, id))
+}
+
+func (dao *tracingTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	ctx, span := dao.start(ctx, "CreateTree")
+	tasks, err := dao.TaskDAO.CreateTree(ctx, tree)
+	span.SetAttributes(attribute.Int("task.count", len(tasks)))
+	return tasks, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) Update(ctx context.Context, task *Task) error {
+	if task == nil {
+		return errors.New("input task is nil")
+	}
+	ctx, span := dao.start(ctx, "Update", attribute.Int("task.id", task.ID))
+	return dao.end(span, dao.TaskDAO.Update(ctx, task))
+}
+
+func (dao *tracingTaskDAO) ListTrashed(ctx context.Context) ([]Task, error) {
+	ctx, span := dao.start(ctx, "ListTrashed")
+	tasks, err := dao.TaskDAO.ListTrashed(ctx)
+	span.SetAttributes(attribute.Int("task.count", len(tasks)))
+	return tasks, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) Restore(ctx context.Context, id int) (Task, error) {
+	ctx, span := dao.start(ctx, "Restore", attribute.Int("task.id", id))
+	task, err := dao.TaskDAO.Restore(ctx, id)
+	return task, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) Purge(ctx context.Context, id int) error {
+	ctx, span := dao.start(ctx, "Purge", attribute.Int("task.id", id))
+	return dao.end(span, dao.TaskDAO.Purge(ctx, id))
+}
+
+func (dao *tracingTaskDAO) PurgeTrashedBefore(ctx context.Context, t time.Time) ([]int, error) {
+	ctx, span := dao.start(ctx, "PurgeTrashedBefore")
+	ids, err := dao.TaskDAO.PurgeTrashedBefore(ctx, t)
+	span.SetAttributes(attribute.Int("task.count", len(ids)))
+	return ids, dao.end(span, err)
+}
+
+func (dao *tracingTaskDAO) start(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.String("tenant", dao.tenant))
+	return dao.tracer.Start(ctx, "TaskDAO."+method, trace.WithAttributes(attrs...))
+}
+
+// end ends the span, err is recorded unless it is nil or ErrResourceNotFound
+// and returned as is.
+func (dao *tracingTaskDAO) end(span trace.Span, err error) error {
+	if err != nil && !errors.Is(err, ErrResourceNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	return err
+}
+
+var _ TaskDAO = (*tracingTaskDAO)(nil)
@@ -0,0 +1,68 @@
+package dao
+
+import (
+	"context"
+	"errors"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gocache "github.com/patrickmn/go-cache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("TracingTaskDAO", func() {
+	var (
+		cache    *gocache.Cache
+		recorder *tracetest.SpanRecorder
+		provider *sdktrace.TracerProvider
+		taskDAO  *tracingTaskDAO
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache = NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		recorder = tracetest.NewSpanRecorder()
+		provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		taskDAO = NewTracingTaskDAO(NewGoCacheTaskDAO(logger, cache), provider)
+	})
+
+	It("should record a span of each method under the span in the context", func() {
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+		task, err := taskDAO.Create(ctx, Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = ScopeTenant[TaskDAO](taskDAO, "acme").GetByID(ctx, task.ID)
+		Expect(errors.Is(err, ErrResourceNotFound)).To(BeTrue())
+		parent.End()
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(3))
+		Expect(spans[0].Name()).To(Equal("TaskDAO.Create"))
+		Expect(spans[0].Parent().SpanID()).To(Equal(parent.SpanContext().SpanID()))
+		Expect(spans[0].Attributes()).To(ContainElements(
+			attribute.Int("task.id", task.ID),
+			attribute.String("tenant", DefaultTenant),
+		))
+		Expect(spans[1].Name()).To(Equal("TaskDAO.GetByID"))
+		Expect(spans[1].Attributes()).To(ContainElement(attribute.String("tenant", "acme")))
+		// the missing task is not an error
+		Expect(spans[1].Status().Code).To(Equal(codes.Unset))
+	})
+
+	It("should record the errors", func() {
+		// the ID sequence is corrupted, so creating a task fails
+		cache.SetDefault(cacheKeyNextTaskID, "corrupted")
+		_, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).To(HaveOccurred())
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[0].Events()).To(HaveLen(1))
+	})
+})
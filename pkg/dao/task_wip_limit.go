@@ -0,0 +1,188 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ErrWIPLimitReached is returned if a task would enter a board column which
+// has reached its WIP limit.
+var ErrWIPLimitReached = errors.New("WIP limit reached")
+
+// wipLimitTaskDAO decorates a TaskDAO to keep the columns of the boards within
+// their WIP limits. Every write placing a task into a column is checked,
+// whether it is a creation, an update or a restoration, while a task staying
+// in its column is let be even if the limit has been lowered below the cards.
+// It counts all the tasks of the tenant, so it goes under the ownerTaskDAO.
+type wipLimitTaskDAO struct {
+	taskDecorator
+	logger   *zap.SugaredLogger
+	boardDAO BoardDAO
+	// mu serializes the writes of all tenants, so the cards are counted right
+	mu *sync.Mutex
+}
+
+func NewWIPLimitTaskDAO(logger *zap.SugaredLogger, taskDAO TaskDAO, boardDAO BoardDAO) *wipLimitTaskDAO {
+	return &wipLimitTaskDAO{
+		taskDecorator: taskDecorator{TaskDAO: taskDAO},
+		logger:        logger,
+		boardDAO:      boardDAO,
+		mu:            &sync.Mutex{},
+	}
+}
+
+func (dao *wipLimitTaskDAO) WithActor(actor string) TaskDAO {
+	return decoratorWithActor(dao, actor)
+}
+
+func (dao *wipLimitTaskDAO) WithTenant(tenant string) TaskDAO {
+	return decoratorWithTenant(dao, tenant)
+}
+
+func (dao *wipLimitTaskDAO) WithLogger(logger *zap.SugaredLogger) TaskDAO {
+	return decoratorWithLogger(dao, logger)
+}
+
+func (dao *wipLimitTaskDAO) scopeTenant(tenant string) {
+	dao.boardDAO = ScopeTenant(dao.boardDAO, tenant)
+}
+
+func (dao *wipLimitTaskDAO) scopeLogger(logger *zap.SugaredLogger) {
+	dao.boardDAO = ScopeLogger(dao.boardDAO, logger)
+	dao.logger = logger
+}
+
+func (dao *wipLimitTaskDAO) Create(ctx context.Context, task Task) (Task, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if err := dao.checkWIPLimits(ctx, []Task{task}); err != nil {
+		return Task{}, err
+	}
+
+	return dao.TaskDAO.Create(ctx, task)
+}
+
+func (dao *wipLimitTaskDAO) CreateTree(ctx context.Context, tree TaskTree) ([]Task, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	tasks, _ := tree.Flatten()
+	if err := dao.checkWIPLimits(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	return dao.TaskDAO.CreateTree(ctx, tree)
+}
+
+func (dao *wipLimitTaskDAO) Update(ctx context.Context, task *Task) error {
+	if task == nil {
+		return errors.New("input task is nil")
+	}
+
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if err := dao.checkWIPLimits(ctx, []Task{*task}); err != nil {
+		return err
+	}
+
+	return dao.TaskDAO.Update(ctx, task)
+}
+
+func (dao *wipLimitTaskDAO) Restore(ctx context.Context, id int) (Task, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	trashed, err := dao.TaskDAO.ListTrashed(ctx)
+	if err != nil {
+		return Task{}, err
+	}
+	for i := range trashed {
+		if trashed[i].ID == id {
+			if err := dao.checkWIPLimits(ctx, trashed[i:i+1]); err != nil {
+				return Task{}, err
+			}
+			break
+		}
+	}
+
+	return dao.TaskDAO.Restore(ctx, id)
+}
+
+// checkWIPLimits fails with ErrWIPLimitReached if writing the tasks moves more
+// cards into a column than its WIP limit allows. The tasks which are not
+// listed yet, such as the new ones, enter their columns.
+func (dao *wipLimitTaskDAO) checkWIPLimits(ctx context.Context, written []Task) error {
+	boards, err := dao.boardDAO.List()
+	if err != nil {
+		return err
+	}
+	if !anyWIPLimit(boards) {
+		return nil
+	}
+
+	tasks, err := dao.TaskDAO.List(ctx)
+	if err != nil {
+		return err
+	}
+	current := make(map[int]Task, len(tasks))
+	for i := range tasks {
+		current[tasks[i].ID] = tasks[i]
+	}
+	writtenIDs := make(map[int]bool, len(written))
+	for i := range written {
+		if written[i].ID != 0 {
+			writtenIDs[written[i].ID] = true
+		}
+	}
+
+	for _, board := range boards {
+		for _, column := range board.Columns {
+			if column.WIPLimit == 0 {
+				continue
+			}
+
+			cards, entering := 0, 0
+			for i := range tasks {
+				if !writtenIDs[tasks[i].ID] && board.Shows(tasks[i]) && board.ColumnKeyOf(tasks[i]) == column.Key {
+					cards++
+				}
+			}
+			for i := range written {
+				if !board.Shows(written[i]) || board.ColumnKeyOf(written[i]) != column.Key {
+					continue
+				}
+				if before, ok := current[written[i].ID]; ok && board.Shows(before) && board.ColumnKeyOf(before) == column.Key {
+					cards++
+				} else {
+					entering++
+				}
+			}
+
+			if entering > 0 && cards+entering > column.WIPLimit {
+				dao.logger.Infof("WIP limit reached, boardID=%v, column=%v, limit=%v", board.ID, column.Key, column.WIPLimit)
+				return fmt.Errorf("%w: column %v of board %v is full, its WIP limit is %d", ErrWIPLimitReached, column.Key, board.Name, column.WIPLimit)
+			}
+		}
+	}
+
+	return nil
+}
+
+func anyWIPLimit(boards []Board) bool {
+	for _, board := range boards {
+		for _, column := range board.Columns {
+			if column.WIPLimit > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var _ TaskDAO = (*wipLimitTaskDAO)(nil)
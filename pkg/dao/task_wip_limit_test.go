@@ -0,0 +1,97 @@
+package dao
+
+import (
+	"context"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("WIPLimitTaskDAO", func() {
+	var (
+		taskDAO  TaskDAO
+		boardDAO BoardDAO
+		doing    Task
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		boardDAO = NewGoCacheBoardDAO(logger, cache)
+		_, err := boardDAO.Create(Board{
+			Name:    gofakeit.Noun(),
+			GroupBy: BoardGroupByColumn,
+			Columns: []BoardColumn{{Key: "todo"}, {Key: "doing", WIPLimit: 1}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = boardDAO.Create(Board{
+			Name:    gofakeit.Noun(),
+			GroupBy: BoardGroupByStatus,
+			Columns: []BoardColumn{{Key: "incomplete"}, {Key: "complete", WIPLimit: 1}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		shareDAO := NewGoCacheTaskShareDAO(logger, cache)
+		taskDAO = NewOwnerTaskDAO(logger, NewWIPLimitTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), boardDAO), shareDAO)
+		doing, err = WithOwner(taskDAO, 1).Create(context.Background(), Task{Name: gofakeit.Noun(), Column: "doing"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should count the tasks of all owners", func() {
+		bob := WithOwner(taskDAO, 2)
+		_, err := bob.Create(context.Background(), Task{Name: gofakeit.Noun(), Column: "doing"})
+		Expect(err).To(MatchError(ErrWIPLimitReached))
+
+		task, err := bob.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		task.Column = "doing"
+		Expect(bob.Update(context.Background(), &task)).To(MatchError(ErrWIPLimitReached))
+	})
+
+	It("should limit the status columns", func() {
+		task, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun(), Status: TaskStatusComplete})
+		Expect(err).NotTo(HaveOccurred())
+
+		doing.Status = TaskStatusComplete
+		Expect(taskDAO.Update(context.Background(), &doing)).To(MatchError(ErrWIPLimitReached))
+
+		task.Status = TaskStatusIncomplete
+		Expect(taskDAO.Update(context.Background(), &task)).To(Succeed())
+		Expect(taskDAO.Update(context.Background(), &doing)).To(Succeed())
+	})
+
+	It("should let the task stay in its column", func() {
+		doing.Name = gofakeit.Noun()
+		Expect(taskDAO.Update(context.Background(), &doing)).To(Succeed())
+	})
+
+	It("should reject the tree exceeding the limit as a whole", func() {
+		Expect(taskDAO.Delete(context.Background(), doing.ID)).To(Succeed())
+
+		_, err := taskDAO.CreateTree(context.Background(), TaskTree{
+			Task:     Task{Name: gofakeit.Noun(), Column: "doing"},
+			Subtasks: []TaskTree{{Task: Task{Name: gofakeit.Noun(), Column: "doing"}}},
+		})
+		Expect(err).To(MatchError(ErrWIPLimitReached))
+	})
+
+	It("should limit restoring the task into its column", func() {
+		Expect(taskDAO.Delete(context.Background(), doing.ID)).To(Succeed())
+		_, err := taskDAO.Create(context.Background(), Task{Name: gofakeit.Noun(), Column: "doing"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = taskDAO.Restore(context.Background(), doing.ID)
+		Expect(err).To(MatchError(ErrWIPLimitReached))
+	})
+
+	It("should only count the boards of the tenant", func() {
+		acme := ScopeTenant(taskDAO, "acme")
+		for i := 0; i < 2; i++ {
+			_, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun(), Column: "doing"})
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+})
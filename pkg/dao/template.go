@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"time"
+)
+
+// Template is the blueprint of a task tree, the texts may contain placeholders
+// such as {{date}} which are filled when it is instantiated.
+type Template struct {
+	ID int `json:"id"`
+	TemplateTask
+	// CreatedAt and UpdatedAt are managed by the TemplateDAO.
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TemplateTask is a task of a template with its subtasks.
+type TemplateTask struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	// Checklist are the texts of the checklist items.
+	Checklist []string       `json:"checklist,omitempty"`
+	Subtasks  []TemplateTask `json:"subtasks,omitempty"`
+}
+
+// Clone returns a deep copy of the template so that the tasks are not shared.
+func (t Template) Clone() Template {
+	t.TemplateTask = t.TemplateTask.Clone()
+	return t
+}
+
+func (t TemplateTask) Clone() TemplateTask {
+	if t.Tags != nil {
+		t.Tags = append([]string(nil), t.Tags...)
+	}
+	if t.Checklist != nil {
+		t.Checklist = append([]string(nil), t.Checklist...)
+	}
+	if t.Subtasks != nil {
+		subtasks := make([]TemplateTask, len(t.Subtasks))
+		for i := range t.Subtasks {
+			subtasks[i] = t.Subtasks[i].Clone()
+		}
+		t.Subtasks = subtasks
+	}
+	return t
+}
+
+type TemplateDAO interface {
+	TenantScoper[TemplateDAO]
+	LoggerScoper[TemplateDAO]
+
+	List() ([]Template, error)
+	GetByID(id int) (Template, error)
+	// Create stores a new template, the ID and the managed timestamps are
+	// assigned by the TemplateDAO.
+	Create(template Template) (Template, error)
+	// Update stores the template and writes the managed timestamps back to
+	// it.
+	Update(template *Template) error
+	Delete(id int) error
+}
@@ -0,0 +1,134 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(Template{})
+}
+
+const (
+	cacheKeyNextTemplateID = "cacheKeyNextTemplateID"
+	cacheKeyPrefixTemplate = "template:"
+)
+
+type goCacheTemplateDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+}
+
+func NewGoCacheTemplateDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheTemplateDAO {
+	return &goCacheTemplateDAO{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+func (dao *goCacheTemplateDAO) WithTenant(tenant string) TemplateDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheTemplateDAO) WithLogger(logger *zap.SugaredLogger) TemplateDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheTemplateDAO) List() ([]Template, error) {
+	templates := make([]Template, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixTemplate) {
+			continue
+		}
+
+		template, ok := item.Object.(Template)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(Template)")
+			continue
+		}
+
+		templates = append(templates, template.Clone())
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].ID < templates[j].ID
+	})
+
+	return templates, nil
+}
+
+func (dao *goCacheTemplateDAO) GetByID(id int) (Template, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return Template{}, ErrResourceNotFound
+	}
+
+	template, ok := item.(Template)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(Template)")
+		return Template{}, errors.New("type assertion failed")
+	}
+
+	return template.Clone(), nil
+}
+
+func (dao *goCacheTemplateDAO) Create(template Template) (Template, error) {
+	// the counter is missing until the first template is created
+	_ = dao.cache.Add(cacheKeyNextTemplateID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextTemplateID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return Template{}, err
+	}
+
+	template.ID = int(id)
+	template.CreatedAt = now()
+	template.UpdatedAt = template.CreatedAt
+	dao.cache.SetDefault(dao.cacheKey(template.ID), template.Clone())
+
+	return template, nil
+}
+
+func (dao *goCacheTemplateDAO) Update(template *Template) error {
+	if template == nil {
+		return errors.New("input template is nil")
+	}
+
+	current, err := dao.GetByID(template.ID)
+	if err != nil {
+		return err
+	}
+
+	template.CreatedAt = current.CreatedAt
+	template.UpdatedAt = now()
+	dao.cache.SetDefault(dao.cacheKey(template.ID), template.Clone())
+
+	return nil
+}
+
+func (dao *goCacheTemplateDAO) Delete(id int) error {
+	dao.cache.Delete(dao.cacheKey(id))
+
+	return nil
+}
+
+func (dao *goCacheTemplateDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + templateCacheKey(id)
+}
+
+func templateCacheKey(id int) string {
+	return cacheKeyPrefixTemplate + strconv.Itoa(id)
+}
+
+var _ TemplateDAO = (*goCacheTemplateDAO)(nil)
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheTemplateDAO", func() {
+	var (
+		dao      *goCacheTemplateDAO
+		template Template
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheTemplateDAO(zap.NewNop().Sugar(), NewGoCache())
+		template = Template{
+			TemplateTask: TemplateTask{
+				Name:      gofakeit.Noun(),
+				Checklist: []string{"sign in"},
+				Subtasks:  []TemplateTask{{Name: gofakeit.Noun(), Tags: []string{"onboarding"}}},
+			},
+		}
+	})
+
+	Describe("Create", func() {
+		It("should assign ID and the managed timestamps", func() {
+			created, err := dao.Create(template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ID).To(Equal(1))
+			Expect(created.CreatedAt).To(BeTemporally("~", time.Now(), time.Second))
+
+			stored, err := dao.GetByID(created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(created))
+		})
+
+		It("should not share the subtasks with the caller", func() {
+			created, err := dao.Create(template)
+			Expect(err).NotTo(HaveOccurred())
+			template.Subtasks[0].Tags[0] = "changed"
+
+			stored, err := dao.GetByID(created.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Subtasks[0].Tags[0]).To(Equal("onboarding"))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should store the template", func() {
+			created, err := dao.Create(template)
+			Expect(err).NotTo(HaveOccurred())
+
+			created.Checklist = append(created.Checklist, "read the handbook")
+			Expect(dao.Update(&created)).To(Succeed())
+
+			templates, err := dao.List()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(templates).To(Equal([]Template{created}))
+		})
+
+		It("should get ErrResourceNotFound if template not exists", func() {
+			err := dao.Update(&Template{ID: rand.Int()})
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package dao
+
+import (
+	"regexp"
+)
+
+// DefaultTenant owns the data of the deployments serving a single team, as
+// well as the data stored before the tenants were introduced.
+const DefaultTenant = ""
+
+const (
+	cacheKeyPrefixTenant = "tenant:"
+)
+
+// tenantPattern keeps the tenant IDs out of the way of the cache keys.
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ValidTenant reports whether the tenant ID can be used, the default tenant
+// is valid.
+func ValidTenant(tenant string) bool {
+	return tenant == DefaultTenant || tenantPattern.MatchString(tenant)
+}
+
+// TenantScoper is implemented by the DAOs keeping the data of each tenant
+// apart, the DAO interfaces of the data owned by the tenants embed it.
+type TenantScoper[T any] interface {
+	WithTenant(tenant string) T
+}
+
+// ScopeTenant returns the DAO keeping the data of the tenant apart from the
+// others.
+func ScopeTenant[T TenantScoper[T]](dao T, tenant string) T {
+	return dao.WithTenant(tenant)
+}
+
+// TenantLister is implemented by the TaskDAOs which know the tenants having
+// tasks.
+type TenantLister interface {
+	Tenants() ([]string, error)
+}
+
+// ListTenants returns the tenants having tasks, which is only the default
+// tenant if taskDAO does not know about the tenants.
+func ListTenants(taskDAO TaskDAO) ([]string, error) {
+	if lister, ok := taskDAO.(TenantLister); ok {
+		return lister.Tenants()
+	}
+	return []string{DefaultTenant}, nil
+}
+
+// tenantCacheKeyPrefix returns the prefix of the cache keys of the tenant, the
+// default tenant has none so the snapshots taken before keep working.
+func tenantCacheKeyPrefix(tenant string) string {
+	if tenant == DefaultTenant {
+		return ""
+	}
+	return cacheKeyPrefixTenant + tenant + ":"
+}
@@ -0,0 +1,123 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("Tenant", func() {
+	var (
+		taskDAO    TaskDAO
+		commentDAO CommentDAO
+		acme       TaskDAO
+		globex     TaskDAO
+	)
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := NewGoCache()
+		cache.SetDefault(cacheKeyNextTaskID, int64(0))
+		commentDAO = NewGoCacheCommentDAO(logger, cache)
+		taskDAO = NewRevisionTaskDAO(logger, NewCascadeTaskDAO(logger, NewGoCacheTaskDAO(logger, cache), Dependent[CommentDAO](commentDAO)), NewGoCacheRevisionDAO(logger, cache))
+		acme = ScopeTenant(taskDAO, "acme")
+		globex = ScopeTenant(taskDAO, "globex")
+	})
+
+	It("should give each tenant its own ID sequence", func() {
+		for _, scoped := range []TaskDAO{taskDAO, acme, globex, acme} {
+			_, err := scoped.Create(context.Background(), Task{Name: gofakeit.Noun()})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		acmeTasks, err := acme.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acmeTasks).To(HaveLen(2))
+		Expect(acmeTasks[0].ID).To(Equal(2))
+		Expect(acmeTasks[1].ID).To(Equal(1))
+	})
+
+	It("should never read the tasks of other tenants", func() {
+		task, err := acme.Create(context.Background(), Task{Name: "acme secret"})
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, other := range []TaskDAO{taskDAO, globex} {
+			tasks, err := other.List(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tasks).To(BeEmpty())
+
+			_, err = other.GetByID(context.Background(), task.ID)
+			Expect(err).To(Equal(ErrResourceNotFound))
+
+			update := task
+			update.Name = "stolen"
+			Expect(other.Update(context.Background(), &update)).To(Equal(ErrResourceNotFound))
+			Expect(other.Delete(context.Background(), task.ID)).To(Succeed())
+		}
+
+		stored, err := acme.GetByID(context.Background(), task.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Name).To(Equal("acme secret"))
+	})
+
+	It("should keep the trash of each tenant apart", func() {
+		task, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acme.Delete(context.Background(), task.ID)).To(Succeed())
+
+		trashed, err := globex.ListTrashed(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(trashed).To(BeEmpty())
+		_, err = globex.Restore(context.Background(), task.ID)
+		Expect(err).To(Equal(ErrResourceNotFound))
+		Expect(globex.Purge(context.Background(), task.ID)).To(Equal(ErrResourceNotFound))
+
+		ids, err := globex.PurgeTrashedBefore(context.Background(), time.Now().Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids).To(BeEmpty())
+	})
+
+	It("should keep the data of the tasks with the same ID apart", func() {
+		acmeTask, err := acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		globexTask, err := globex.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(globexTask.ID).To(Equal(acmeTask.ID))
+
+		acmeComments := ScopeTenant(commentDAO, "acme")
+		_, err = acmeComments.Create(Comment{TaskID: acmeTask.ID, Body: "acme secret"})
+		Expect(err).NotTo(HaveOccurred())
+
+		comments, err := ScopeTenant(commentDAO, "globex").ListByTaskID(globexTask.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(BeEmpty())
+
+		// purging the task of globex leaves the comments of acme
+		Expect(globex.Delete(context.Background(), globexTask.ID)).To(Succeed())
+		Expect(globex.Purge(context.Background(), globexTask.ID)).To(Succeed())
+		comments, err = acmeComments.ListByTaskID(acmeTask.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(comments).To(HaveLen(1))
+	})
+
+	It("should list the tenants having tasks", func() {
+		_, err := globex.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = acme.Create(context.Background(), Task{Name: gofakeit.Noun()})
+		Expect(err).NotTo(HaveOccurred())
+
+		tenants, err := ListTenants(taskDAO)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tenants).To(Equal([]string{DefaultTenant, "acme", "globex"}))
+	})
+
+	It("should validate the tenant IDs", func() {
+		Expect(ValidTenant(DefaultTenant)).To(BeTrue())
+		Expect(ValidTenant("acme-1")).To(BeTrue())
+		Expect(ValidTenant("acme:1")).To(BeFalse())
+	})
+})
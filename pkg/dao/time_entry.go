@@ -0,0 +1,52 @@
+package dao
+
+import (
+	"time"
+)
+
+// TimeEntry is a period of time tracked on a task, the entry of a running
+// timer has no StoppedAt.
+type TimeEntry struct {
+	ID        int        `json:"id"`
+	TaskID    int        `json:"task_id"`
+	User      string     `json:"user"`
+	Tags      []string   `json:"tags,omitempty"`
+	StartedAt time.Time  `json:"started_at"`
+	StoppedAt *time.Time `json:"stopped_at,omitempty"`
+}
+
+func (entry TimeEntry) Running() bool {
+	return entry.StoppedAt == nil
+}
+
+// Clone returns a deep copy of the entry so that the tags are not shared.
+func (entry TimeEntry) Clone() TimeEntry {
+	if entry.Tags != nil {
+		entry.Tags = append([]string(nil), entry.Tags...)
+	}
+	return entry
+}
+
+type TimeEntryDAO interface {
+	TenantScoper[TimeEntryDAO]
+	LoggerScoper[TimeEntryDAO]
+
+	// List returns the entries overlapping the range in ascending order, a
+	// running entry is taken as lasting until now.
+	List(from, to time.Time) ([]TimeEntry, error)
+	// ListByTaskID returns the entries of the task in ascending order.
+	ListByTaskID(taskID int) ([]TimeEntry, error)
+	GetByID(id int) (TimeEntry, error)
+	// Create stores a new entry, the ID is assigned by the TimeEntryDAO.
+	Create(entry TimeEntry) (TimeEntry, error)
+	Update(entry *TimeEntry) error
+	Delete(id int) error
+	DeleteByTaskID(taskID int) error
+
+	// StartTimer starts a timer of the user on the task. The running timer of
+	// the user is stopped and returned, so only one timer runs per user.
+	StartTimer(taskID int, user string, tags []string) (started TimeEntry, stopped *TimeEntry, err error)
+	// StopTimer stops the running timer of the user on the task, it fails with
+	// ErrResourceNotFound if there is none.
+	StopTimer(taskID int, user string) (TimeEntry, error)
+}
@@ -0,0 +1,229 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(TimeEntry{})
+}
+
+const (
+	cacheKeyNextTimeEntryID = "cacheKeyNextTimeEntryID"
+	cacheKeyPrefixTimeEntry = "time_entry:"
+)
+
+type goCacheTimeEntryDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	tenant string
+	// mu serializes the timers so that a user never has two running, it is
+	// shared by the DAOs of all tenants
+	mu *sync.Mutex
+}
+
+func NewGoCacheTimeEntryDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheTimeEntryDAO {
+	return &goCacheTimeEntryDAO{
+		logger: logger,
+		cache:  cache,
+		mu:     &sync.Mutex{},
+	}
+}
+
+// WithTenant returns the TimeEntryDAO of the tenant, a user may have a timer
+// running in each tenant.
+func (dao *goCacheTimeEntryDAO) WithTenant(tenant string) TimeEntryDAO {
+	scoped := *dao
+	scoped.tenant = tenant
+	return &scoped
+}
+
+func (dao *goCacheTimeEntryDAO) WithLogger(logger *zap.SugaredLogger) TimeEntryDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheTimeEntryDAO) List(from, to time.Time) ([]TimeEntry, error) {
+	current := now()
+	entries := dao.list(func(entry TimeEntry) bool {
+		stoppedAt := current
+		if entry.StoppedAt != nil {
+			stoppedAt = *entry.StoppedAt
+		}
+		return entry.StartedAt.Before(to) && stoppedAt.After(from)
+	})
+	return entries, nil
+}
+
+func (dao *goCacheTimeEntryDAO) ListByTaskID(taskID int) ([]TimeEntry, error) {
+	entries := dao.list(func(entry TimeEntry) bool {
+		return entry.TaskID == taskID
+	})
+	return entries, nil
+}
+
+func (dao *goCacheTimeEntryDAO) GetByID(id int) (TimeEntry, error) {
+	item, found := dao.cache.Get(dao.cacheKey(id))
+	if !found {
+		return TimeEntry{}, ErrResourceNotFound
+	}
+
+	entry, ok := item.(TimeEntry)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(TimeEntry)")
+		return TimeEntry{}, errors.New("type assertion failed")
+	}
+
+	return entry.Clone(), nil
+}
+
+func (dao *goCacheTimeEntryDAO) Create(entry TimeEntry) (TimeEntry, error) {
+	// the counter is missing until the first entry is created
+	_ = dao.cache.Add(cacheKeyNextTimeEntryID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextTimeEntryID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return TimeEntry{}, err
+	}
+
+	entry.ID = int(id)
+	dao.cache.SetDefault(dao.cacheKey(entry.ID), entry.Clone())
+
+	return entry, nil
+}
+
+func (dao *goCacheTimeEntryDAO) Update(entry *TimeEntry) error {
+	if entry == nil {
+		return errors.New("input entry is nil")
+	}
+
+	if _, err := dao.GetByID(entry.ID); err != nil {
+		return err
+	}
+
+	dao.cache.SetDefault(dao.cacheKey(entry.ID), entry.Clone())
+
+	return nil
+}
+
+func (dao *goCacheTimeEntryDAO) Delete(id int) error {
+	dao.cache.Delete(dao.cacheKey(id))
+
+	return nil
+}
+
+func (dao *goCacheTimeEntryDAO) DeleteByTaskID(taskID int) error {
+	entries := dao.list(func(entry TimeEntry) bool {
+		return entry.TaskID == taskID
+	})
+
+	for i := range entries {
+		dao.cache.Delete(dao.cacheKey(entries[i].ID))
+	}
+
+	return nil
+}
+
+func (dao *goCacheTimeEntryDAO) StartTimer(taskID int, user string, tags []string) (TimeEntry, *TimeEntry, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	current := now()
+
+	var stopped *TimeEntry
+	if running, found := dao.running(user); found {
+		running.StoppedAt = &current
+		if err := dao.Update(&running); err != nil {
+			return TimeEntry{}, nil, err
+		}
+		stopped = &running
+	}
+
+	started, err := dao.Create(TimeEntry{
+		TaskID:    taskID,
+		User:      user,
+		Tags:      tags,
+		StartedAt: current,
+	})
+	if err != nil {
+		return TimeEntry{}, nil, err
+	}
+
+	return started, stopped, nil
+}
+
+func (dao *goCacheTimeEntryDAO) StopTimer(taskID int, user string) (TimeEntry, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	running, found := dao.running(user)
+	if !found || running.TaskID != taskID {
+		return TimeEntry{}, ErrResourceNotFound
+	}
+
+	stoppedAt := now()
+	running.StoppedAt = &stoppedAt
+	if err := dao.Update(&running); err != nil {
+		return TimeEntry{}, err
+	}
+
+	return running, nil
+}
+
+// running returns the running timer of the user.
+func (dao *goCacheTimeEntryDAO) running(user string) (TimeEntry, bool) {
+	entries := dao.list(func(entry TimeEntry) bool {
+		return entry.User == user && entry.Running()
+	})
+	if len(entries) == 0 {
+		return TimeEntry{}, false
+	}
+
+	return entries[len(entries)-1], true
+}
+
+// list returns the entries matched by the filter, sorted by ID ascending.
+func (dao *goCacheTimeEntryDAO) list(filter func(entry TimeEntry) bool) []TimeEntry {
+	entries := make([]TimeEntry, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, tenantCacheKeyPrefix(dao.tenant)+cacheKeyPrefixTimeEntry) {
+			continue
+		}
+
+		entry, ok := item.Object.(TimeEntry)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(TimeEntry)")
+			continue
+		}
+
+		if filter(entry) {
+			entries = append(entries, entry.Clone())
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries
+}
+
+func (dao *goCacheTimeEntryDAO) cacheKey(id int) string {
+	return tenantCacheKeyPrefix(dao.tenant) + timeEntryCacheKey(id)
+}
+
+func timeEntryCacheKey(id int) string {
+	return cacheKeyPrefixTimeEntry + strconv.Itoa(id)
+}
+
+var _ TimeEntryDAO = (*goCacheTimeEntryDAO)(nil)
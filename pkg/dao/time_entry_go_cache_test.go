@@ -0,0 +1,113 @@
+package dao
+
+import (
+	"math/rand"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheTimeEntryDAO", func() {
+	var (
+		dao    *goCacheTimeEntryDAO
+		taskID int
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheTimeEntryDAO(zap.NewNop().Sugar(), NewGoCache())
+		taskID = rand.Int()
+	})
+
+	Describe("StartTimer", func() {
+		It("should start a running timer", func() {
+			started, stopped, err := dao.StartTimer(taskID, "alice", []string{"billable"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stopped).To(BeNil())
+			Expect(started.Running()).To(BeTrue())
+			Expect(started.StartedAt).To(BeTemporally("~", time.Now(), time.Second))
+
+			stored, err := dao.GetByID(started.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(started))
+		})
+
+		It("should stop the running timer of the user", func() {
+			first, _, err := dao.StartTimer(taskID, "alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+			other, _, err := dao.StartTimer(taskID, "bob", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			second, stopped, err := dao.StartTimer(taskID+1, "alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stopped).NotTo(BeNil())
+			Expect(stopped.ID).To(Equal(first.ID))
+			Expect(*stopped.StoppedAt).To(Equal(second.StartedAt))
+
+			stored, err := dao.GetByID(other.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Running()).To(BeTrue())
+		})
+	})
+
+	Describe("StopTimer", func() {
+		It("should stop the running timer on the task", func() {
+			started, _, err := dao.StartTimer(taskID, "alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			stopped, err := dao.StopTimer(taskID, "alice")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stopped.ID).To(Equal(started.ID))
+			Expect(stopped.Running()).To(BeFalse())
+		})
+
+		It("should get ErrResourceNotFound if the timer runs on another task", func() {
+			_, _, err := dao.StartTimer(taskID, "alice", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dao.StopTimer(taskID+1, "alice")
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+
+	Describe("List", func() {
+		It("should return the entries overlapping the range", func() {
+			day := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+			newEntry := func(start, stop time.Duration) TimeEntry {
+				stoppedAt := day.Add(stop)
+				entry, err := dao.Create(TimeEntry{TaskID: taskID, StartedAt: day.Add(start), StoppedAt: &stoppedAt})
+				Expect(err).NotTo(HaveOccurred())
+				return entry
+			}
+
+			newEntry(-2*time.Hour, -time.Hour)
+			overlapped := newEntry(-time.Hour, time.Hour)
+			within := newEntry(2*time.Hour, 3*time.Hour)
+			newEntry(25*time.Hour, 26*time.Hour)
+
+			entries, err := dao.List(day, day.Add(24*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(Equal([]TimeEntry{overlapped, within}))
+		})
+	})
+
+	Describe("Create", func() {
+		It("should not share the tags with the caller", func() {
+			entry, err := dao.Create(TimeEntry{TaskID: taskID, Tags: []string{"a"}})
+			Expect(err).NotTo(HaveOccurred())
+			entry.Tags[0] = "b"
+
+			stored, err := dao.GetByID(entry.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Tags).To(Equal([]string{"a"}))
+		})
+	})
+
+	Describe("Update", func() {
+		It("should get ErrResourceNotFound if entry not exists", func() {
+			err := dao.Update(&TimeEntry{ID: rand.Int()})
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+})
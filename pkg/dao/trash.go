@@ -0,0 +1,41 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PurgeTrashPeriodically permanently removes the tasks which have stayed in
+// the trash longer than retention, of every tenant. It checks every interval
+// until ctx is done.
+func PurgeTrashPeriodically(ctx context.Context, logger *zap.SugaredLogger, taskDAO TaskDAO, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			tenants, err := ListTenants(taskDAO)
+			if err != nil {
+				logger.Errorf("ListTenants failed, err=%v", err)
+				continue
+			}
+
+			for _, tenant := range tenants {
+				ids, err := ScopeTenant(taskDAO, tenant).PurgeTrashedBefore(ctx, now.Add(-retention))
+				if err != nil {
+					logger.Errorf("taskDAO.PurgeTrashedBefore failed, err=%v, tenant=%v", err, tenant)
+					continue
+				}
+
+				if len(ids) > 0 {
+					logger.Infof("purged trashed tasks, count=%v, tenant=%v", len(ids), tenant)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("PurgeTrashPeriodically", func() {
+	var (
+		dao    *goCacheTaskDAO
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		dao = &goCacheTaskDAO{
+			logger: zap.NewNop().Sugar(),
+			cache:  gocache.New(gocache.NoExpiration, 10*time.Minute),
+			mu:     &sync.Mutex{},
+		}
+		dao.cache.SetDefault(cacheKeyNextTaskID, int64(0))
+
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		go PurgeTrashPeriodically(ctx, dao.logger, dao, time.Hour, 10*time.Millisecond)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should purge tasks trashed longer than retention", func() {
+		deletedAt := time.Now().Add(-2 * time.Hour)
+		task := Task{ID: 1, Name: gofakeit.Noun(), DeletedAt: &deletedAt}
+		dao.cache.SetDefault(strconv.Itoa(task.ID), task)
+
+		Eventually(func() bool {
+			_, found := dao.cache.Get(strconv.Itoa(task.ID))
+			return found
+		}).Should(BeFalse())
+	})
+
+	It("should purge tasks of every tenant", func() {
+		deletedAt := time.Now().Add(-2 * time.Hour)
+		task := Task{ID: 1, Name: gofakeit.Noun(), DeletedAt: &deletedAt}
+		key := tenantCacheKeyPrefix("acme") + strconv.Itoa(task.ID)
+		dao.cache.SetDefault(key, task)
+
+		Eventually(func() bool {
+			_, found := dao.cache.Get(key)
+			return found
+		}).Should(BeFalse())
+	})
+
+	It("should keep tasks trashed within retention", func() {
+		deletedAt := time.Now()
+		task := Task{ID: 1, Name: gofakeit.Noun(), DeletedAt: &deletedAt}
+		dao.cache.SetDefault(strconv.Itoa(task.ID), task)
+
+		Consistently(func() bool {
+			_, found := dao.cache.Get(strconv.Itoa(task.ID))
+			return found
+		}, 100*time.Millisecond).Should(BeTrue())
+	})
+})
@@ -0,0 +1,34 @@
+package dao
+
+// Operation is a mutation of a task, which is the transition from the Before
+// state to the After state. A state with DeletedAt is a task in the trash.
+type Operation struct {
+	TaskID int  `json:"task_id"`
+	Before Task `json:"before"`
+	After  Task `json:"after"`
+}
+
+// UndoLog keeps the recent operations of a client, the last operations are
+// the latest ones.
+type UndoLog struct {
+	Undo []Operation `json:"undo"`
+	Redo []Operation `json:"redo"`
+}
+
+// Push records op as the latest undoable operation, the redo stack is cleared
+// since the operations in it are no longer based on the latest state.
+func (l *UndoLog) Push(op Operation) {
+	l.Undo = append(l.Undo, op)
+	l.Redo = nil
+}
+
+type UndoLogDAO interface {
+	LoggerScoper[UndoLogDAO]
+
+	// Get returns the undo log of the client, an empty log is returned if the
+	// client has no operations.
+	Get(clientID string) (UndoLog, error)
+	// Save stores the undo log of the client, the oldest operations exceeding
+	// the limit of the DAO are dropped.
+	Save(clientID string, log UndoLog) error
+}
@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(UndoLog{})
+}
+
+type goCacheUndoLogDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	limit  int
+}
+
+func NewGoCacheUndoLogDAO(logger *zap.SugaredLogger, cache *gocache.Cache, limit int) *goCacheUndoLogDAO {
+	return &goCacheUndoLogDAO{
+		logger: logger,
+		cache:  cache,
+		limit:  limit,
+	}
+}
+
+func (dao *goCacheUndoLogDAO) WithLogger(logger *zap.SugaredLogger) UndoLogDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheUndoLogDAO) Get(clientID string) (UndoLog, error) {
+	item, found := dao.cache.Get(undoLogCacheKey(clientID))
+	if !found {
+		return UndoLog{}, nil
+	}
+
+	log, ok := item.(UndoLog)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(UndoLog)")
+		return UndoLog{}, errors.New("type assertion failed")
+	}
+
+	return log, nil
+}
+
+func (dao *goCacheUndoLogDAO) Save(clientID string, log UndoLog) error {
+	log.Undo = lastOperations(log.Undo, dao.limit)
+	log.Redo = lastOperations(log.Redo, dao.limit)
+	dao.cache.SetDefault(undoLogCacheKey(clientID), log)
+
+	return nil
+}
+
+func lastOperations(ops []Operation, limit int) []Operation {
+	if len(ops) <= limit {
+		return ops
+	}
+	return append([]Operation(nil), ops[len(ops)-limit:]...)
+}
+
+func undoLogCacheKey(clientID string) string {
+	return "undo:" + clientID
+}
+
+var _ UndoLogDAO = (*goCacheUndoLogDAO)(nil)
@@ -0,0 +1,51 @@
+package dao
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheUndoLogDAO", func() {
+	var (
+		dao *goCacheUndoLogDAO
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheUndoLogDAO(zap.NewNop().Sugar(), NewGoCache(), 2)
+	})
+
+	It("should return an empty log for unknown client", func() {
+		log, err := dao.Get("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(log).To(Equal(UndoLog{}))
+	})
+
+	It("should keep logs per client", func() {
+		var log UndoLog
+		log.Push(Operation{TaskID: 1})
+		Expect(dao.Save("alice", log)).To(Succeed())
+
+		log, err := dao.Get("bob")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(log.Undo).To(BeEmpty())
+	})
+
+	It("should drop the oldest operations exceeding the limit", func() {
+		var log UndoLog
+		for i := 1; i <= 3; i++ {
+			log.Push(Operation{TaskID: i})
+		}
+		Expect(dao.Save("alice", log)).To(Succeed())
+
+		log, err := dao.Get("alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(log.Undo).To(Equal([]Operation{{TaskID: 2}, {TaskID: 3}}))
+	})
+
+	It("should clear redo stack on push", func() {
+		log := UndoLog{Redo: []Operation{{TaskID: 1}}}
+		log.Push(Operation{TaskID: 2})
+		Expect(log.Redo).To(BeEmpty())
+	})
+})
@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUsernameTaken = errors.New("username is taken")
+)
+
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	// PasswordHash is the bcrypt hash of the password, see SetPassword.
+	PasswordHash []byte `json:"-"`
+	// Role applies to everything unless the user is a member of the project
+	// with another role.
+	Role Role `json:"role"`
+	// Tenant is the tenant the user works in, the usernames are unique
+	// across the tenants though.
+	Tenant string `json:"tenant"`
+	// CreatedAt is managed by the UserDAO.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SetPassword stores the hash of the password.
+func (u *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = hash
+	return nil
+}
+
+// CheckPassword reports whether the password matches the stored hash.
+func (u User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword(u.PasswordHash, []byte(password)) == nil
+}
+
+type UserDAO interface {
+	LoggerScoper[UserDAO]
+
+	List() ([]User, error)
+	GetByID(id int) (User, error)
+	GetByUsername(username string) (User, error)
+	// Create stores a new user, the ID and the managed timestamps are
+	// assigned by the UserDAO. It fails with ErrUsernameTaken if the username
+	// is in use.
+	Create(user User) (User, error)
+	Update(user *User) error
+}
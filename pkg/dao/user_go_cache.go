@@ -0,0 +1,153 @@
+package dao
+
+import (
+	"encoding/gob"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	gocache "github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gob.Register(User{})
+}
+
+const (
+	cacheKeyNextUserID = "cacheKeyNextUserID"
+	cacheKeyPrefixUser = "user:"
+)
+
+type goCacheUserDAO struct {
+	logger *zap.SugaredLogger
+	cache  *gocache.Cache
+	// mu keeps the usernames unique, it is shared by the scoped DAOs
+	mu *sync.Mutex
+}
+
+func NewGoCacheUserDAO(logger *zap.SugaredLogger, cache *gocache.Cache) *goCacheUserDAO {
+	return &goCacheUserDAO{
+		logger: logger,
+		cache:  cache,
+		mu:     &sync.Mutex{},
+	}
+}
+
+func (dao *goCacheUserDAO) WithLogger(logger *zap.SugaredLogger) UserDAO {
+	scoped := *dao
+	scoped.logger = logger
+	return &scoped
+}
+
+func (dao *goCacheUserDAO) List() ([]User, error) {
+	return dao.list(func(user User) bool {
+		return true
+	}), nil
+}
+
+func (dao *goCacheUserDAO) GetByID(id int) (User, error) {
+	item, found := dao.cache.Get(userCacheKey(id))
+	if !found {
+		return User{}, ErrResourceNotFound
+	}
+
+	user, ok := item.(User)
+	if !ok {
+		dao.logger.Errorf("gocache.Get type assertion failed: item.(User)")
+		return User{}, errors.New("type assertion failed")
+	}
+
+	return user, nil
+}
+
+func (dao *goCacheUserDAO) GetByUsername(username string) (User, error) {
+	users := dao.list(func(user User) bool {
+		return user.Username == username
+	})
+	if len(users) == 0 {
+		return User{}, ErrResourceNotFound
+	}
+
+	return users[0], nil
+}
+
+func (dao *goCacheUserDAO) Create(user User) (User, error) {
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	if _, err := dao.GetByUsername(user.Username); err == nil {
+		return User{}, ErrUsernameTaken
+	}
+
+	// the counter is missing until the first user is created
+	_ = dao.cache.Add(cacheKeyNextUserID, int64(0), gocache.NoExpiration)
+	id, err := dao.cache.IncrementInt64(cacheKeyNextUserID, 1)
+	if err != nil {
+		dao.logger.Errorf("gocache.IncrementInt64 failed, err=%v", err)
+		return User{}, err
+	}
+
+	user.ID = int(id)
+	user.CreatedAt = now()
+	dao.cache.SetDefault(userCacheKey(user.ID), user)
+
+	return user, nil
+}
+
+func (dao *goCacheUserDAO) Update(user *User) error {
+	if user == nil {
+		return errors.New("input user is nil")
+	}
+
+	dao.mu.Lock()
+	defer dao.mu.Unlock()
+
+	current, err := dao.GetByID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if other, err := dao.GetByUsername(user.Username); err == nil && other.ID != user.ID {
+		return ErrUsernameTaken
+	}
+
+	user.CreatedAt = current.CreatedAt
+	dao.cache.SetDefault(userCacheKey(user.ID), *user)
+
+	return nil
+}
+
+// list returns the users matched by the filter, sorted by ID ascending.
+func (dao *goCacheUserDAO) list(filter func(user User) bool) []User {
+	users := make([]User, 0)
+	for key, item := range dao.cache.Items() {
+		if !strings.HasPrefix(key, cacheKeyPrefixUser) {
+			continue
+		}
+
+		user, ok := item.Object.(User)
+		if !ok {
+			dao.logger.Errorf("gocache.Items type assertion failed: item.Object.(User)")
+			continue
+		}
+
+		if filter(user) {
+			users = append(users, user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	return users
+}
+
+func userCacheKey(id int) string {
+	return cacheKeyPrefixUser + strconv.Itoa(id)
+}
+
+var _ UserDAO = (*goCacheUserDAO)(nil)
@@ -0,0 +1,50 @@
+package dao
+
+import (
+	"github.com/brianvoe/gofakeit/v5"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+var _ = Describe("GoCacheUserDAO", func() {
+	var (
+		dao  *goCacheUserDAO
+		user User
+	)
+
+	BeforeEach(func() {
+		dao = NewGoCacheUserDAO(zap.NewNop().Sugar(), NewGoCache())
+		user = User{Username: gofakeit.Username()}
+		Expect(user.SetPassword("secret")).To(Succeed())
+	})
+
+	Describe("Create", func() {
+		It("should store the user with the password hash", func() {
+			created, err := dao.Create(user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ID).To(Equal(1))
+
+			stored, err := dao.GetByUsername(user.Username)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored).To(Equal(created))
+			Expect(stored.CheckPassword("secret")).To(BeTrue())
+			Expect(stored.CheckPassword("wrong")).To(BeFalse())
+		})
+
+		It("should get ErrUsernameTaken if the username is in use", func() {
+			_, err := dao.Create(user)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dao.Create(user)
+			Expect(err).To(Equal(ErrUsernameTaken))
+		})
+	})
+
+	Describe("GetByUsername", func() {
+		It("should get ErrResourceNotFound if user not exists", func() {
+			_, err := dao.GetByUsername(user.Username)
+			Expect(err).To(Equal(ErrResourceNotFound))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// userMe refers to the requester in the requests and the queries.
+const userMe = "me"
+
+func (s *httpServerImpl) AssignTaskHandler(c *gin.Context) {
+	var req AssignTaskRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Assignee == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	assigneeID, ok := s.resolveUserOrAbort(c, req.Assignee)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	task.AssigneeID = assigneeID
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := AssignTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) UnassignTaskHandler(c *gin.Context) {
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	task.AssigneeID = 0
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := UnassignTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// WatchTaskHandler adds the requester to the watchers of the task, watching a
+// task twice has no effect.
+func (s *httpServerImpl) WatchTaskHandler(c *gin.Context) {
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	if task.Watch(ownerOf(c)) && !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := WatchTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// UnwatchTaskHandler removes the requester from the watchers of the task.
+func (s *httpServerImpl) UnwatchTaskHandler(c *gin.Context) {
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	if task.Unwatch(ownerOf(c)) && !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := UnwatchTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// resolveUserOrAbort returns the ID of the user given by the username, "me" is
+// the requester. The users of the other tenants are not found, the error
+// response is written if it fails.
+func (s *httpServerImpl) resolveUserOrAbort(c *gin.Context, username string) (int, bool) {
+	if username == userMe {
+		return ownerOf(c), true
+	}
+
+	user, err := s.users(c).GetByUsername(username)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && user.Tenant != tenantOf(c)) {
+		writeResponseError(c, http.StatusBadRequest, "user not found")
+		return 0, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("userDAO.GetByUsername failed, err=%v, username=%v", err, username)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return 0, false
+	}
+
+	return user.ID, true
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	formFieldAttachment       = "file"
+	defaultAttachmentType     = "application/octet-stream"
+	multipartOverheadMaxBytes = 64 << 10
+)
+
+var errAttachmentTooLarge = errors.New("attachment too large")
+
+func (s *httpServerImpl) ListAttachmentsHandler(c *gin.Context) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	attachments, err := s.attachments(c).ListByTaskID(task.ID)
+	if err != nil {
+		s.loggerOf(c).Errorf("attachmentDAO.ListByTaskID failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListAttachmentsResponse{
+		Result: toModelAttachments(attachments),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// CreateAttachmentHandler streams the file of the multipart upload into the
+// blob store, the file is not buffered in memory.
+func (s *httpServerImpl) CreateAttachmentHandler(c *gin.Context) {
+	task, ok := s.getWritableTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if s.config.AttachmentMaxBytes > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, s.config.AttachmentMaxBytes+multipartOverheadMaxBytes)
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	part, err := nextAttachmentPart(reader)
+	if isAttachmentTooLarge(err) {
+		writeResponseError(c, http.StatusRequestEntityTooLarge, errAttachmentTooLarge.Error())
+		return
+	} else if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+	defer part.Close()
+
+	var content io.Reader = part
+	if s.config.AttachmentMaxBytes > 0 {
+		content = &limitedReader{r: part, n: s.config.AttachmentMaxBytes}
+	}
+
+	digest, size, err := s.blobs(c).Put(content)
+	if isAttachmentTooLarge(err) {
+		writeResponseError(c, http.StatusRequestEntityTooLarge, errAttachmentTooLarge.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("blobStore.Put failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	attachment, err := s.attachments(c).Create(dao.Attachment{
+		TaskID:      task.ID,
+		Filename:    part.FileName(),
+		Size:        size,
+		ContentType: attachmentContentType(part),
+		SHA256:      digest,
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("attachmentDAO.Create failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateAttachmentResponse{
+		Result: toModelAttachment(attachment),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) DownloadAttachmentHandler(c *gin.Context) {
+	attachment, ok := s.getAttachmentOrAbort(c, s.getTaskOrAbort)
+	if !ok {
+		return
+	}
+
+	blob, err := s.blobs(c).Open(attachment.SHA256)
+	if err != nil {
+		s.loggerOf(c).Errorf("blobStore.Open failed, err=%v, attachmentID=%v", err, attachment.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	defer blob.Close()
+
+	// the json content type is set by the middleware already
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, blob, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+	})
+}
+
+func (s *httpServerImpl) DeleteAttachmentHandler(c *gin.Context) {
+	attachment, ok := s.getAttachmentOrAbort(c, s.getWritableTaskOrAbort)
+	if !ok {
+		return
+	}
+
+	if err := s.attachments(c).Delete(attachment.ID); err != nil {
+		s.loggerOf(c).Errorf("attachmentDAO.Delete failed, err=%v, attachmentID=%v", err, attachment.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// getAttachmentOrAbort returns the attachment given by the path parameters of
+// the task got by getTask, the error response is written if it fails.
+func (s *httpServerImpl) getAttachmentOrAbort(c *gin.Context, getTask func(c *gin.Context) (dao.Task, bool)) (dao.Attachment, bool) {
+	attachmentID, err := strconv.Atoi(c.Param("attachmentID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.Attachment{}, false
+	}
+
+	task, ok := getTask(c)
+	if !ok {
+		return dao.Attachment{}, false
+	}
+
+	attachment, err := s.attachments(c).GetByID(attachmentID)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && attachment.TaskID != task.ID) {
+		writeResponseError(c, http.StatusNotFound, "attachment not found")
+		return dao.Attachment{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("attachmentDAO.GetByID failed, err=%v, attachmentID=%v", err, attachmentID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Attachment{}, false
+	}
+
+	return attachment, true
+}
+
+// nextAttachmentPart skips the parts until the file field of the upload.
+func nextAttachmentPart(reader *multipart.Reader) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == formFieldAttachment && part.FileName() != "" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
+
+func attachmentContentType(part *multipart.Part) string {
+	mediaType, params, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+	if err != nil {
+		return defaultAttachmentType
+	}
+	return mime.FormatMediaType(mediaType, params)
+}
+
+func isAttachmentTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.Is(err, errAttachmentTooLarge) || errors.As(err, &maxBytesErr)
+}
+
+// limitedReader reads at most n bytes from r, it fails with
+// errAttachmentTooLarge rather than io.EOF if r has more.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		return int(l.n), errAttachmentTooLarge
+	}
+	l.n -= int64(n)
+
+	return n, err
+}
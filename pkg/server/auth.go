@@ -0,0 +1,421 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	headerAuthorization = "Authorization"
+	bearerPrefix        = "Bearer "
+	// contextKeyIdentity keeps the identity of the requester in the gin
+	// context, see identityOf.
+	contextKeyIdentity = "identity"
+)
+
+var errUnauthenticated = errors.New("unauthenticated")
+
+// identity is the authenticated requester.
+type identity struct {
+	UserID   int
+	Username string
+	Role     dao.Role
+	Tenant   string
+}
+
+// identityOf returns the identity placed by the AuthMiddleware.
+func identityOf(c *gin.Context) (identity, bool) {
+	value, ok := c.Get(contextKeyIdentity)
+	if !ok {
+		return identity{}, false
+	}
+	id, ok := value.(identity)
+	return id, ok
+}
+
+// AuthMiddleware authenticates the requests by a JWT or an API key given as
+// the bearer token, the others get 401.
+func (s *httpServerImpl) AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := s.authenticate(c)
+		if errors.Is(err, errUnauthenticated) {
+			writeResponseError(c, http.StatusUnauthorized, "unauthorized")
+			c.Abort()
+			return
+		} else if err != nil {
+			s.loggerOf(c).Errorf("authenticate failed, err=%v", err)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			c.Abort()
+			return
+		}
+
+		c.Set(contextKeyIdentity, id)
+		c.Next()
+	}
+}
+
+// OperatorMiddleware admits the admins of the default tenant, who operate the
+// deployment, it follows the AuthMiddleware.
+func (s *httpServerImpl) OperatorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := identityOf(c)
+		if id.Role != dao.RoleAdmin || id.Tenant != dao.DefaultTenant {
+			writeProblem(c, http.StatusForbidden, fmt.Sprintf("the %v role of the default tenant is required", dao.RoleAdmin))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authenticate returns the identity of the bearer token, it fails with
+// errUnauthenticated if the token is missing or invalid. The identity is
+// loaded per request, so a changed role applies to the tokens issued before.
+func (s *httpServerImpl) authenticate(c *gin.Context) (identity, error) {
+	header := c.GetHeader(headerAuthorization)
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return identity{}, errUnauthenticated
+	}
+	token := strings.TrimPrefix(header, bearerPrefix)
+
+	if !strings.HasPrefix(token, dao.APIKeyPrefix) {
+		claims, err := parseToken(s.config.JWTSecret, token, time.Now())
+		if err != nil {
+			return identity{}, errUnauthenticated
+		}
+
+		userID, err := strconv.Atoi(claims.Subject)
+		if err != nil {
+			return identity{}, errUnauthenticated
+		}
+		return s.loadIdentity(c, userID)
+	}
+
+	key, err := s.apiKeys(c).GetByHash(dao.HashAPIKey(token))
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		return identity{}, errUnauthenticated
+	} else if err != nil {
+		return identity{}, err
+	}
+
+	if key.RevokedAt != nil {
+		return identity{}, errUnauthenticated
+	}
+
+	return s.loadIdentity(c, key.UserID)
+}
+
+// loadIdentity returns the identity of the user as stored, it fails with
+// errUnauthenticated if the user is gone.
+func (s *httpServerImpl) loadIdentity(c *gin.Context, userID int) (identity, error) {
+	user, err := s.users(c).GetByID(userID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		return identity{}, errUnauthenticated
+	} else if err != nil {
+		return identity{}, err
+	}
+
+	return identity{UserID: user.ID, Username: user.Username, Role: user.Role, Tenant: user.Tenant}, nil
+}
+
+func (s *httpServerImpl) LoginHandler(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	user, err := s.users(c).GetByUsername(req.Username)
+	if err != nil && !errors.Is(err, dao.ErrResourceNotFound) {
+		s.loggerOf(c).Errorf("userDAO.GetByUsername failed, err=%v, username=%v", err, req.Username)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if err != nil || !user.CheckPassword(req.Password) {
+		writeResponseError(c, http.StatusUnauthorized, "invalid username or password")
+		return
+	}
+
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(s.config.JWTTTL)
+	token, err := signToken(s.config.JWTSecret, tokenClaims{
+		Subject:   strconv.Itoa(user.ID),
+		Username:  user.Username,
+		Role:      string(user.Role),
+		Tenant:    user.Tenant,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("signToken failed, err=%v, userID=%v", err, user.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := LoginResponse{
+		Result: Token{
+			Token:     token,
+			ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC(),
+		},
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// CreateUserHandler signs up a user, it is open until the first user is
+// created, who becomes an admin of the default tenant, after which only the
+// admins can add others. The admins of a tenant add the users of the tenant.
+func (s *httpServerImpl) CreateUserHandler(c *gin.Context) {
+	var req CreateUserRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Username == "" || req.Password == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	role := dao.RoleEditor
+	if req.Role != "" {
+		role = dao.Role(req.Role)
+	}
+	if !role.Valid() {
+		writeResponseError(c, http.StatusBadRequest, "invalid role")
+		return
+	}
+	if !dao.ValidTenant(req.Tenant) {
+		writeResponseError(c, http.StatusBadRequest, "invalid tenant")
+		return
+	}
+
+	if id, err := s.authenticate(c); errors.Is(err, errUnauthenticated) {
+		// held until the user is created, otherwise the concurrent sign-ups
+		// all find no users and become admins
+		s.signUpMu.Lock()
+		defer s.signUpMu.Unlock()
+
+		users, err := s.users(c).List()
+		if err != nil {
+			s.loggerOf(c).Errorf("userDAO.List failed, err=%v", err)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			return
+		}
+		if len(users) > 0 {
+			writeResponseError(c, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		role = dao.RoleAdmin
+		req.Tenant = dao.DefaultTenant
+	} else if err != nil {
+		s.loggerOf(c).Errorf("authenticate failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	} else if id.Role != dao.RoleAdmin {
+		writeProblem(c, http.StatusForbidden, fmt.Sprintf("the %v role is required", dao.RoleAdmin))
+		return
+	} else {
+		if req.Tenant == "" {
+			req.Tenant = id.Tenant
+		}
+		if id.Tenant != dao.DefaultTenant && req.Tenant != id.Tenant {
+			writeProblem(c, http.StatusForbidden, "the tenant is not accessible")
+			return
+		}
+	}
+
+	user := dao.User{
+		Username: req.Username,
+		Role:     role,
+		Tenant:   req.Tenant,
+	}
+	if err := user.SetPassword(req.Password); err != nil {
+		// bcrypt rejects the passwords longer than 72 bytes
+		writeResponseError(c, http.StatusBadRequest, "invalid password")
+		return
+	}
+
+	user, err := s.users(c).Create(user)
+	if errors.Is(err, dao.ErrUsernameTaken) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("userDAO.Create failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateUserResponse{
+		Result: toModelUser(user),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+// ListUsersHandler returns the users of the tenant.
+func (s *httpServerImpl) ListUsersHandler(c *gin.Context) {
+	users, err := s.users(c).List()
+	if err != nil {
+		s.loggerOf(c).Errorf("userDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	tenantUsers := make([]dao.User, 0, len(users))
+	for i := range users {
+		if users[i].Tenant == tenantOf(c) {
+			tenantUsers = append(tenantUsers, users[i])
+		}
+	}
+	users = tenantUsers
+
+	rsp := ListUsersResponse{
+		Result: toModelUsers(users),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// UpdateUserRoleHandler changes the global role of a user, which applies to
+// the tokens issued before as well.
+func (s *httpServerImpl) UpdateUserRoleHandler(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	var req UpdateUserRoleRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+	if role := dao.Role(req.Role); !role.Valid() {
+		writeResponseError(c, http.StatusBadRequest, "invalid role")
+		return
+	}
+
+	user, err := s.users(c).GetByID(userID)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && user.Tenant != tenantOf(c)) {
+		writeResponseError(c, http.StatusNotFound, "user not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("userDAO.GetByID failed, err=%v, userID=%v", err, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	user.Role = dao.Role(req.Role)
+	if err := s.users(c).Update(&user); err != nil {
+		s.loggerOf(c).Errorf("userDAO.Update failed, err=%v, userID=%v", err, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := UpdateUserRoleResponse{
+		Result: toModelUser(user),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetMeHandler returns the requester.
+func (s *httpServerImpl) GetMeHandler(c *gin.Context) {
+	id, _ := identityOf(c)
+	user, err := s.users(c).GetByID(id.UserID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "user not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("userDAO.GetByID failed, err=%v, userID=%v", err, id.UserID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := GetMeResponse{
+		Result: toModelUser(user),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// ListAPIKeysHandler returns the API keys of the requester, including the
+// revoked ones.
+func (s *httpServerImpl) ListAPIKeysHandler(c *gin.Context) {
+	id, _ := identityOf(c)
+	keys, err := s.apiKeys(c).ListByUserID(id.UserID)
+	if err != nil {
+		s.loggerOf(c).Errorf("apiKeyDAO.ListByUserID failed, err=%v, userID=%v", err, id.UserID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListAPIKeysResponse{
+		Result: toModelAPIKeys(keys),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// CreateAPIKeyHandler issues an API key for the requester, the key is only
+// returned in the response.
+func (s *httpServerImpl) CreateAPIKeyHandler(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Name == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	secret, hash, err := dao.NewAPIKey()
+	if err != nil {
+		s.loggerOf(c).Errorf("dao.NewAPIKey failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	id, _ := identityOf(c)
+	key, err := s.apiKeys(c).Create(dao.APIKey{
+		UserID:  id.UserID,
+		Name:    req.Name,
+		KeyHash: hash,
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("apiKeyDAO.Create failed, err=%v, userID=%v", err, id.UserID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateAPIKeyResponse{
+		Result: toModelAPIKey(key),
+		Key:    secret,
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+// RevokeAPIKeyHandler revokes an API key of the requester.
+func (s *httpServerImpl) RevokeAPIKeyHandler(c *gin.Context) {
+	keyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	id, _ := identityOf(c)
+	key, err := s.apiKeys(c).GetByID(keyID)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && key.UserID != id.UserID) {
+		writeResponseError(c, http.StatusNotFound, "api key not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("apiKeyDAO.GetByID failed, err=%v, keyID=%v", err, keyID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if _, err := s.apiKeys(c).Revoke(keyID); err != nil {
+		s.loggerOf(c).Errorf("apiKeyDAO.Revoke failed, err=%v, keyID=%v", err, keyID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,313 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func (s *httpServerImpl) ListBoardsHandler(c *gin.Context) {
+	boards, err := s.boards(c).List()
+	if err != nil {
+		s.loggerOf(c).Errorf("boardDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListBoardsResponse{
+		Result: toModelBoards(boards),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// GetBoardHandler returns the board with the cards of each column.
+func (s *httpServerImpl) GetBoardHandler(c *gin.Context) {
+	board, ok := s.getBoardOrAbort(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := s.tasks(c).List(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	cards := boardCards(board, tasks)
+	result := toModelBoard(board)
+	for i := range result.Columns {
+		result.Columns[i].Cards = toModelTasks(cards[result.Columns[i].Key])
+	}
+
+	rsp := GetBoardResponse{
+		Result: result,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) CreateBoardHandler(c *gin.Context) {
+	var req CreateBoardRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	board := toDAOBoard(req.Name, req.ProjectID, req.GroupBy, req.Columns)
+	if err := validBoard(board); err != nil {
+		writeResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !s.checkProjectOrAbort(c, board.ProjectID) {
+		return
+	}
+
+	board, err := s.boards(c).Create(board)
+	if err != nil {
+		s.loggerOf(c).Errorf("boardDAO.Create failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateBoardResponse{
+		Result: toModelBoard(board),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) UpdateBoardHandler(c *gin.Context) {
+	var req UpdateBoardRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	board := toDAOBoard(req.Name, req.ProjectID, req.GroupBy, req.Columns)
+	if err := validBoard(board); err != nil {
+		writeResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	current, ok := s.getBoardOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if board.ProjectID != current.ProjectID && !s.checkProjectOrAbort(c, board.ProjectID) {
+		return
+	}
+
+	board.ID = current.ID
+	err := s.boards(c).Update(&board)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "board not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("boardDAO.Update failed, err=%v, boardID=%v", err, board.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := UpdateBoardResponse{
+		Result: toModelBoard(board),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) DeleteBoardHandler(c *gin.Context) {
+	boardID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	if err := s.boards(c).Delete(boardID); err != nil {
+		s.loggerOf(c).Errorf("boardDAO.Delete failed, err=%v, boardID=%v", err, boardID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MoveCardHandler moves the task into a column of the board, it is rejected by
+// the TaskDAO if the column has reached its WIP limit.
+func (s *httpServerImpl) MoveCardHandler(c *gin.Context) {
+	var req MoveCardRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Column == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	taskID, err := strconv.Atoi(c.Param("taskID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	board, ok := s.getBoardOrAbort(c)
+	if !ok {
+		return
+	}
+
+	column, found := boardColumn(board, req.Column)
+	if !found {
+		writeResponseError(c, http.StatusBadRequest, "column not found")
+		return
+	}
+
+	current, err := s.tasks(c).GetByID(c.Request.Context(), taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && !board.Shows(current)) {
+		writeResponseError(c, http.StatusNotFound, "card not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.GetByID failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	tasks, err := s.tasks(c).List(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	task := current.Clone()
+	if board.GroupBy == dao.BoardGroupByStatus {
+		task.Status = dao.BoardStatusKeys[column.Key]
+	} else {
+		task.Column = column.Key
+	}
+
+	if req.Before != nil || req.After != nil {
+		task.Rank, err = rankBetweenAnchors(tasks, task.ID, req.Before, req.After)
+		if errors.Is(err, errAnchorNotFound) {
+			writeResponseError(c, http.StatusBadRequest, err.Error())
+			return
+		} else if errors.Is(err, dao.ErrInvalidRank) {
+			writeResponseError(c, http.StatusConflict, "the anchor tasks are not in order")
+			return
+		} else if err != nil {
+			s.loggerOf(c).Errorf("rankBetweenAnchors failed, err=%v, taskID=%v", err, task.ID)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			return
+		}
+	}
+
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := MoveCardResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// getBoardOrAbort returns the board given by the path parameter, the error
+// response is written if it fails.
+func (s *httpServerImpl) getBoardOrAbort(c *gin.Context) (dao.Board, bool) {
+	boardID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.Board{}, false
+	}
+
+	board, err := s.boards(c).GetByID(boardID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "board not found")
+		return dao.Board{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("boardDAO.GetByID failed, err=%v, boardID=%v", err, boardID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Board{}, false
+	}
+
+	return board, true
+}
+
+func toDAOBoard(name string, projectID int, groupBy string, columns []BoardColumn) dao.Board {
+	board := dao.Board{
+		Name:      name,
+		ProjectID: projectID,
+		GroupBy:   dao.BoardGroupBy(groupBy),
+		Columns:   make([]dao.BoardColumn, 0, len(columns)),
+	}
+	for _, column := range columns {
+		board.Columns = append(board.Columns, dao.BoardColumn{
+			Key:      column.Key,
+			Name:     column.Name,
+			WIPLimit: column.WIPLimit,
+		})
+	}
+	return board
+}
+
+func validBoard(board dao.Board) error {
+	if board.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if board.GroupBy != dao.BoardGroupByStatus && board.GroupBy != dao.BoardGroupByColumn {
+		return fmt.Errorf("unsupported group_by: %v", board.GroupBy)
+	}
+
+	if len(board.Columns) == 0 {
+		return errors.New("columns are required")
+	}
+
+	keys := make(map[string]bool, len(board.Columns))
+	for _, column := range board.Columns {
+		if column.Key == "" || keys[column.Key] {
+			return fmt.Errorf("invalid column key: %q", column.Key)
+		}
+		keys[column.Key] = true
+
+		if _, ok := dao.BoardStatusKeys[column.Key]; !ok && board.GroupBy == dao.BoardGroupByStatus {
+			return fmt.Errorf("invalid column key: %q", column.Key)
+		}
+
+		if column.WIPLimit < 0 {
+			return fmt.Errorf("invalid wip_limit of column: %q", column.Key)
+		}
+	}
+
+	return nil
+}
+
+func boardColumn(board dao.Board, key string) (dao.BoardColumn, bool) {
+	for _, column := range board.Columns {
+		if column.Key == key {
+			return column, true
+		}
+	}
+	return dao.BoardColumn{}, false
+}
+
+// boardCards groups the tasks on the board by the column keys, the cards of a
+// column are in the manual order.
+func boardCards(board dao.Board, tasks []dao.Task) map[string][]dao.Task {
+	cards := make(map[string][]dao.Task, len(board.Columns))
+	for i := range tasks {
+		if board.Shows(tasks[i]) {
+			key := board.ColumnKeyOf(tasks[i])
+			cards[key] = append(cards[key], tasks[i])
+		}
+	}
+
+	for key := range cards {
+		sort.Slice(cards[key], func(i, j int) bool {
+			return manualTaskLess(cards[key][i], cards[key][j])
+		})
+	}
+
+	return cards
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func (s *httpServerImpl) ListChecklistHandler(c *gin.Context) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	rsp := ListChecklistResponse{
+		Result: toModelChecklist(task.Checklist),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) CreateChecklistItemHandler(c *gin.Context) {
+	var req CreateChecklistItemRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	item := task.AddChecklistItem(req.Text)
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := CreateChecklistItemResponse{
+		Result: ChecklistItem(item),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) UpdateChecklistItemHandler(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	var req UpdateChecklistItemRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	item, err := task.ChecklistItem(itemID)
+	if err != nil {
+		writeResponseError(c, http.StatusNotFound, "checklist item not found")
+		return
+	}
+	item.Text = req.Text
+	item.Done = req.Done
+	updated := *item
+
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := UpdateChecklistItemResponse{
+		Result: ChecklistItem(updated),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) DeleteChecklistItemHandler(c *gin.Context) {
+	itemID, err := strconv.Atoi(c.Param("itemID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	if err := task.DeleteChecklistItem(itemID); err != nil {
+		writeResponseError(c, http.StatusNotFound, "checklist item not found")
+		return
+	}
+
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (s *httpServerImpl) ReorderChecklistHandler(c *gin.Context) {
+	var req ReorderChecklistRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	task := current.Clone()
+	if err := task.ReorderChecklist(req.ItemIDs); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "item_ids must contain every checklist item once")
+		return
+	}
+
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := ReorderChecklistResponse{
+		Result: toModelChecklist(task.Checklist),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// getTaskOrAbort returns the task given by the id path parameter, the error
+// response is written if it fails.
+func (s *httpServerImpl) getTaskOrAbort(c *gin.Context) (dao.Task, bool) {
+	task, _, ok := s.getTaskWithAccessOrAbort(c)
+	return task, ok
+}
+
+// getWritableTaskOrAbort is getTaskOrAbort for writing to the comments and the
+// attachments of the task, the requester with read access gets 403.
+func (s *httpServerImpl) getWritableTaskOrAbort(c *gin.Context) (dao.Task, bool) {
+	task, access, ok := s.getTaskWithAccessOrAbort(c)
+	if ok && access != dao.ShareAccessWrite {
+		writeResponseError(c, http.StatusForbidden, "the task is shared read-only")
+		return dao.Task{}, false
+	}
+	return task, ok
+}
+
+func (s *httpServerImpl) getTaskWithAccessOrAbort(c *gin.Context) (dao.Task, dao.ShareAccess, bool) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.Task{}, "", false
+	}
+
+	task, access, err := dao.GetWithAccess(c.Request.Context(), s.tasks(c), taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found")
+		return dao.Task{}, "", false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.GetByID failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Task{}, "", false
+	}
+
+	return task, access, true
+}
+
+// updateTaskOrAbort stores the modified task, records the operation for undo
+// and notifies the webhooks, the error response is written if it fails.
+func (s *httpServerImpl) updateTaskOrAbort(c *gin.Context, current dao.Task, task *dao.Task) bool {
+	err := s.tasks(c).Update(c.Request.Context(), task)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found")
+		return false
+	} else if errors.Is(err, dao.ErrPermissionDenied) {
+		writeResponseError(c, http.StatusForbidden, "the task is shared read-only")
+		return false
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.Update failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return false
+	}
+
+	s.recordOperation(c, current, *task)
+	s.notifyTaskChanges(c, current, *task)
+	return true
+}
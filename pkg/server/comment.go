@@ -0,0 +1,136 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func (s *httpServerImpl) ListCommentsHandler(c *gin.Context) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	comments, err := s.comments(c).ListByTaskID(task.ID)
+	if err != nil {
+		s.loggerOf(c).Errorf("commentDAO.ListByTaskID failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListCommentsResponse{
+		Result: toModelComments(comments),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) CreateCommentHandler(c *gin.Context) {
+	var req CreateCommentRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Body == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	task, ok := s.getWritableTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	comment, err := s.comments(c).Create(dao.Comment{
+		TaskID: task.ID,
+		Author: actorOf(c),
+		Body:   req.Body,
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("commentDAO.Create failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateCommentResponse{
+		Result: toModelComment(comment),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) UpdateCommentHandler(c *gin.Context) {
+	var req UpdateCommentRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Body == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	comment, ok := s.getCommentOrAbort(c)
+	if !ok {
+		return
+	}
+
+	comment.Body = req.Body
+	err := s.comments(c).Update(&comment)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "comment not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("commentDAO.Update failed, err=%v, commentID=%v", err, comment.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := UpdateCommentResponse{
+		Result: toModelComment(comment),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) DeleteCommentHandler(c *gin.Context) {
+	comment, ok := s.getCommentOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if err := s.comments(c).Delete(comment.ID); err != nil {
+		s.loggerOf(c).Errorf("commentDAO.Delete failed, err=%v, commentID=%v", err, comment.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// getCommentOrAbort returns the comment given by the path parameters to change,
+// which only its author and the admins can do. The error response is written
+// if it fails.
+func (s *httpServerImpl) getCommentOrAbort(c *gin.Context) (dao.Comment, bool) {
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.Comment{}, false
+	}
+
+	task, ok := s.getWritableTaskOrAbort(c)
+	if !ok {
+		return dao.Comment{}, false
+	}
+
+	comment, err := s.comments(c).GetByID(commentID)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && comment.TaskID != task.ID) {
+		writeResponseError(c, http.StatusNotFound, "comment not found")
+		return dao.Comment{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("commentDAO.GetByID failed, err=%v, commentID=%v", err, commentID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Comment{}, false
+	}
+
+	if id, _ := identityOf(c); comment.Author != actorOf(c) && id.Role != dao.RoleAdmin {
+		writeResponseError(c, http.StatusForbidden, "only the author can change the comment")
+		return dao.Comment{}, false
+	}
+
+	return comment, true
+}
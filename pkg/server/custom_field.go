@@ -0,0 +1,146 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// customFieldSortPrefix prefixes the key of the custom field to sort by, such
+// as "field.priority".
+const customFieldSortPrefix = "field."
+
+func (s *httpServerImpl) ListCustomFieldsHandler(c *gin.Context) {
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	rsp := ListCustomFieldsResponse{
+		Result: toModelCustomFields(project.CustomFields),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) UpdateCustomFieldsHandler(c *gin.Context) {
+	var req UpdateCustomFieldsRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	fields := toDAOCustomFields(req.Fields)
+	keys := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if err := field.Validate(); err != nil {
+			writeResponseError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if keys[field.Key] {
+			writeResponseError(c, http.StatusBadRequest, fmt.Sprintf("custom field %v: duplicated", field.Key))
+			return
+		}
+		keys[field.Key] = true
+	}
+
+	current, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	project := current.Clone()
+	project.CustomFields = fields
+	if !s.updateProjectOrAbort(c, &project) {
+		return
+	}
+
+	rsp := UpdateCustomFieldsResponse{
+		Result: toModelCustomFields(project.CustomFields),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// getFieldSchemaOrAbort returns the project which defines the custom fields of
+// an existing task, the archived projects are included and a missing project
+// defines none. The error response is written if it fails.
+func (s *httpServerImpl) getFieldSchemaOrAbort(c *gin.Context, projectID int) (dao.Project, bool) {
+	if projectID == 0 {
+		return dao.Project{}, true
+	}
+
+	project, err := s.projects(c).GetByID(projectID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		return dao.Project{}, true
+	} else if err != nil {
+		s.loggerOf(c).Errorf("projectDAO.GetByID failed, err=%v, projectID=%v", err, projectID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Project{}, false
+	}
+
+	return project, true
+}
+
+// checkFieldsOrAbort validates the custom field values against the schema of
+// the project. The error response is written if they are invalid.
+func checkFieldsOrAbort(c *gin.Context, project dao.Project, fields map[string]interface{}) bool {
+	if err := project.ValidateFields(fields); err != nil {
+		writeResponseError(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+// customFieldEqual compares the custom field value with the one given in the
+// query string by the type of the value.
+func customFieldEqual(value interface{}, s string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == s
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		return err == nil && v == f
+	case bool:
+		b, err := strconv.ParseBool(s)
+		return err == nil && v == b
+	default:
+		return false
+	}
+}
+
+// customFieldLess orders the tasks by the custom field, the tasks without the
+// field are always put last. The values of different types, which is possible
+// once the schema is changed, are ordered by the type names.
+func customFieldLess(key string, desc bool) func(a, b dao.Task) bool {
+	return func(a, b dao.Task) bool {
+		va, oka := a.Fields[key]
+		vb, okb := b.Fields[key]
+		if !oka || !okb {
+			return oka
+		}
+		if desc {
+			va, vb = vb, va
+		}
+
+		ta, tb := fmt.Sprintf("%T", va), fmt.Sprintf("%T", vb)
+		if ta != tb {
+			return ta < tb
+		}
+
+		switch x := va.(type) {
+		case string:
+			return x < vb.(string)
+		case float64:
+			return x < vb.(float64)
+		case bool:
+			return !x && vb.(bool)
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	healthStatusOK       = "ok"
+	healthStatusReady    = "ready"
+	healthStatusNotReady = "not ready"
+)
+
+// Health tells the orchestrator whether the server is alive and ready to
+// serve. The server is ready once SetReady(true) is called, normally after the
+// store is loaded, and as long as all the checks pass.
+type Health struct {
+	ready  atomic.Bool
+	checks map[string]dao.Pinger
+}
+
+// NewHealth returns the Health running the checks by name, it is not ready
+// until SetReady is called.
+func NewHealth(checks map[string]dao.Pinger) *Health {
+	return &Health{
+		checks: checks,
+	}
+}
+
+// SetReady flips the readiness, it is set false on shutdown so no more
+// requests are routed to the server while it drains.
+func (h *Health) SetReady(ready bool) {
+	h.ready.Store(ready)
+}
+
+// check runs the checks and returns the result of each, along with whether
+// all of them pass.
+func (h *Health) check() (map[string]string, bool) {
+	results := make(map[string]string, len(h.checks))
+	ok := true
+	for name, pinger := range h.checks {
+		if err := pinger.Ping(); err != nil {
+			results[name] = err.Error()
+			ok = false
+			continue
+		}
+		results[name] = healthStatusOK
+	}
+
+	return results, ok
+}
+
+// HealthzHandler reports the liveness, the server is alive as long as it
+// responds.
+func (h *Health) HealthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{
+		Status: healthStatusOK,
+	})
+}
+
+// ReadyzHandler reports the readiness, it gets 503 unless the server is ready
+// and all the checks pass.
+func (h *Health) ReadyzHandler(c *gin.Context) {
+	checks, ok := h.check()
+	if !h.ready.Load() || !ok {
+		c.JSON(http.StatusServiceUnavailable, HealthResponse{
+			Status: healthStatusNotReady,
+			Checks: checks,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, HealthResponse{
+		Status: healthStatusReady,
+		Checks: checks,
+	})
+}
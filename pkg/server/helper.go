@@ -1,22 +1,134 @@
 package server
 
 import (
+	"fmt"
 	"gogo-exercise/pkg/dao"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	defaultActor = "anonymous"
+)
+
+// tasks returns the TaskDAO of the tenant, acting on behalf of the requester.
+// The tasks are restricted to the ones owned by or shared with the requester,
+// unless the requester is an admin.
+func (s *httpServerImpl) tasks(c *gin.Context) dao.TaskDAO {
+	taskDAO := dao.WithActor(dao.ScopeTenant(s.taskDAO, tenantOf(c)), actorOf(c))
+	taskDAO = dao.ScopeLogger(taskDAO, s.loggerOf(c))
+	if id, ok := identityOf(c); ok && id.Role != dao.RoleAdmin {
+		taskDAO = dao.WithOwner(taskDAO, id.UserID)
+	}
+	return taskDAO
+}
+
+func (s *httpServerImpl) revisions(c *gin.Context) dao.RevisionDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.revisionDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) comments(c *gin.Context) dao.CommentDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.commentDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) attachments(c *gin.Context) dao.AttachmentDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.attachmentDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) timeEntries(c *gin.Context) dao.TimeEntryDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.timeEntryDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) projects(c *gin.Context) dao.ProjectDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.projectDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) boards(c *gin.Context) dao.BoardDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.boardDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) templates(c *gin.Context) dao.TemplateDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.templateDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) members(c *gin.Context) dao.ProjectMemberDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.projectMemberDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) shares(c *gin.Context) dao.TaskShareDAO {
+	return dao.ScopeLogger(dao.ScopeTenant(s.taskShareDAO, tenantOf(c)), s.loggerOf(c))
+}
+
+func (s *httpServerImpl) users(c *gin.Context) dao.UserDAO {
+	return dao.ScopeLogger(s.userDAO, s.loggerOf(c))
+}
+
+func (s *httpServerImpl) apiKeys(c *gin.Context) dao.APIKeyDAO {
+	return dao.ScopeLogger(s.apiKeyDAO, s.loggerOf(c))
+}
+
+func (s *httpServerImpl) undoLogs(c *gin.Context) dao.UndoLogDAO {
+	return dao.ScopeLogger(s.undoLogDAO, s.loggerOf(c))
+}
+
+func (s *httpServerImpl) blobs(c *gin.Context) dao.BlobStore {
+	return dao.ScopeLogger(s.blobStore, s.loggerOf(c))
+}
+
+// actorOf returns the username of the requester.
+func actorOf(c *gin.Context) string {
+	if id, ok := identityOf(c); ok {
+		return id.Username
+	}
+	return defaultActor
+}
+
+// ownerOf returns the user ID of the requester, who owns the created tasks.
+func ownerOf(c *gin.Context) int {
+	if id, ok := identityOf(c); ok {
+		return id.UserID
+	}
+	return 0
+}
+
+func (s *httpServerImpl) validDescription(description string) bool {
+	return s.config.DescriptionMaxBytes <= 0 || len(description) <= s.config.DescriptionMaxBytes
+}
+
 func writeResponseError(c *gin.Context, code int, message string) {
 	c.JSON(code, ErrorResponse{
-		Message: message,
+		Message:   message,
+		RequestID: requestIDOf(c),
 	})
 }
 
 func toModelTask(task dao.Task) Task {
+	var progress string
+	if done, total := task.ChecklistProgress(); total > 0 {
+		progress = fmt.Sprintf("%d/%d", done, total)
+	}
+
 	return Task{
-		ID:     task.ID,
-		Name:   task.Name,
-		Status: TaskStatus(task.Status),
+		ID:                task.ID,
+		Name:              task.Name,
+		Status:            TaskStatus(task.Status),
+		Description:       task.Description,
+		ProjectID:         task.ProjectID,
+		ParentID:          task.ParentID,
+		OwnerID:           task.OwnerID,
+		Tags:              task.Tags,
+		AssigneeID:        task.AssigneeID,
+		WatcherIDs:        task.WatcherIDs,
+		Rank:              task.Rank,
+		Column:            task.Column,
+		Fields:            task.Fields,
+		Checklist:         toModelChecklist(task.Checklist),
+		ChecklistProgress: progress,
+		CreatedAt:         task.CreatedAt,
+		UpdatedAt:         task.UpdatedAt,
+		CompletedAt:       task.CompletedAt,
+		DeletedAt:         task.DeletedAt,
 	}
 }
 
@@ -27,3 +139,297 @@ func toModelTasks(tasks []dao.Task) []Task {
 	}
 	return retTasks
 }
+
+func toModelRevision(revision dao.Revision) Revision {
+	changes := revision.Changes()
+	retChanges := make([]FieldChange, 0, len(changes))
+	for i := range changes {
+		retChanges = append(retChanges, FieldChange(changes[i]))
+	}
+
+	return Revision{
+		Number:    revision.Number,
+		Action:    string(revision.Action),
+		Actor:     revision.Actor,
+		CreatedAt: revision.CreatedAt,
+		Changes:   retChanges,
+	}
+}
+
+func toModelRevisions(revisions []dao.Revision) []Revision {
+	retRevisions := make([]Revision, 0, len(revisions))
+	for i := range revisions {
+		retRevisions = append(retRevisions, toModelRevision(revisions[i]))
+	}
+	return retRevisions
+}
+
+func toModelChecklist(checklist []dao.ChecklistItem) []ChecklistItem {
+	retChecklist := make([]ChecklistItem, 0, len(checklist))
+	for i := range checklist {
+		retChecklist = append(retChecklist, ChecklistItem(checklist[i]))
+	}
+	return retChecklist
+}
+
+func toModelComment(comment dao.Comment) Comment {
+	return Comment{
+		ID:        comment.ID,
+		Author:    comment.Author,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		EditedAt:  comment.EditedAt,
+	}
+}
+
+func toModelComments(comments []dao.Comment) []Comment {
+	retComments := make([]Comment, 0, len(comments))
+	for i := range comments {
+		retComments = append(retComments, toModelComment(comments[i]))
+	}
+	return retComments
+}
+
+func toModelAttachment(attachment dao.Attachment) Attachment {
+	return Attachment{
+		ID:          attachment.ID,
+		Filename:    attachment.Filename,
+		Size:        attachment.Size,
+		ContentType: attachment.ContentType,
+		SHA256:      attachment.SHA256,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func toModelAttachments(attachments []dao.Attachment) []Attachment {
+	retAttachments := make([]Attachment, 0, len(attachments))
+	for i := range attachments {
+		retAttachments = append(retAttachments, toModelAttachment(attachments[i]))
+	}
+	return retAttachments
+}
+
+// toModelTimeEntry converts the entry, the duration of a running entry is
+// counted until now.
+func toModelTimeEntry(entry dao.TimeEntry, now time.Time) TimeEntry {
+	stoppedAt := now
+	if entry.StoppedAt != nil {
+		stoppedAt = *entry.StoppedAt
+	}
+
+	tags := entry.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return TimeEntry{
+		ID:              entry.ID,
+		TaskID:          entry.TaskID,
+		User:            entry.User,
+		Tags:            tags,
+		StartedAt:       entry.StartedAt,
+		StoppedAt:       entry.StoppedAt,
+		DurationSeconds: int64(stoppedAt.Sub(entry.StartedAt) / time.Second),
+	}
+}
+
+func toModelTimeEntries(entries []dao.TimeEntry, now time.Time) []TimeEntry {
+	retEntries := make([]TimeEntry, 0, len(entries))
+	for i := range entries {
+		retEntries = append(retEntries, toModelTimeEntry(entries[i], now))
+	}
+	return retEntries
+}
+
+func toModelProject(project dao.Project) Project {
+	return Project{
+		ID:         project.ID,
+		Name:       project.Name,
+		CreatedAt:  project.CreatedAt,
+		UpdatedAt:  project.UpdatedAt,
+		ArchivedAt: project.ArchivedAt,
+	}
+}
+
+func toModelProjects(projects []dao.Project) []Project {
+	retProjects := make([]Project, 0, len(projects))
+	for i := range projects {
+		retProjects = append(retProjects, toModelProject(projects[i]))
+	}
+	return retProjects
+}
+
+func toModelBoard(board dao.Board) Board {
+	columns := make([]BoardColumn, 0, len(board.Columns))
+	for _, column := range board.Columns {
+		columns = append(columns, BoardColumn{
+			Key:      column.Key,
+			Name:     column.Name,
+			WIPLimit: column.WIPLimit,
+		})
+	}
+
+	return Board{
+		ID:        board.ID,
+		Name:      board.Name,
+		ProjectID: board.ProjectID,
+		GroupBy:   string(board.GroupBy),
+		Columns:   columns,
+		CreatedAt: board.CreatedAt,
+		UpdatedAt: board.UpdatedAt,
+	}
+}
+
+func toModelBoards(boards []dao.Board) []Board {
+	retBoards := make([]Board, 0, len(boards))
+	for i := range boards {
+		retBoards = append(retBoards, toModelBoard(boards[i]))
+	}
+	return retBoards
+}
+
+func toModelCustomFields(fields []dao.CustomField) []CustomField {
+	retFields := make([]CustomField, 0, len(fields))
+	for _, field := range fields {
+		retFields = append(retFields, CustomField{
+			Key:      field.Key,
+			Name:     field.Name,
+			Type:     string(field.Type),
+			Required: field.Required,
+			Options:  field.Options,
+		})
+	}
+	return retFields
+}
+
+func toDAOCustomFields(fields []CustomField) []dao.CustomField {
+	retFields := make([]dao.CustomField, 0, len(fields))
+	for _, field := range fields {
+		retFields = append(retFields, dao.CustomField{
+			Key:      field.Key,
+			Name:     field.Name,
+			Type:     dao.CustomFieldType(field.Type),
+			Required: field.Required,
+			Options:  field.Options,
+		})
+	}
+	return retFields
+}
+
+func toModelTemplate(template dao.Template) Template {
+	return Template{
+		ID:           template.ID,
+		TemplateTask: toModelTemplateTask(template.TemplateTask),
+		CreatedAt:    template.CreatedAt,
+		UpdatedAt:    template.UpdatedAt,
+	}
+}
+
+func toModelTemplates(templates []dao.Template) []Template {
+	retTemplates := make([]Template, 0, len(templates))
+	for i := range templates {
+		retTemplates = append(retTemplates, toModelTemplate(templates[i]))
+	}
+	return retTemplates
+}
+
+func toModelTemplateTask(task dao.TemplateTask) TemplateTask {
+	var subtasks []TemplateTask
+	for _, subtask := range task.Subtasks {
+		subtasks = append(subtasks, toModelTemplateTask(subtask))
+	}
+
+	return TemplateTask{
+		Name:        task.Name,
+		Description: task.Description,
+		Tags:        task.Tags,
+		Checklist:   task.Checklist,
+		Subtasks:    subtasks,
+	}
+}
+
+func toDAOTemplateTask(task TemplateTask) dao.TemplateTask {
+	var subtasks []dao.TemplateTask
+	for _, subtask := range task.Subtasks {
+		subtasks = append(subtasks, toDAOTemplateTask(subtask))
+	}
+
+	return dao.TemplateTask{
+		Name:        task.Name,
+		Description: task.Description,
+		Tags:        task.Tags,
+		Checklist:   task.Checklist,
+		Subtasks:    subtasks,
+	}
+}
+
+func toModelUser(user dao.User) User {
+	return User{
+		ID:        user.ID,
+		Username:  user.Username,
+		Role:      string(user.Role),
+		Tenant:    user.Tenant,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+func toModelUsers(users []dao.User) []User {
+	retUsers := make([]User, 0, len(users))
+	for i := range users {
+		retUsers = append(retUsers, toModelUser(users[i]))
+	}
+	return retUsers
+}
+
+func toModelAPIKey(key dao.APIKey) APIKey {
+	return APIKey{
+		ID:        key.ID,
+		Name:      key.Name,
+		CreatedAt: key.CreatedAt,
+		RevokedAt: key.RevokedAt,
+	}
+}
+
+func toModelAPIKeys(keys []dao.APIKey) []APIKey {
+	retKeys := make([]APIKey, 0, len(keys))
+	for i := range keys {
+		retKeys = append(retKeys, toModelAPIKey(keys[i]))
+	}
+	return retKeys
+}
+
+func toModelProjectMember(member dao.ProjectMember) ProjectMember {
+	return ProjectMember{
+		ProjectID: member.ProjectID,
+		UserID:    member.UserID,
+		Role:      string(member.Role),
+		CreatedAt: member.CreatedAt,
+		UpdatedAt: member.UpdatedAt,
+	}
+}
+
+func toModelProjectMembers(members []dao.ProjectMember) []ProjectMember {
+	retMembers := make([]ProjectMember, 0, len(members))
+	for i := range members {
+		retMembers = append(retMembers, toModelProjectMember(members[i]))
+	}
+	return retMembers
+}
+
+func toModelTaskShare(share dao.TaskShare) TaskShare {
+	return TaskShare{
+		TaskID:    share.TaskID,
+		UserID:    share.UserID,
+		Access:    string(share.Access),
+		CreatedAt: share.CreatedAt,
+		UpdatedAt: share.UpdatedAt,
+	}
+}
+
+func toModelTaskShares(shares []dao.TaskShare) []TaskShare {
+	retShares := make([]TaskShare, 0, len(shares))
+	for i := range shares {
+		retShares = append(retShares, toModelTaskShare(shares[i]))
+	}
+	return retShares
+}
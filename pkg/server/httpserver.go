@@ -5,38 +5,255 @@ import (
 	"gogo-exercise/pkg/dao"
 	"net/http"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// Config is the settings of the http server.
+type Config struct {
+	// DescriptionMaxBytes limits the size of task descriptions, 0 means no
+	// limit.
+	DescriptionMaxBytes int
+	// AttachmentMaxBytes limits the size of uploaded attachments, 0 means no
+	// limit.
+	AttachmentMaxBytes int64
+	// JWTSecret signs the JWTs issued on login, which expire after JWTTTL.
+	JWTSecret []byte
+	JWTTTL    time.Duration
+	// ReadRateLimit and WriteRateLimit are the budgets of each client for
+	// the reads and the writes.
+	ReadRateLimit  RateLimit
+	WriteRateLimit RateLimit
+	// TrustedProxies are the IPs or CIDRs of the proxies whose
+	// X-Forwarded-For is trusted for the client IP, nil means none, so the
+	// client IP is the remote address.
+	TrustedProxies []string
+	// Registry collects the metrics of the requests, nil means none. The
+	// metrics are served on /metrics to the admins of the default tenant if
+	// ServeMetrics is set, otherwise they are left to the admin server.
+	Registry     *prometheus.Registry
+	ServeMetrics bool
+	// Health serves /healthz and /readyz, nil means no probes.
+	Health *Health
+	// TracerProvider records the spans of the requests, nil means none.
+	TracerProvider trace.TracerProvider
+	// Webhooks are the URLs the task events are posted to, each delivery
+	// times out after WebhookTimeout.
+	Webhooks       []string
+	WebhookTimeout time.Duration
+}
+
+// Deps are the DAOs the http server works on, those of the routes a server
+// does not serve may be left nil.
+type Deps struct {
+	TaskDAO          dao.TaskDAO
+	RevisionDAO      dao.RevisionDAO
+	UndoLogDAO       dao.UndoLogDAO
+	CommentDAO       dao.CommentDAO
+	AttachmentDAO    dao.AttachmentDAO
+	BlobStore        dao.BlobStore
+	TimeEntryDAO     dao.TimeEntryDAO
+	ProjectDAO       dao.ProjectDAO
+	BoardDAO         dao.BoardDAO
+	TemplateDAO      dao.TemplateDAO
+	UserDAO          dao.UserDAO
+	APIKeyDAO        dao.APIKeyDAO
+	ProjectMemberDAO dao.ProjectMemberDAO
+	TaskShareDAO     dao.TaskShareDAO
+}
+
 type httpServerImpl struct {
-	logger  *zap.SugaredLogger
-	addr    string
-	taskDAO dao.TaskDAO
+	logger           *zap.SugaredLogger
+	addr             string
+	config           Config
+	taskDAO          dao.TaskDAO
+	revisionDAO      dao.RevisionDAO
+	undoLogDAO       dao.UndoLogDAO
+	undoMu           sync.Mutex
+	commentDAO       dao.CommentDAO
+	attachmentDAO    dao.AttachmentDAO
+	blobStore        dao.BlobStore
+	timeEntryDAO     dao.TimeEntryDAO
+	projectDAO       dao.ProjectDAO
+	boardDAO         dao.BoardDAO
+	templateDAO      dao.TemplateDAO
+	userDAO          dao.UserDAO
+	apiKeyDAO        dao.APIKeyDAO
+	projectMemberDAO dao.ProjectMemberDAO
+	taskShareDAO     dao.TaskShareDAO
+	// readLimiter and writeLimiter are nil if the requests are unlimited
+	readLimiter  *rateLimiter
+	writeLimiter *rateLimiter
+	// metrics is nil if the metrics are off
+	metrics *httpMetrics
+	// tracer is nil if the tracing is off
+	tracer trace.Tracer
+	// webhooks is nil if there are no webhooks
+	webhooks *webhookNotifier
+	// signUpMu serializes the sign-ups so that only one becomes the first user
+	signUpMu sync.Mutex
 }
 
-func NewHttpServer(logger *zap.SugaredLogger, addr string, taskDAO dao.TaskDAO) *http.Server {
+func NewHttpServer(logger *zap.SugaredLogger, addr string, config Config, deps Deps) *http.Server {
 	server := &httpServerImpl{
-		logger:  logger,
-		addr:    addr,
-		taskDAO: taskDAO,
+		logger:           logger,
+		addr:             addr,
+		config:           config,
+		taskDAO:          deps.TaskDAO,
+		revisionDAO:      deps.RevisionDAO,
+		undoLogDAO:       deps.UndoLogDAO,
+		commentDAO:       deps.CommentDAO,
+		attachmentDAO:    deps.AttachmentDAO,
+		blobStore:        deps.BlobStore,
+		timeEntryDAO:     deps.TimeEntryDAO,
+		projectDAO:       deps.ProjectDAO,
+		boardDAO:         deps.BoardDAO,
+		templateDAO:      deps.TemplateDAO,
+		userDAO:          deps.UserDAO,
+		apiKeyDAO:        deps.APIKeyDAO,
+		projectMemberDAO: deps.ProjectMemberDAO,
+		taskShareDAO:     deps.TaskShareDAO,
+		readLimiter:      newRateLimiter(config.ReadRateLimit),
+		writeLimiter:     newRateLimiter(config.WriteRateLimit),
+		webhooks:         newWebhookNotifier(config.Webhooks, config.WebhookTimeout),
 	}
 
-	router := gin.Default()
+	router := gin.New()
 	router.RedirectTrailingSlash = true
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		// trust none rather than the invalid ones
+		logger.Errorf("router.SetTrustedProxies failed, err=%v, proxies=%v", err, config.TrustedProxies)
+		_ = router.SetTrustedProxies(nil)
+	}
+	router.Use(server.RequestIDMiddleware())
+	if config.TracerProvider != nil {
+		server.tracer = config.TracerProvider.Tracer(tracerName)
+		router.Use(server.TracingMiddleware())
+	}
+	// the metrics are outside the recovery, so the panics count as 500
+	if config.Registry != nil {
+		server.metrics = newHTTPMetrics(config.Registry)
+		router.Use(server.MetricsMiddleware())
+	}
+	router.Use(server.AccessLogMiddleware(), server.RecoveryMiddleware())
+	if config.Registry != nil && config.ServeMetrics {
+		router.GET("/metrics", server.AuthMiddleware(), server.OperatorMiddleware(), gin.WrapH(NewMetricsHandler(config.Registry)))
+	}
+	if config.Health != nil {
+		router.GET("/healthz", config.Health.HealthzHandler)
+		router.GET("/readyz", config.Health.ReadyzHandler)
+	}
 	apiRouter := router.Group("/api")
-	apiRouter.Use(server.ContentTypeMiddleware())
+	apiRouter.Use(server.ContentTypeMiddleware(), server.RateLimitMiddleware())
+
+	// the routes to sign in are registered before the auth middleware, so
+	// they are open to the unauthenticated requests
+	apiRouter.POST("/auth/login", server.LoginHandler)
+	apiRouter.POST("/users", server.CreateUserHandler)
+	apiRouter.Use(server.AuthMiddleware(), server.TenantMiddleware(), server.PolicyMiddleware())
+
+	apiRouter.GET("/users", server.ListUsersHandler)
+	apiRouter.GET("/users/me", server.GetMeHandler)
+	apiRouter.PUT("/users/:id/role", server.UpdateUserRoleHandler)
+
+	apiKeysRouter := apiRouter.Group("/api-keys")
+	{
+		apiKeysRouter.GET("", server.ListAPIKeysHandler)
+		apiKeysRouter.POST("", server.CreateAPIKeyHandler)
+		apiKeysRouter.DELETE("/:id", server.RevokeAPIKeyHandler)
+	}
 
 	tasksRouter := apiRouter.Group("/tasks")
 	{
 		tasksRouter.GET("", server.ListTasksHandler)
 		tasksRouter.POST("", server.CreateTaskHandler)
+		tasksRouter.GET("/:id", server.GetTaskHandler)
 		tasksRouter.PUT("/:id", server.UpdateTaskHandler)
 		tasksRouter.DELETE("/:id", server.DeleteTaskHandler)
+		tasksRouter.POST("/:id/restore", server.RestoreTaskHandler)
+		tasksRouter.GET("/:id/history", server.ListTaskHistoryHandler)
+		tasksRouter.POST("/:id/revert", server.RevertTaskHandler)
+		tasksRouter.GET("/:id/checklist", server.ListChecklistHandler)
+		tasksRouter.POST("/:id/checklist", server.CreateChecklistItemHandler)
+		tasksRouter.PUT("/:id/checklist/order", server.ReorderChecklistHandler)
+		tasksRouter.PUT("/:id/checklist/:itemID", server.UpdateChecklistItemHandler)
+		tasksRouter.DELETE("/:id/checklist/:itemID", server.DeleteChecklistItemHandler)
+		tasksRouter.GET("/:id/comments", server.ListCommentsHandler)
+		tasksRouter.POST("/:id/comments", server.CreateCommentHandler)
+		tasksRouter.PUT("/:id/comments/:commentID", server.UpdateCommentHandler)
+		tasksRouter.DELETE("/:id/comments/:commentID", server.DeleteCommentHandler)
+		tasksRouter.GET("/:id/attachments", server.ListAttachmentsHandler)
+		tasksRouter.POST("/:id/attachments", server.CreateAttachmentHandler)
+		tasksRouter.GET("/:id/attachments/:attachmentID", server.DownloadAttachmentHandler)
+		tasksRouter.DELETE("/:id/attachments/:attachmentID", server.DeleteAttachmentHandler)
+		tasksRouter.POST("/:id/timer/start", server.StartTimerHandler)
+		tasksRouter.POST("/:id/timer/stop", server.StopTimerHandler)
+		tasksRouter.GET("/:id/time-entries", server.ListTimeEntriesHandler)
+		tasksRouter.POST("/:id/time-entries", server.CreateTimeEntryHandler)
+		tasksRouter.PUT("/:id/time-entries/:entryID", server.UpdateTimeEntryHandler)
+		tasksRouter.DELETE("/:id/time-entries/:entryID", server.DeleteTimeEntryHandler)
+		tasksRouter.PUT("/:id/project", server.MoveTaskHandler)
+		tasksRouter.POST("/:id/move", server.ReorderTaskHandler)
+		tasksRouter.POST("/:id/assign", server.AssignTaskHandler)
+		tasksRouter.POST("/:id/unassign", server.UnassignTaskHandler)
+		tasksRouter.POST("/:id/watch", server.WatchTaskHandler)
+		tasksRouter.POST("/:id/unwatch", server.UnwatchTaskHandler)
+		tasksRouter.GET("/:id/shares", server.ListTaskSharesHandler)
+		tasksRouter.PUT("/:id/shares/:userID", server.SetTaskShareHandler)
+		tasksRouter.DELETE("/:id/shares/:userID", server.DeleteTaskShareHandler)
+	}
+
+	projectsRouter := apiRouter.Group("/projects")
+	{
+		projectsRouter.GET("", server.ListProjectsHandler)
+		projectsRouter.POST("", server.CreateProjectHandler)
+		projectsRouter.GET("/:id", server.GetProjectHandler)
+		projectsRouter.PUT("/:id", server.UpdateProjectHandler)
+		projectsRouter.DELETE("/:id", server.DeleteProjectHandler)
+		projectsRouter.GET("/:id/tasks", server.ListProjectTasksHandler)
+		projectsRouter.GET("/:id/fields", server.ListCustomFieldsHandler)
+		projectsRouter.PUT("/:id/fields", server.UpdateCustomFieldsHandler)
+		projectsRouter.GET("/:id/members", server.ListProjectMembersHandler)
+		projectsRouter.PUT("/:id/members/:userID", server.SetProjectMemberHandler)
+		projectsRouter.DELETE("/:id/members/:userID", server.DeleteProjectMemberHandler)
+	}
+
+	boardsRouter := apiRouter.Group("/boards")
+	{
+		boardsRouter.GET("", server.ListBoardsHandler)
+		boardsRouter.POST("", server.CreateBoardHandler)
+		boardsRouter.GET("/:id", server.GetBoardHandler)
+		boardsRouter.PUT("/:id", server.UpdateBoardHandler)
+		boardsRouter.DELETE("/:id", server.DeleteBoardHandler)
+		boardsRouter.POST("/:id/cards/:taskID/move", server.MoveCardHandler)
+	}
+
+	templatesRouter := apiRouter.Group("/templates")
+	{
+		templatesRouter.GET("", server.ListTemplatesHandler)
+		templatesRouter.POST("", server.CreateTemplateHandler)
+		templatesRouter.GET("/:id", server.GetTemplateHandler)
+		templatesRouter.PUT("/:id", server.UpdateTemplateHandler)
+		templatesRouter.DELETE("/:id", server.DeleteTemplateHandler)
+		templatesRouter.POST("/:id/instantiate", server.InstantiateTemplateHandler)
+	}
+
+	trashRouter := apiRouter.Group("/trash")
+	{
+		trashRouter.GET("", server.ListTrashHandler)
+		trashRouter.DELETE("/:id", server.PurgeTaskHandler)
 	}
 
+	apiRouter.POST("/undo", server.UndoHandler)
+	apiRouter.POST("/redo", server.RedoHandler)
+	apiRouter.GET("/time-report", server.TimeReportHandler)
+
 	return &http.Server{
 		Handler: router,
 		Addr:    addr,
@@ -51,15 +268,98 @@ func (s *httpServerImpl) ContentTypeMiddleware() gin.HandlerFunc {
 }
 
 func (s *httpServerImpl) ListTasksHandler(c *gin.Context) {
-	tasks, err := s.taskDAO.List()
+	var req ListTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	s.listTasks(c, req)
+}
+
+// listTasks writes the tasks matched by the request.
+func (s *httpServerImpl) listTasks(c *gin.Context, req ListTasksRequest) {
+	var assigneeID, watcherID int
+	if req.Assignee != "" {
+		var ok bool
+		if assigneeID, ok = s.resolveUserOrAbort(c, req.Assignee); !ok {
+			return
+		}
+	}
+	if req.Watcher != "" {
+		var ok bool
+		if watcherID, ok = s.resolveUserOrAbort(c, req.Watcher); !ok {
+			return
+		}
+	}
+	query, err := newTaskQuery(req, assigneeID, watcherID)
 	if err != nil {
-		s.logger.Errorf("taskDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Render != "" && req.Render != renderHTML {
+		writeResponseError(c, http.StatusBadRequest, "unsupported render format")
+		return
+	}
+
+	tasks, err := s.tasks(c).List(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.List failed, err=%v", err)
 		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
 		return
 	}
 
 	rsp := ListTasksResponse{
-		Result: toModelTasks(tasks),
+		Result: toModelTasks(query.apply(tasks)),
+	}
+	if req.Render == renderHTML {
+		if err := renderTasks(rsp.Result); err != nil {
+			s.loggerOf(c).Errorf("renderTasks failed, err=%v", err)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			return
+		}
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) GetTaskHandler(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	var req GetTaskRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	if req.Render != "" && req.Render != renderHTML {
+		writeResponseError(c, http.StatusBadRequest, "unsupported render format")
+		return
+	}
+
+	task, err := s.tasks(c).GetByID(c.Request.Context(), taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.GetByID failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := GetTaskResponse{
+		Result: toModelTask(task),
+	}
+	if req.Render == renderHTML {
+		if err := renderTask(&rsp.Result); err != nil {
+			s.loggerOf(c).Errorf("renderTasks failed, err=%v, taskID=%v", err, taskID)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			return
+		}
 	}
 	c.JSON(http.StatusOK, rsp)
 }
@@ -71,12 +371,49 @@ func (s *httpServerImpl) CreateTaskHandler(c *gin.Context) {
 		return
 	}
 
-	task, err := s.taskDAO.Create(req.Name)
-	if err != nil {
-		s.logger.Errorf("taskDAO.Create failed, err=%v", err)
+	if !s.validDescription(req.Description) {
+		writeResponseError(c, http.StatusBadRequest, "description is too long")
+		return
+	}
+
+	project, ok := s.getTaskProjectOrAbort(c, req.ProjectID)
+	if !ok {
+		return
+	}
+
+	if !checkFieldsOrAbort(c, project, req.Fields) {
+		return
+	}
+
+	var assigneeID int
+	if req.Assignee != "" {
+		if assigneeID, ok = s.resolveUserOrAbort(c, req.Assignee); !ok {
+			return
+		}
+	}
+
+	task, err := s.tasks(c).Create(c.Request.Context(), dao.Task{
+		Name:        req.Name,
+		Description: req.Description,
+		ProjectID:   req.ProjectID,
+		Tags:        req.Tags,
+		AssigneeID:  assigneeID,
+		OwnerID:     ownerOf(c),
+		Fields:      req.Fields,
+	})
+	if errors.Is(err, dao.ErrTaskQuotaExceeded) {
+		writeResponseError(c, http.StatusForbidden, err.Error())
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.Create failed, err=%v", err)
 		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
 		return
 	}
+	s.recordOperation(c, trashedTask(task), task)
+	s.notifyTaskChanges(c, dao.Task{}, task)
 
 	rsp := CreateTaskResponse{
 		Result: toModelTask(task),
@@ -91,12 +428,28 @@ func (s *httpServerImpl) DeleteTaskHandler(c *gin.Context) {
 		return
 	}
 
-	if err = s.taskDAO.Delete(taskID); err != nil {
-		s.logger.Errorf("taskDAO.Delete failed, err=%v, taskID=%v", err, taskID)
+	current, err := s.tasks(c).GetByID(c.Request.Context(), taskID)
+	found := err == nil
+	if err != nil && !errors.Is(err, dao.ErrResourceNotFound) {
+		s.loggerOf(c).Errorf("taskDAO.GetByID failed, err=%v, taskID=%v", err, taskID)
 		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
 		return
 	}
 
+	err = s.tasks(c).Delete(c.Request.Context(), taskID)
+	if errors.Is(err, dao.ErrPermissionDenied) {
+		writeResponseError(c, http.StatusForbidden, "only the owner can delete the task")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.Delete failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if found {
+		s.recordOperation(c, current, trashedTask(current))
+	}
+
 	c.JSON(http.StatusNoContent, nil)
 }
 
@@ -113,23 +466,196 @@ func (s *httpServerImpl) UpdateTaskHandler(c *gin.Context) {
 		return
 	}
 
-	task := dao.Task{
-		ID:     taskID,
-		Name:   req.Name,
-		Status: dao.TaskStatus(req.Status),
+	if req.Description != nil && !s.validDescription(*req.Description) {
+		writeResponseError(c, http.StatusBadRequest, "description is too long")
+		return
+	}
+
+	current, err := s.tasks(c).GetByID(c.Request.Context(), taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.GetByID failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	task := current.Clone()
+	task.Name = req.Name
+	task.Status = dao.TaskStatus(req.Status)
+	if req.Description != nil {
+		task.Description = *req.Description
 	}
-	err = s.taskDAO.Update(&task)
+	if req.Tags != nil {
+		task.Tags = req.Tags
+	}
+	if req.Fields != nil {
+		project, ok := s.getFieldSchemaOrAbort(c, task.ProjectID)
+		if !ok || !checkFieldsOrAbort(c, project, req.Fields) {
+			return
+		}
+		task.Fields = req.Fields
+	}
+	err = s.tasks(c).Update(c.Request.Context(), &task)
 	if errors.Is(err, dao.ErrResourceNotFound) {
 		writeResponseError(c, http.StatusNotFound, "task not found")
 		return
+	} else if errors.Is(err, dao.ErrPermissionDenied) {
+		writeResponseError(c, http.StatusForbidden, "the task is shared read-only")
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
 	} else if err != nil {
-		s.logger.Errorf("taskDAO.Update failed, err=%v, taskID=%v", err, taskID)
+		s.loggerOf(c).Errorf("taskDAO.Update failed, err=%v, taskID=%v", err, taskID)
 		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
 		return
 	}
+	s.recordOperation(c, current, task)
+	s.notifyTaskChanges(c, current, task)
 
 	rsp := UpdateTaskResponse{
 		Result: toModelTask(task),
 	}
 	c.JSON(http.StatusOK, rsp)
 }
+
+func (s *httpServerImpl) ListTrashHandler(c *gin.Context) {
+	tasks, err := s.tasks(c).ListTrashed(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.ListTrashed failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListTrashResponse{
+		Result: toModelTasks(tasks),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) RestoreTaskHandler(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	task, err := s.tasks(c).Restore(c.Request.Context(), taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found in trash")
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.Restore failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	s.recordOperation(c, trashedTask(task), task)
+
+	rsp := RestoreTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) PurgeTaskHandler(c *gin.Context) {
+	taskID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	err = s.tasks(c).Purge(c.Request.Context(), taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found in trash")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.Purge failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ListTaskHistoryHandler lists the revisions of a task, which is checked to be
+// visible to the requester first as the revisions hold its contents.
+func (s *httpServerImpl) ListTaskHistoryHandler(c *gin.Context) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := s.revisions(c).ListByTaskID(task.ID)
+	if err != nil {
+		s.loggerOf(c).Errorf("revisionDAO.ListByTaskID failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListTaskHistoryResponse{
+		Result: toModelRevisions(revisions),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) RevertTaskHandler(c *gin.Context) {
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+	taskID := current.ID
+
+	number, err := strconv.Atoi(c.Query("rev"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	revision, err := s.revisions(c).GetByTaskIDAndNumber(taskID, number)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "revision not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("revisionDAO.GetByTaskIDAndNumber failed, err=%v, taskID=%v, rev=%v", err, taskID, number)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if revision.After == nil {
+		writeResponseError(c, http.StatusConflict, "revision has no task to revert to")
+		return
+	}
+
+	task := *revision.After
+	if task.ProjectID != current.ProjectID && !s.checkTargetProjectOrAbort(c, task.ProjectID) {
+		return
+	}
+
+	err = s.tasks(c).Update(c.Request.Context(), &task)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "task not found")
+		return
+	} else if errors.Is(err, dao.ErrPermissionDenied) {
+		writeResponseError(c, http.StatusForbidden, "the task is shared read-only")
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.Update failed, err=%v, taskID=%v", err, taskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	s.recordOperation(c, current, task)
+	s.notifyTaskChanges(c, current, task)
+
+	rsp := RevertTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
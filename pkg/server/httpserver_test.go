@@ -2,33 +2,195 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"gogo-exercise/internal/mock/daomock"
 	"gogo-exercise/pkg/dao"
+	"io"
 	"math/rand"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v5"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
+// testJWTSecret signs the tokens of the test requests.
+var testJWTSecret = []byte("test-secret")
+
+// testUsername makes the requests which are not authorized otherwise.
+const testUsername = "tester"
+
+// authorize makes the request on behalf of the user, who is an admin.
+func authorize(req *http.Request, username string) {
+	authorizeAs(req, 1, username, dao.RoleAdmin)
+}
+
+// authorizeAs makes the request on behalf of the user with the role.
+func authorizeAs(req *http.Request, userID int, username string, role dao.Role) {
+	authorizeIn(req, userID, username, role, dao.DefaultTenant)
+}
+
+// authorizeIn makes the request on behalf of the user of the tenant, who is
+// served by the signedInUserDAO.
+func authorizeIn(req *http.Request, userID int, username string, role dao.Role, tenant string) {
+	signedInUsers[userID] = dao.User{ID: userID, Username: username, Role: role, Tenant: tenant}
+	token, err := signToken(testJWTSecret, tokenClaims{
+		Subject:   strconv.Itoa(userID),
+		Username:  username,
+		Role:      string(role),
+		Tenant:    tenant,
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+// signedInUsers are the users the tokens of the spec are signed for.
+var signedInUsers map[int]dao.User
+
+var _ = BeforeEach(func() {
+	signedInUsers = make(map[int]dao.User)
+})
+
+// signedInUserDAO serves the users signed in by authorizeIn, as the
+// AuthMiddleware loads the requester, the others are left to the UserDAO,
+// which is nil if the server needs no other users.
+type signedInUserDAO struct {
+	dao.UserDAO
+}
+
+func (userDAO signedInUserDAO) WithLogger(logger *zap.SugaredLogger) dao.UserDAO {
+	if userDAO.UserDAO == nil {
+		return userDAO
+	}
+	return signedInUserDAO{UserDAO: dao.ScopeLogger(userDAO.UserDAO, logger)}
+}
+
+func (userDAO signedInUserDAO) GetByID(id int) (dao.User, error) {
+	if user, found := signedInUsers[id]; found {
+		return user, nil
+	}
+	if userDAO.UserDAO == nil {
+		return dao.User{}, dao.ErrResourceNotFound
+	}
+	return userDAO.UserDAO.GetByID(id)
+}
+
+// allowScoping lets the mock be scoped to any tenant and logger, the mock
+// itself serves all of them.
+func allowScoping(mock interface{}, recorder interface {
+	WithLogger(logger interface{}) *gomock.Call
+}) {
+	recorder.WithLogger(gomock.Any()).Return(mock).AnyTimes()
+	if recorder, ok := recorder.(interface {
+		WithTenant(tenant interface{}) *gomock.Call
+	}); ok {
+		recorder.WithTenant(gomock.Any()).Return(mock).AnyTimes()
+	}
+}
+
 var _ = Describe("HttpServer", func() {
 	var ctrl *gomock.Controller
 	var taskDAO *daomock.MockTaskDAO
+	var revisionDAO *daomock.MockRevisionDAO
+	var undoLogDAO dao.UndoLogDAO
+	var commentDAO *daomock.MockCommentDAO
+	var attachmentDAO *daomock.MockAttachmentDAO
+	var blobStore *daomock.MockBlobStore
+	var timeEntryDAO *daomock.MockTimeEntryDAO
+	var projectDAO *daomock.MockProjectDAO
+	var boardDAO *daomock.MockBoardDAO
+	var templateDAO *daomock.MockTemplateDAO
+	var userDAO *daomock.MockUserDAO
+	var apiKeyDAO *daomock.MockAPIKeyDAO
+	var projectMemberDAO *daomock.MockProjectMemberDAO
+	var taskShareDAO *daomock.MockTaskShareDAO
 	var server *http.Server
+	// rawHandler serves the requests without authorizing them
+	var rawHandler http.Handler
 
 	BeforeEach(func() {
 		ctrl = gomock.NewController(GinkgoT())
 		taskDAO = daomock.NewMockTaskDAO(ctrl)
-		server = NewHttpServer(zap.NewNop().Sugar(), "", taskDAO)
+		revisionDAO = daomock.NewMockRevisionDAO(ctrl)
+		undoLogDAO = dao.NewGoCacheUndoLogDAO(zap.NewNop().Sugar(), dao.NewGoCache(), 10)
+		commentDAO = daomock.NewMockCommentDAO(ctrl)
+		attachmentDAO = daomock.NewMockAttachmentDAO(ctrl)
+		blobStore = daomock.NewMockBlobStore(ctrl)
+		timeEntryDAO = daomock.NewMockTimeEntryDAO(ctrl)
+		projectDAO = daomock.NewMockProjectDAO(ctrl)
+		boardDAO = daomock.NewMockBoardDAO(ctrl)
+		templateDAO = daomock.NewMockTemplateDAO(ctrl)
+		userDAO = daomock.NewMockUserDAO(ctrl)
+		apiKeyDAO = daomock.NewMockAPIKeyDAO(ctrl)
+		projectMemberDAO = daomock.NewMockProjectMemberDAO(ctrl)
+		taskShareDAO = daomock.NewMockTaskShareDAO(ctrl)
+		allowScoping(taskDAO, taskDAO.EXPECT())
+		allowScoping(revisionDAO, revisionDAO.EXPECT())
+		allowScoping(commentDAO, commentDAO.EXPECT())
+		allowScoping(attachmentDAO, attachmentDAO.EXPECT())
+		allowScoping(blobStore, blobStore.EXPECT())
+		allowScoping(timeEntryDAO, timeEntryDAO.EXPECT())
+		allowScoping(projectDAO, projectDAO.EXPECT())
+		allowScoping(boardDAO, boardDAO.EXPECT())
+		allowScoping(templateDAO, templateDAO.EXPECT())
+		allowScoping(userDAO, userDAO.EXPECT())
+		allowScoping(apiKeyDAO, apiKeyDAO.EXPECT())
+		allowScoping(projectMemberDAO, projectMemberDAO.EXPECT())
+		allowScoping(taskShareDAO, taskShareDAO.EXPECT())
+		config := Config{
+			DescriptionMaxBytes: 16,
+			AttachmentMaxBytes:  16,
+			JWTSecret:           testJWTSecret,
+			JWTTTL:              time.Hour,
+		}
+		server = NewHttpServer(zap.NewNop().Sugar(), "", config, Deps{
+			TaskDAO:          taskDAO,
+			RevisionDAO:      revisionDAO,
+			UndoLogDAO:       undoLogDAO,
+			CommentDAO:       commentDAO,
+			AttachmentDAO:    attachmentDAO,
+			BlobStore:        blobStore,
+			TimeEntryDAO:     timeEntryDAO,
+			ProjectDAO:       projectDAO,
+			BoardDAO:         boardDAO,
+			TemplateDAO:      templateDAO,
+			UserDAO:          signedInUserDAO{UserDAO: userDAO},
+			APIKeyDAO:        apiKeyDAO,
+			ProjectMemberDAO: projectMemberDAO,
+			TaskShareDAO:     taskShareDAO,
+		})
+
+		// the requests are made by a tester unless they are authorized
+		rawHandler = server.Handler
+		server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				authorize(r, testUsername)
+			}
+			rawHandler.ServeHTTP(w, r)
+		})
 	})
 
 	AfterEach(func() {
@@ -65,7 +227,7 @@ var _ = Describe("HttpServer", func() {
 					})
 				}
 
-				taskDAO.EXPECT().List().Return(tasks, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return(tasks, nil)
 			})
 
 			It("should get tasks", func() {
@@ -90,7 +252,7 @@ var _ = Describe("HttpServer", func() {
 				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
 				Expect(err).NotTo(HaveOccurred())
 
-				taskDAO.EXPECT().List().Return(nil, errors.New("dao error"))
+				taskDAO.EXPECT().List(gomock.Any()).Return(nil, errors.New("dao error"))
 			})
 
 			It("should get error message", func() {
@@ -109,6 +271,100 @@ var _ = Describe("HttpServer", func() {
 			})
 		})
 
+		Context("sort and filter by timestamps", func() {
+			var (
+				tasks []dao.Task
+			)
+
+			BeforeEach(func() {
+				var err error
+				createdAfter := time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)
+				url := "/api/tasks?sort=-created_at&created_after=" + createdAfter.Format(time.RFC3339)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				tasks = make([]dao.Task, 0, 3)
+				for day := 1; day <= 3; day++ {
+					createdAt := time.Date(2022, 10, day, 0, 0, 0, 0, time.UTC)
+					tasks = append(tasks, dao.Task{
+						ID:        day,
+						Name:      gofakeit.Noun(),
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					})
+				}
+
+				taskDAO.EXPECT().List(gomock.Any()).Return(tasks, nil)
+			})
+
+			It("should get the filtered tasks in order", func() {
+				var listResp ListTasksResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listResp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listResp.Result).To(Equal(toModelTasks([]dao.Task{tasks[2], tasks[1]})))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("manual order", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks?sort=manual", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{
+					{ID: 3, Name: gofakeit.Noun(), Rank: "05"},
+					{ID: 2, Name: gofakeit.Noun(), Rank: "2"},
+					{ID: 1, Name: gofakeit.Noun(), Rank: "1"},
+				}, nil)
+			})
+
+			It("should get tasks sorted by rank", func() {
+				var listResp ListTasksResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listResp)
+				Expect(err).NotTo(HaveOccurred())
+				ids := make([]int, 0, len(listResp.Result))
+				for _, task := range listResp.Result {
+					ids = append(ids, task.ID)
+				}
+				Expect(ids).To(Equal([]int{3, 1, 2}))
+			})
+		})
+
+		Context("unsupported sort field", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks?sort=color", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("unsupported sort field: color"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("invalid time", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks?updated_before=yesterday", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 	})
 
 	Describe("CreateTaskHandler", func() {
@@ -142,7 +398,7 @@ var _ = Describe("HttpServer", func() {
 					Name:   createReq.Name,
 					Status: dao.TaskStatusIncomplete,
 				}
-				taskDAO.EXPECT().Create(createReq.Name).Return(dbTask, nil)
+				taskDAO.EXPECT().Create(gomock.Any(), dao.Task{Name: createReq.Name, OwnerID: 1}).Return(dbTask, nil)
 			})
 
 			It("should get the created task", func() {
@@ -184,6 +440,31 @@ var _ = Describe("HttpServer", func() {
 			})
 		})
 
+		Context("description too long", func() {
+			BeforeEach(func() {
+				createReq := CreateTaskRequest{
+					Name:        gofakeit.Noun(),
+					Description: strings.Repeat("a", 17),
+				}
+				requestByte, err := json.Marshal(createReq)
+				Expect(err).NotTo(HaveOccurred())
+
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("description is too long"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Context("dao error", func() {
 			BeforeEach(func() {
 				createReq := CreateTaskRequest{
@@ -195,7 +476,7 @@ var _ = Describe("HttpServer", func() {
 				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
 				Expect(err).NotTo(HaveOccurred())
 
-				taskDAO.EXPECT().Create(createReq.Name).Return(dao.Task{}, errors.New("dao error"))
+				taskDAO.EXPECT().Create(gomock.Any(), dao.Task{Name: createReq.Name, OwnerID: 1}).Return(dao.Task{}, errors.New("dao error"))
 			})
 
 			It("should get error message", func() {
@@ -234,7 +515,8 @@ var _ = Describe("HttpServer", func() {
 				req, err = http.NewRequest(http.MethodDelete, url, nil)
 				Expect(err).NotTo(HaveOccurred())
 
-				taskDAO.EXPECT().Delete(taskID).Return(nil)
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+				taskDAO.EXPECT().Delete(gomock.Any(), taskID).Return(nil)
 			})
 
 			It("should get content-type header", func() {
@@ -278,7 +560,8 @@ var _ = Describe("HttpServer", func() {
 				req, err = http.NewRequest(http.MethodDelete, url, nil)
 				Expect(err).NotTo(HaveOccurred())
 
-				taskDAO.EXPECT().Delete(taskID).Return(errors.New("dao error"))
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+				taskDAO.EXPECT().Delete(gomock.Any(), taskID).Return(errors.New("dao error"))
 			})
 
 			It("should get error message", func() {
@@ -332,7 +615,8 @@ var _ = Describe("HttpServer", func() {
 					Name:   reqBody.Name,
 					Status: dao.TaskStatus(reqBody.Status),
 				}
-				taskDAO.EXPECT().Update(&dbTask).Return(nil)
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID, Name: gofakeit.Noun()}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &dbTask).Return(nil)
 			})
 
 			It("should get the updated task", func() {
@@ -420,7 +704,7 @@ var _ = Describe("HttpServer", func() {
 				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
 				Expect(err).NotTo(HaveOccurred())
 
-				taskDAO.EXPECT().Update(gomock.Any()).Return(dao.ErrResourceNotFound)
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{}, dao.ErrResourceNotFound)
 			})
 
 			It("should get error message", func() {
@@ -453,7 +737,8 @@ var _ = Describe("HttpServer", func() {
 				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
 				Expect(err).NotTo(HaveOccurred())
 
-				taskDAO.EXPECT().Update(gomock.Any()).Return(errors.New("dao error"))
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("dao error"))
 			})
 
 			It("should get error message", func() {
@@ -472,6 +757,4549 @@ var _ = Describe("HttpServer", func() {
 			})
 		})
 	})
+
+	Describe("ListTrashHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("normal case", func() {
+			var (
+				tasks []dao.Task
+			)
+
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/trash", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				deletedAt := time.Now().UTC()
+				tasks = []dao.Task{
+					{ID: rand.Int(), Name: gofakeit.Noun(), DeletedAt: &deletedAt},
+				}
+				taskDAO.EXPECT().ListTrashed(gomock.Any()).Return(tasks, nil)
+			})
+
+			It("should get trashed tasks", func() {
+				var listRsp ListTrashResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(HaveLen(1))
+				Expect(listRsp.Result[0].ID).To(Equal(tasks[0].ID))
+				Expect(listRsp.Result[0].DeletedAt.Equal(*tasks[0].DeletedAt)).To(BeTrue())
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("dao error", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/trash", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().ListTrashed(gomock.Any()).Return(nil, errors.New("dao error"))
+			})
+
+			It("should get status code 500", func() {
+				Expect(rsp.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("RestoreTaskHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("normal case", func() {
+			var (
+				dbTask dao.Task
+			)
+
+			BeforeEach(func() {
+				dbTask = dao.Task{
+					ID:     rand.Int(),
+					Name:   gofakeit.Noun(),
+					Status: dao.TaskStatus(rand.Int() % 2),
+				}
+
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/restore", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().Restore(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get the restored task", func() {
+				var restoreRsp RestoreTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &restoreRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restoreRsp.Result).To(Equal(toModelTask(dbTask)))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("task not in trash", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/restore", taskID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().Restore(gomock.Any(), taskID).Return(dao.Task{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("task not found in trash"))
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("PurgeTaskHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("normal case", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/trash/%d", taskID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().Purge(gomock.Any(), taskID).Return(nil)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("task not in trash", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/trash/%d", taskID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().Purge(gomock.Any(), taskID).Return(dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("ListTaskHistoryHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("normal case", func() {
+			var (
+				revisions []dao.Revision
+			)
+
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/history", taskID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				before := dao.Task{ID: taskID, Name: gofakeit.Noun()}
+				after := before
+				after.Status = dao.TaskStatusComplete
+				revisions = []dao.Revision{
+					{TaskID: taskID, Number: 1, Action: dao.RevisionActionCreate, Actor: "alice", After: &before},
+					{TaskID: taskID, Number: 2, Action: dao.RevisionActionUpdate, Actor: "bob", Before: &before, After: &after},
+				}
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(after, nil)
+				revisionDAO.EXPECT().ListByTaskID(taskID).Return(revisions, nil)
+			})
+
+			It("should get revisions with changes", func() {
+				var historyRsp ListTaskHistoryResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &historyRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(historyRsp.Result).To(HaveLen(2))
+				Expect(historyRsp.Result[1].Actor).To(Equal("bob"))
+				Expect(historyRsp.Result[1].Action).To(Equal("update"))
+				Expect(historyRsp.Result[1].Changes).To(Equal([]FieldChange{
+					{Field: "status", Before: float64(0), After: float64(1)},
+				}))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("dao error", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/history", taskID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+				revisionDAO.EXPECT().ListByTaskID(taskID).Return(nil, errors.New("dao error"))
+			})
+
+			It("should get status code 500", func() {
+				Expect(rsp.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("RevertTaskHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("normal case", func() {
+			var (
+				dbTask dao.Task
+			)
+
+			BeforeEach(func() {
+				dbTask = dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/revert?rev=1", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				revision := dao.Revision{TaskID: dbTask.ID, Number: 1, Action: dao.RevisionActionCreate, After: &dbTask}
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dao.Task{ID: dbTask.ID, Name: gofakeit.Noun()}, nil)
+				revisionDAO.EXPECT().GetByTaskIDAndNumber(dbTask.ID, 1).Return(revision, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &dbTask).Return(nil)
+			})
+
+			It("should get the reverted task", func() {
+				var revertRsp RevertTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &revertRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revertRsp.Result).To(Equal(toModelTask(dbTask)))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("invalid revision", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/revert?rev=nan", taskID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("revision not exist", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/revert?rev=3", taskID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+				revisionDAO.EXPECT().GetByTaskIDAndNumber(taskID, 3).Return(dao.Revision{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("revision of deletion", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/revert?rev=2", taskID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				revision := dao.Revision{TaskID: taskID, Number: 2, Action: dao.RevisionActionDelete, Before: &dao.Task{ID: taskID}}
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{ID: taskID}, nil)
+				revisionDAO.EXPECT().GetByTaskIDAndNumber(taskID, 2).Return(revision, nil)
+			})
+
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("UndoHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			before dao.Task
+			after  dao.Task
+		)
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodPost, "/api/undo", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			before = dao.Task{ID: rand.Int(), Name: gofakeit.Noun(), Status: dao.TaskStatusIncomplete}
+			after = before
+			after.Status = dao.TaskStatusComplete
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("nothing to undo", func() {
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("undo an update", func() {
+			BeforeEach(func() {
+				var log dao.UndoLog
+				log.Push(dao.Operation{TaskID: before.ID, Before: before, After: after})
+				Expect(undoLogDAO.Save(testUsername, log)).To(Succeed())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(after, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &before).Return(nil)
+			})
+
+			It("should get the task before the update", func() {
+				var undoRsp UndoResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &undoRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(undoRsp.Result).To(Equal(toModelTask(before)))
+			})
+
+			It("should move the operation to the redo stack", func() {
+				log, err := undoLogDAO.Get(testUsername)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(log.Undo).To(BeEmpty())
+				Expect(log.Redo).To(Equal([]dao.Operation{{TaskID: before.ID, Before: before, After: after}}))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("undo an update that emptied the tags", func() {
+			BeforeEach(func() {
+				before.Tags = []string{"home"}
+				after.Tags = []string{}
+
+				var log dao.UndoLog
+				log.Push(dao.Operation{TaskID: before.ID, Before: before, After: after})
+				Expect(undoLogDAO.Save(testUsername, log)).To(Succeed())
+
+				// the TaskDAO stores the empty tags as nil
+				stored := after
+				stored.Tags = nil
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(stored, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &before).Return(nil)
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("undo a creation", func() {
+			BeforeEach(func() {
+				var log dao.UndoLog
+				log.Push(dao.Operation{TaskID: after.ID, Before: trashedTask(after), After: after})
+				Expect(undoLogDAO.Save(testUsername, log)).To(Succeed())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), after.ID).Return(after, nil)
+				taskDAO.EXPECT().Delete(gomock.Any(), after.ID).Return(nil)
+			})
+
+			It("should get the trashed task", func() {
+				var undoRsp UndoResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &undoRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(undoRsp.Result.DeletedAt).NotTo(BeNil())
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("task has changed", func() {
+			BeforeEach(func() {
+				var log dao.UndoLog
+				log.Push(dao.Operation{TaskID: before.ID, Before: before, After: after})
+				Expect(undoLogDAO.Save(testUsername, log)).To(Succeed())
+
+				changed := after
+				changed.Name = changed.Name + "_v2"
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(changed, nil)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("task has changed since the operation"))
+			})
+
+			It("should drop the operation", func() {
+				log, err := undoLogDAO.Get(testUsername)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(log.Undo).To(BeEmpty())
+				Expect(log.Redo).To(BeEmpty())
+			})
+
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("recorded by UpdateTaskHandler", func() {
+			BeforeEach(func() {
+				reqBody := UpdateTaskRequest{Name: after.Name, Status: int(after.Status)}
+				requestByte, err := json.Marshal(reqBody)
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d", before.ID)
+				updateReq, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(before, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &after).Return(nil)
+				server.Handler.ServeHTTP(httptest.NewRecorder(), updateReq)
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(after, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &before).Return(nil)
+			})
+
+			It("should undo the update", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+	})
+
+	Describe("RedoHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			before dao.Task
+			after  dao.Task
+		)
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodPost, "/api/redo", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			before = dao.Task{ID: rand.Int(), Name: gofakeit.Noun(), Status: dao.TaskStatusIncomplete}
+			after = before
+			after.Status = dao.TaskStatusComplete
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("nothing to redo", func() {
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("redo an update", func() {
+			BeforeEach(func() {
+				log := dao.UndoLog{
+					Redo: []dao.Operation{{TaskID: before.ID, Before: before, After: after}},
+				}
+				Expect(undoLogDAO.Save(testUsername, log)).To(Succeed())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(before, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &after).Return(nil)
+			})
+
+			It("should get the task after the update", func() {
+				var redoRsp RedoResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &redoRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(redoRsp.Result).To(Equal(toModelTask(after)))
+			})
+
+			It("should move the operation back to the undo stack", func() {
+				log, err := undoLogDAO.Get(testUsername)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(log.Undo).To(HaveLen(1))
+				Expect(log.Redo).To(BeEmpty())
+			})
+		})
+
+		Context("task has been deleted since the undo", func() {
+			BeforeEach(func() {
+				log := dao.UndoLog{
+					Redo: []dao.Operation{{TaskID: before.ID, Before: before, After: trashedTask(before)}},
+				}
+				Expect(undoLogDAO.Save(testUsername, log)).To(Succeed())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), before.ID).Return(dao.Task{}, dao.ErrResourceNotFound)
+				taskDAO.EXPECT().ListTrashed(gomock.Any()).Return([]dao.Task{trashedTask(before)}, nil)
+			})
+
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+	})
+
+	Describe("GetTaskHandler", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("normal case", func() {
+			var (
+				dbTask dao.Task
+			)
+
+			BeforeEach(func() {
+				dbTask = dao.Task{
+					ID:          rand.Int(),
+					Name:        gofakeit.Noun(),
+					Description: "**bold**",
+				}
+
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d", dbTask.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get the task without rendered description", func() {
+				var getRsp GetTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &getRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getRsp.Result).To(Equal(toModelTask(dbTask)))
+				Expect(getRsp.Result.DescriptionHTML).To(BeEmpty())
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("render html", func() {
+			BeforeEach(func() {
+				dbTask := dao.Task{
+					ID:          rand.Int(),
+					Name:        gofakeit.Noun(),
+					Description: "**bold** <script>alert(1)</script>",
+				}
+
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d?render=html", dbTask.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get the sanitized html", func() {
+				var getRsp GetTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &getRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getRsp.Result.DescriptionHTML).To(ContainSubstring("<strong>bold</strong>"))
+				Expect(getRsp.Result.DescriptionHTML).NotTo(ContainSubstring("<script>"))
+			})
+		})
+
+		Context("unsupported render format", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d?render=pdf", rand.Int())
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("task not exist", func() {
+			BeforeEach(func() {
+				taskID := rand.Int()
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d", taskID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), taskID).Return(dao.Task{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("ChecklistHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbTask dao.Task
+		)
+
+		BeforeEach(func() {
+			dbTask = dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+			dbTask.AddChecklistItem("first")
+			dbTask.AddChecklistItem("second")
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("list checklist", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/checklist", dbTask.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get items in order", func() {
+				var listRsp ListChecklistResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(Equal([]ChecklistItem{
+					{ID: 1, Text: "first"},
+					{ID: 2, Text: "second"},
+				}))
+			})
+		})
+
+		Context("create item", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateChecklistItemRequest{Text: "third"})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/checklist", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := dbTask.Clone()
+				updated.AddChecklistItem("third")
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &updated).Return(nil)
+			})
+
+			It("should get the created item", func() {
+				var createRsp CreateChecklistItemResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result).To(Equal(ChecklistItem{ID: 3, Text: "third"}))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("update item", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateChecklistItemRequest{Text: "first", Done: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/checklist/1", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := dbTask.Clone()
+				updated.Checklist[0].Done = true
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &updated).Return(nil)
+			})
+
+			It("should get the updated item", func() {
+				var updateRsp UpdateChecklistItemResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &updateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updateRsp.Result).To(Equal(ChecklistItem{ID: 1, Text: "first", Done: true}))
+			})
+		})
+
+		Context("update item not exist", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateChecklistItemRequest{Text: "first", Done: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/checklist/3", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("delete item", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/checklist/1", dbTask.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := dbTask.Clone()
+				updated.Checklist = updated.Checklist[1:]
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &updated).Return(nil)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("reorder items", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(ReorderChecklistRequest{ItemIDs: []int{2, 1}})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/checklist/order", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := dbTask.Clone()
+				updated.Checklist = []dao.ChecklistItem{dbTask.Checklist[1], dbTask.Checklist[0]}
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &updated).Return(nil)
+			})
+
+			It("should get the reordered items", func() {
+				var reorderRsp ReorderChecklistResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &reorderRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reorderRsp.Result[0].ID).To(Equal(2))
+				Expect(reorderRsp.Result[1].ID).To(Equal(1))
+			})
+		})
+
+		Context("reorder with missing items", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(ReorderChecklistRequest{ItemIDs: []int{2}})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/checklist/order", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("CommentHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbTask    dao.Task
+			dbComment dao.Comment
+		)
+
+		BeforeEach(func() {
+			dbTask = dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+			dbComment = dao.Comment{
+				ID:        rand.Int(),
+				TaskID:    dbTask.ID,
+				Author:    "alice",
+				Body:      gofakeit.Sentence(3),
+				CreatedAt: time.Now().UTC(),
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("list comments", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/comments", dbTask.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				commentDAO.EXPECT().ListByTaskID(dbTask.ID).Return([]dao.Comment{dbComment}, nil)
+			})
+
+			It("should get comments", func() {
+				var listRsp ListCommentsResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(HaveLen(1))
+				Expect(listRsp.Result[0].Body).To(Equal(dbComment.Body))
+				Expect(listRsp.Result[0].Author).To(Equal("alice"))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("list comments of task not exist", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/comments", dbTask.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dao.Task{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("create comment", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateCommentRequest{Body: dbComment.Body})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/comments", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				commentDAO.EXPECT().Create(dao.Comment{
+					TaskID: dbTask.ID,
+					Author: "alice",
+					Body:   dbComment.Body,
+				}).Return(dbComment, nil)
+			})
+
+			It("should get the created comment", func() {
+				var createRsp CreateCommentResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result.ID).To(Equal(dbComment.ID))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create empty comment", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateCommentRequest{})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/comments", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("edit comment", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateCommentRequest{Body: "edited"})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/comments/%d", dbTask.ID, dbComment.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				edited := dbComment
+				edited.Body = "edited"
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				commentDAO.EXPECT().GetByID(dbComment.ID).Return(dbComment, nil)
+				commentDAO.EXPECT().Update(&edited).Return(nil)
+			})
+
+			It("should get the edited comment", func() {
+				var updateRsp UpdateCommentResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &updateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updateRsp.Result.Body).To(Equal("edited"))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("comment of another task", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/comments/%d", dbTask.ID, dbComment.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				other := dbComment
+				other.TaskID = dbTask.ID + 1
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				commentDAO.EXPECT().GetByID(dbComment.ID).Return(other, nil)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("delete comment", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/comments/%d", dbTask.ID, dbComment.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				commentDAO.EXPECT().GetByID(dbComment.ID).Return(dbComment, nil)
+				commentDAO.EXPECT().Delete(dbComment.ID).Return(nil)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+
+	Describe("AttachmentHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbTask       dao.Task
+			dbAttachment dao.Attachment
+		)
+
+		newUploadRequest := func(filename string, content []byte) *http.Request {
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+			Expect(writer.WriteField("note", "ignored")).To(Succeed())
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+			header.Set("Content-Type", "text/plain")
+			part, err := writer.CreatePart(header)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = part.Write(content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+
+			url := fmt.Sprintf("/api/tasks/%d/attachments", dbTask.ID)
+			req, err := http.NewRequest(http.MethodPost, url, body)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			return req
+		}
+
+		putBlob := func(r io.Reader) (string, int64, error) {
+			content, err := io.ReadAll(r)
+			if err != nil {
+				return "", 0, err
+			}
+			return dbAttachment.SHA256, int64(len(content)), nil
+		}
+
+		BeforeEach(func() {
+			dbTask = dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+			dbAttachment = dao.Attachment{
+				ID:          rand.Int(),
+				TaskID:      dbTask.ID,
+				Filename:    "note.txt",
+				Size:        5,
+				ContentType: "text/plain",
+				SHA256:      strings.Repeat("a", 64),
+				CreatedAt:   time.Now().UTC(),
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("list attachments", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/attachments", dbTask.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				attachmentDAO.EXPECT().ListByTaskID(dbTask.ID).Return([]dao.Attachment{dbAttachment}, nil)
+			})
+
+			It("should get attachments", func() {
+				var listRsp ListAttachmentsResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(HaveLen(1))
+				Expect(listRsp.Result[0].Filename).To(Equal("note.txt"))
+				Expect(listRsp.Result[0].SHA256).To(Equal(dbAttachment.SHA256))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("upload attachment", func() {
+			BeforeEach(func() {
+				req = newUploadRequest("note.txt", []byte("hello"))
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				blobStore.EXPECT().Put(gomock.Any()).DoAndReturn(putBlob)
+				attachmentDAO.EXPECT().Create(dao.Attachment{
+					TaskID:      dbTask.ID,
+					Filename:    "note.txt",
+					Size:        5,
+					ContentType: "text/plain",
+					SHA256:      dbAttachment.SHA256,
+				}).Return(dbAttachment, nil)
+			})
+
+			It("should get the created attachment", func() {
+				var createRsp CreateAttachmentResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result.ID).To(Equal(dbAttachment.ID))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("upload attachment too large", func() {
+			BeforeEach(func() {
+				req = newUploadRequest("note.txt", []byte(strings.Repeat("a", 17)))
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				blobStore.EXPECT().Put(gomock.Any()).DoAndReturn(putBlob)
+			})
+
+			It("should get status code 413", func() {
+				Expect(rsp.Code).To(Equal(http.StatusRequestEntityTooLarge))
+			})
+		})
+
+		Context("upload without file", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/attachments", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, strings.NewReader("{}"))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("download attachment", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/attachments/%d", dbTask.ID, dbAttachment.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				attachmentDAO.EXPECT().GetByID(dbAttachment.ID).Return(dbAttachment, nil)
+				blobStore.EXPECT().Open(dbAttachment.SHA256).Return(io.NopCloser(strings.NewReader("hello")), nil)
+			})
+
+			It("should get the content", func() {
+				Expect(rsp.Body.String()).To(Equal("hello"))
+				Expect(rsp.Header().Get("Content-Type")).To(Equal("text/plain"))
+				Expect(rsp.Header().Get("Content-Disposition")).To(Equal(`attachment; filename=note.txt`))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("attachment of another task", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/attachments/%d", dbTask.ID, dbAttachment.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				other := dbAttachment
+				other.TaskID = dbTask.ID + 1
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				attachmentDAO.EXPECT().GetByID(dbAttachment.ID).Return(other, nil)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("delete attachment", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/attachments/%d", dbTask.ID, dbAttachment.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				attachmentDAO.EXPECT().GetByID(dbAttachment.ID).Return(dbAttachment, nil)
+				attachmentDAO.EXPECT().Delete(dbAttachment.ID).Return(nil)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+	})
+
+	Describe("TimeEntryHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbTask  dao.Task
+			dbEntry dao.TimeEntry
+			day     time.Time
+		)
+
+		BeforeEach(func() {
+			day = time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+			stoppedAt := day.Add(2 * time.Hour)
+			dbTask = dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+			dbEntry = dao.TimeEntry{
+				ID:        rand.Int(),
+				TaskID:    dbTask.ID,
+				User:      "alice",
+				Tags:      []string{"billable"},
+				StartedAt: day.Add(time.Hour),
+				StoppedAt: &stoppedAt,
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("start timer", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(StartTimerRequest{Tags: []string{"billable"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/timer/start", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				running := dbEntry
+				running.StoppedAt = nil
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				timeEntryDAO.EXPECT().StartTimer(dbTask.ID, "alice", []string{"billable"}).Return(running, &dbEntry, nil)
+			})
+
+			It("should get the started and the stopped timers", func() {
+				var startRsp StartTimerResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &startRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(startRsp.Result.StoppedAt).To(BeNil())
+				Expect(startRsp.Stopped).NotTo(BeNil())
+				Expect(startRsp.Stopped.DurationSeconds).To(Equal(int64(3600)))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("stop timer not running", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/timer/stop", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				timeEntryDAO.EXPECT().StopTimer(dbTask.ID, testUsername).Return(dao.TimeEntry{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("create time entry", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateTimeEntryRequest{
+					StartedAt: dbEntry.StartedAt,
+					StoppedAt: *dbEntry.StoppedAt,
+					Tags:      dbEntry.Tags,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/time-entries", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				timeEntryDAO.EXPECT().Create(dao.TimeEntry{
+					TaskID:    dbTask.ID,
+					User:      "alice",
+					Tags:      dbEntry.Tags,
+					StartedAt: dbEntry.StartedAt,
+					StoppedAt: dbEntry.StoppedAt,
+				}).Return(dbEntry, nil)
+			})
+
+			It("should get the created entry", func() {
+				var createRsp CreateTimeEntryResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result.ID).To(Equal(dbEntry.ID))
+				Expect(createRsp.Result.DurationSeconds).To(Equal(int64(3600)))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create time entry stopped before started", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateTimeEntryRequest{
+					StartedAt: *dbEntry.StoppedAt,
+					StoppedAt: dbEntry.StartedAt,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/time-entries", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("update time entry", func() {
+			BeforeEach(func() {
+				stoppedAt := dbEntry.StartedAt.Add(30 * time.Minute)
+				requestByte, err := json.Marshal(UpdateTimeEntryRequest{
+					StartedAt: dbEntry.StartedAt,
+					StoppedAt: stoppedAt,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				url := fmt.Sprintf("/api/tasks/%d/time-entries/%d", dbTask.ID, dbEntry.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := dbEntry
+				updated.StoppedAt = &stoppedAt
+				updated.Tags = nil
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				timeEntryDAO.EXPECT().GetByID(dbEntry.ID).Return(dbEntry, nil)
+				timeEntryDAO.EXPECT().Update(&updated).Return(nil)
+			})
+
+			It("should get the updated entry", func() {
+				var updateRsp UpdateTimeEntryResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &updateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updateRsp.Result.DurationSeconds).To(Equal(int64(1800)))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("delete time entry", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/tasks/%d/time-entries/%d", dbTask.ID, dbEntry.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				timeEntryDAO.EXPECT().GetByID(dbEntry.ID).Return(dbEntry, nil)
+				timeEntryDAO.EXPECT().Delete(dbEntry.ID).Return(nil)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("time report", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/time-report?from=2022-10-01&to=2022-10-01", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				// the entry across midnight is clipped to the range
+				stoppedAt := day.Add(30 * time.Minute)
+				acrossMidnight := dao.TimeEntry{
+					TaskID:    dbTask.ID + 1,
+					User:      "bob",
+					StartedAt: day.Add(-time.Hour),
+					StoppedAt: &stoppedAt,
+				}
+				timeEntryDAO.EXPECT().List(day, day.Add(24*time.Hour)).Return([]dao.TimeEntry{dbEntry, acrossMidnight}, nil)
+				// billable is on both the task and the entry, it counts once
+				taggedTask := dbTask
+				taggedTask.Tags = []string{"billable", "client-a"}
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{taggedTask, {ID: dbTask.ID + 1, Tags: []string{"client-a"}}}, nil)
+			})
+
+			It("should sum the time per task, tag and day", func() {
+				var reportRsp TimeReportResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &reportRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reportRsp.Result.From).To(Equal("2022-10-01"))
+				Expect(reportRsp.Result.To).To(Equal("2022-10-01"))
+				Expect(reportRsp.Result.TotalSeconds).To(Equal(int64(5400)))
+				Expect(reportRsp.Result.ByTask).To(ConsistOf(
+					TaskTime{TaskID: dbTask.ID, Seconds: 3600},
+					TaskTime{TaskID: dbTask.ID + 1, Seconds: 1800},
+				))
+				Expect(reportRsp.Result.ByTag).To(Equal([]TagTime{
+					{Tag: "billable", Seconds: 3600},
+					{Tag: "client-a", Seconds: 5400},
+				}))
+				Expect(reportRsp.Result.ByDay).To(Equal([]DayTime{{Date: "2022-10-01", Seconds: 5400}}))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("time report without range", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/time-report", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("ProjectHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbProject  dao.Project
+			archivedAt time.Time
+		)
+
+		BeforeEach(func() {
+			archivedAt = time.Now().UTC()
+			dbProject = dao.Project{
+				ID:        rand.Int(),
+				Name:      gofakeit.Noun(),
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("list projects", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/projects", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				archived := dao.Project{ID: dbProject.ID + 1, Name: gofakeit.Noun(), ArchivedAt: &archivedAt}
+				projectDAO.EXPECT().List().Return([]dao.Project{dbProject, archived}, nil)
+			})
+
+			It("should get the projects not archived", func() {
+				var listRsp ListProjectsResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(HaveLen(1))
+				Expect(listRsp.Result[0].ID).To(Equal(dbProject.ID))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("create project", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateProjectRequest{Name: dbProject.Name})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/projects", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().Create(dao.Project{Name: dbProject.Name}).Return(dbProject, nil)
+			})
+
+			It("should get the created project", func() {
+				var createRsp CreateProjectResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result.ID).To(Equal(dbProject.ID))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create project without name", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, "/api/projects", strings.NewReader("{}"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("archive project by update", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateProjectRequest{Name: "renamed", Archived: true})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/projects/%d", dbProject.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				projectDAO.EXPECT().Update(gomock.Any()).DoAndReturn(func(project *dao.Project) error {
+					Expect(project.Name).To(Equal("renamed"))
+					Expect(project.ArchivedAt).NotTo(BeNil())
+					return nil
+				})
+			})
+
+			It("should get the archived project", func() {
+				var updateRsp UpdateProjectResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &updateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updateRsp.Result.ArchivedAt).NotTo(BeNil())
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("delete project by default", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/projects/%d", dbProject.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				projectDAO.EXPECT().Update(gomock.Any()).DoAndReturn(func(project *dao.Project) error {
+					Expect(project.ArchivedAt).NotTo(BeNil())
+					return nil
+				})
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("delete project in cascade", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/projects/%d?mode=cascade", dbProject.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				inProject := dao.Task{ID: rand.Int(), Name: gofakeit.Noun(), ProjectID: dbProject.ID}
+				other := dao.Task{ID: inProject.ID + 1, Name: gofakeit.Noun()}
+				takenOut := inProject
+				takenOut.ProjectID = 0
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{inProject, other}, nil)
+				gomock.InOrder(
+					taskDAO.EXPECT().Update(gomock.Any(), &takenOut).Return(nil),
+					taskDAO.EXPECT().Delete(gomock.Any(), inProject.ID).Return(nil),
+					projectDAO.EXPECT().Delete(dbProject.ID).Return(nil),
+					projectMemberDAO.EXPECT().DeleteByProjectID(dbProject.ID).Return(nil),
+				)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("delete project in unknown mode", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/projects/%d?mode=purge", dbProject.ID)
+				req, err = http.NewRequest(http.MethodDelete, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("list project tasks", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/projects/%d/tasks", dbProject.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				inProject := dao.Task{ID: rand.Int(), Name: gofakeit.Noun(), ProjectID: dbProject.ID}
+				other := dao.Task{ID: inProject.ID + 1, Name: gofakeit.Noun()}
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{inProject, other}, nil)
+			})
+
+			It("should get the tasks of the project", func() {
+				var listRsp ListTasksResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(HaveLen(1))
+				Expect(listRsp.Result[0].ProjectID).To(Equal(dbProject.ID))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("list tasks of project not exist", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/projects/%d/tasks", dbProject.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dao.Project{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("move task", func() {
+			var dbTask dao.Task
+
+			BeforeEach(func() {
+				dbTask = dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+				requestByte, err := json.Marshal(MoveTaskRequest{ProjectID: dbProject.ID})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/tasks/%d/project", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				moved := dbTask
+				moved.ProjectID = dbProject.ID
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &moved).Return(nil)
+			})
+
+			It("should get the moved task", func() {
+				var moveRsp MoveTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &moveRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(moveRsp.Result.ProjectID).To(Equal(dbProject.ID))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("move task to archived project", func() {
+			BeforeEach(func() {
+				dbTask := dao.Task{ID: rand.Int(), Name: gofakeit.Noun()}
+				requestByte, err := json.Marshal(MoveTaskRequest{ProjectID: dbProject.ID})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/tasks/%d/project", dbTask.ID)
+				req, err = http.NewRequest(http.MethodPut, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				dbProject.ArchivedAt = &archivedAt
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("create task in project not exist", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateTaskRequest{Name: gofakeit.Noun(), ProjectID: dbProject.ID})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dao.Project{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("ReorderTask", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			first, second, third dao.Task
+			reqBody              ReorderTaskRequest
+		)
+
+		BeforeEach(func() {
+			first = dao.Task{ID: 1, Name: gofakeit.Noun(), Rank: "1"}
+			second = dao.Task{ID: 2, Name: gofakeit.Noun(), Rank: "2"}
+			third = dao.Task{ID: 3, Name: gofakeit.Noun(), Rank: "3"}
+		})
+
+		JustBeforeEach(func() {
+			requestByte, err := json.Marshal(reqBody)
+			Expect(err).NotTo(HaveOccurred())
+			url := fmt.Sprintf("/api/tasks/%d/move", third.ID)
+			req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+			Expect(err).NotTo(HaveOccurred())
+
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("move after a task", func() {
+			BeforeEach(func() {
+				reqBody = ReorderTaskRequest{After: &first.ID}
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), third.ID).Return(third, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{third, second, first}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			})
+
+			It("should rank the task between the neighbours", func() {
+				var reorderRsp ReorderTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &reorderRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reorderRsp.Result.Rank > first.Rank).To(BeTrue())
+				Expect(reorderRsp.Result.Rank < second.Rank).To(BeTrue())
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("move before the first task", func() {
+			BeforeEach(func() {
+				reqBody = ReorderTaskRequest{Before: &first.ID}
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), third.ID).Return(third, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{third, second, first}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			})
+
+			It("should rank the task first", func() {
+				var reorderRsp ReorderTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &reorderRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reorderRsp.Result.Rank < first.Rank).To(BeTrue())
+			})
+		})
+
+		Context("anchors not in order", func() {
+			BeforeEach(func() {
+				reqBody = ReorderTaskRequest{Before: &first.ID, After: &second.ID}
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), third.ID).Return(third, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{third, second, first}, nil)
+			})
+
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("anchor not exists", func() {
+			BeforeEach(func() {
+				anchor := 100
+				reqBody = ReorderTaskRequest{After: &anchor}
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), third.ID).Return(third, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{third, second, first}, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("no anchor", func() {
+			BeforeEach(func() {
+				reqBody = ReorderTaskRequest{}
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+	})
+
+	Describe("BoardHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbBoard dao.Board
+			todo    dao.Task
+			doing   dao.Task
+		)
+
+		BeforeEach(func() {
+			dbBoard = dao.Board{
+				ID:      rand.Int(),
+				Name:    gofakeit.Noun(),
+				GroupBy: dao.BoardGroupByColumn,
+				Columns: []dao.BoardColumn{
+					{Key: "todo", Name: "To Do"},
+					{Key: "doing", Name: "Doing", WIPLimit: 1},
+				},
+			}
+			todo = dao.Task{ID: 1, Name: gofakeit.Noun(), Rank: "1"}
+			doing = dao.Task{ID: 2, Name: gofakeit.Noun(), Rank: "2", Column: "doing"}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("get board", func() {
+			BeforeEach(func() {
+				var err error
+				url := fmt.Sprintf("/api/boards/%d", dbBoard.ID)
+				req, err = http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				unknown := dao.Task{ID: 3, Name: gofakeit.Noun(), Column: "archived"}
+				boardDAO.EXPECT().GetByID(dbBoard.ID).Return(dbBoard, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{unknown, doing, todo}, nil)
+			})
+
+			It("should get the columns with their cards", func() {
+				var getRsp GetBoardResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &getRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getRsp.Result.Columns).To(HaveLen(2))
+				Expect(getRsp.Result.Columns[0].Key).To(Equal("todo"))
+				Expect(getRsp.Result.Columns[0].Cards).To(Equal(toModelTasks([]dao.Task{todo})))
+				Expect(getRsp.Result.Columns[1].Cards).To(Equal(toModelTasks([]dao.Task{doing})))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("create board", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateBoardRequest{
+					Name:    dbBoard.Name,
+					GroupBy: "status",
+					Columns: []BoardColumn{{Key: "incomplete"}, {Key: "complete", WIPLimit: 5}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/boards", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				boardDAO.EXPECT().Create(dao.Board{
+					Name:    dbBoard.Name,
+					GroupBy: dao.BoardGroupByStatus,
+					Columns: []dao.BoardColumn{{Key: "incomplete"}, {Key: "complete", WIPLimit: 5}},
+				}).Return(dbBoard, nil)
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create board with invalid status column", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateBoardRequest{
+					Name:    dbBoard.Name,
+					GroupBy: "status",
+					Columns: []BoardColumn{{Key: "doing"}},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/boards", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal(`invalid column key: "doing"`))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("move card", func() {
+			BeforeEach(func() {
+				dbBoard.Columns[1].WIPLimit = 2
+				requestByte, err := json.Marshal(MoveCardRequest{Column: "doing", After: &doing.ID})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/boards/%d/cards/%d/move", dbBoard.ID, todo.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				boardDAO.EXPECT().GetByID(dbBoard.ID).Return(dbBoard, nil)
+				taskDAO.EXPECT().GetByID(gomock.Any(), todo.ID).Return(todo, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{doing, todo}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			})
+
+			It("should move the card into the column", func() {
+				var moveRsp MoveCardResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &moveRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(moveRsp.Result.Column).To(Equal("doing"))
+				Expect(moveRsp.Result.Rank > doing.Rank).To(BeTrue())
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("move card into full column", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(MoveCardRequest{Column: "doing"})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/boards/%d/cards/%d/move", dbBoard.ID, todo.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				boardDAO.EXPECT().GetByID(dbBoard.ID).Return(dbBoard, nil)
+				taskDAO.EXPECT().GetByID(gomock.Any(), todo.ID).Return(todo, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{doing, todo}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: column doing is full", dao.ErrWIPLimitReached))
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("WIP limit reached: column doing is full"))
+			})
+
+			It("should get status code 409", func() {
+				Expect(rsp.Code).To(Equal(http.StatusConflict))
+			})
+		})
+
+		Context("move card on status board", func() {
+			BeforeEach(func() {
+				dbBoard.GroupBy = dao.BoardGroupByStatus
+				dbBoard.Columns = []dao.BoardColumn{{Key: "incomplete"}, {Key: "complete"}}
+				requestByte, err := json.Marshal(MoveCardRequest{Column: "complete"})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/boards/%d/cards/%d/move", dbBoard.ID, todo.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				completed := todo
+				completed.Status = dao.TaskStatusComplete
+				boardDAO.EXPECT().GetByID(dbBoard.ID).Return(dbBoard, nil)
+				taskDAO.EXPECT().GetByID(gomock.Any(), todo.ID).Return(todo, nil)
+				taskDAO.EXPECT().List(gomock.Any()).Return([]dao.Task{todo}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), &completed).Return(nil)
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("move card into unknown column", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(MoveCardRequest{Column: "review"})
+				Expect(err).NotTo(HaveOccurred())
+				url := fmt.Sprintf("/api/boards/%d/cards/%d/move", dbBoard.ID, todo.ID)
+				req, err = http.NewRequest(http.MethodPost, url, bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				boardDAO.EXPECT().GetByID(dbBoard.ID).Return(dbBoard, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+	Describe("CustomFieldHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbProject dao.Project
+		)
+
+		BeforeEach(func() {
+			dbProject = dao.Project{
+				ID:   rand.Int(),
+				Name: gofakeit.Noun(),
+				CustomFields: []dao.CustomField{
+					{Key: "estimate", Name: "Estimate", Type: dao.CustomFieldTypeNumber, Required: true},
+					{Key: "priority", Name: "Priority", Type: dao.CustomFieldTypeEnum, Options: []string{"low", "high"}},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("update fields", func() {
+			var fields []CustomField
+
+			BeforeEach(func() {
+				fields = []CustomField{
+					{Key: "due", Name: "Due", Type: "date"},
+				}
+				requestByte, err := json.Marshal(UpdateCustomFieldsRequest{Fields: fields})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, fmt.Sprintf("/api/projects/%v/fields", dbProject.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				projectDAO.EXPECT().Update(gomock.Any()).DoAndReturn(func(project *dao.Project) error {
+					Expect(project.CustomFields).To(Equal(toDAOCustomFields(fields)))
+					return nil
+				})
+			})
+
+			It("should get the updated fields", func() {
+				var updateRsp UpdateCustomFieldsResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &updateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updateRsp.Result).To(Equal(fields))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("update duplicated fields", func() {
+			BeforeEach(func() {
+				fields := []CustomField{
+					{Key: "due", Type: "date"},
+					{Key: "due", Type: "string"},
+				}
+				requestByte, err := json.Marshal(UpdateCustomFieldsRequest{Fields: fields})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, fmt.Sprintf("/api/projects/%v/fields", dbProject.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("custom field due: duplicated"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("create task with fields", func() {
+			var dbTask dao.Task
+
+			BeforeEach(func() {
+				fields := map[string]interface{}{"estimate": 3.0, "priority": "high"}
+				requestByte, err := json.Marshal(CreateTaskRequest{Name: gofakeit.Noun(), ProjectID: dbProject.ID, Fields: fields})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+				taskDAO.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task dao.Task) (dao.Task, error) {
+					Expect(task.Fields).To(Equal(fields))
+					task.ID = rand.Int()
+					dbTask = task
+					return task, nil
+				})
+			})
+
+			It("should get the created task", func() {
+				var createRsp CreateTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result).To(Equal(toModelTask(dbTask)))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create task without required field", func() {
+			BeforeEach(func() {
+				fields := map[string]interface{}{"priority": "high"}
+				requestByte, err := json.Marshal(CreateTaskRequest{Name: gofakeit.Noun(), ProjectID: dbProject.ID, Fields: fields})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("custom field estimate: required"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("update task with invalid field", func() {
+			BeforeEach(func() {
+				dbTask := dao.Task{
+					ID:        rand.Int(),
+					Name:      gofakeit.Noun(),
+					ProjectID: dbProject.ID,
+					Fields:    map[string]interface{}{"estimate": 3.0},
+				}
+				fields := map[string]interface{}{"estimate": 3.0, "priority": "medium"}
+				requestByte, err := json.Marshal(UpdateTaskRequest{Name: dbTask.Name, Fields: fields})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, fmt.Sprintf("/api/tasks/%v", dbTask.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				projectDAO.EXPECT().GetByID(dbProject.ID).Return(dbProject, nil)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("custom field priority: invalid enum value medium"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("list tasks by field", func() {
+			var tasks []dao.Task
+
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks?field=priority:high&sort=-field.estimate", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				tasks = []dao.Task{
+					{ID: 1, Fields: map[string]interface{}{"estimate": 1.0, "priority": "high"}},
+					{ID: 2, Fields: map[string]interface{}{"priority": "high"}},
+					{ID: 3, Fields: map[string]interface{}{"estimate": 5.0, "priority": "low"}},
+					{ID: 4, Fields: map[string]interface{}{"estimate": 2.0, "priority": "high"}},
+					{ID: 5},
+				}
+				taskDAO.EXPECT().List(gomock.Any()).Return(tasks, nil)
+			})
+
+			It("should get the matched tasks sorted by the field", func() {
+				var listRsp ListTasksResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(Equal(toModelTasks([]dao.Task{tasks[3], tasks[0], tasks[1]})))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+	})
+	Describe("TemplateHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbTemplate dao.Template
+		)
+
+		BeforeEach(func() {
+			dbTemplate = dao.Template{
+				ID: rand.Int(),
+				TemplateTask: dao.TemplateTask{
+					Name:      "Onboard {{assignee}}",
+					Tags:      []string{"onboarding"},
+					Checklist: []string{"sign in on {{ date }}"},
+					Subtasks: []dao.TemplateTask{
+						{Name: "Meet {{assignee}}"},
+					},
+				},
+				CreatedAt: time.Now().UTC(),
+				UpdatedAt: time.Now().UTC(),
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("create template", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateTemplateRequest{TemplateTask: toModelTemplateTask(dbTemplate.TemplateTask)})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/templates", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				templateDAO.EXPECT().Create(dao.Template{TemplateTask: dbTemplate.TemplateTask}).Return(dbTemplate, nil)
+			})
+
+			It("should get the created template", func() {
+				var createRsp CreateTemplateResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result).To(Equal(toModelTemplate(dbTemplate)))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create template with unnamed subtask", func() {
+			BeforeEach(func() {
+				templateTask := toModelTemplateTask(dbTemplate.TemplateTask)
+				templateTask.Subtasks[0].Name = ""
+				requestByte, err := json.Marshal(CreateTemplateRequest{TemplateTask: templateTask})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/templates", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("instantiate template", func() {
+			var tasks []dao.Task
+
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(InstantiateTemplateRequest{
+					Variables: map[string]string{"assignee": "alice", "date": "2022-03-01"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/templates/%v/instantiate", dbTemplate.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				root := dao.Task{Name: "Onboard alice", Tags: []string{"onboarding"}, OwnerID: 1}
+				root.AddChecklistItem("sign in on 2022-03-01")
+				tree := dao.TaskTree{
+					Task:     root,
+					Subtasks: []dao.TaskTree{{Task: dao.Task{Name: "Meet alice", OwnerID: 1}}},
+				}
+				tasks = []dao.Task{root, tree.Subtasks[0].Task}
+				tasks[0].ID = rand.Int()
+				tasks[1].ID = tasks[0].ID + 1
+				tasks[1].ParentID = tasks[0].ID
+
+				templateDAO.EXPECT().GetByID(dbTemplate.ID).Return(dbTemplate, nil)
+				taskDAO.EXPECT().CreateTree(gomock.Any(), tree).Return(tasks, nil)
+			})
+
+			It("should get the created tasks", func() {
+				var instantiateRsp InstantiateTemplateResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &instantiateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instantiateRsp.Result).To(Equal(toModelTasks(tasks)))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("instantiate template without variable", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(InstantiateTemplateRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/templates/%v/instantiate", dbTemplate.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				templateDAO.EXPECT().GetByID(dbTemplate.ID).Return(dbTemplate, nil)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("variable assignee is not given"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("instantiate template not exist", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(InstantiateTemplateRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/templates/%v/instantiate", dbTemplate.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				templateDAO.EXPECT().GetByID(dbTemplate.ID).Return(dao.Template{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+	Describe("AssigneeHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbTask dao.Task
+		)
+
+		BeforeEach(func() {
+			dbTask = dao.Task{
+				ID:         rand.Int(),
+				Name:       gofakeit.Noun(),
+				WatcherIDs: []int{2},
+			}
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("assign task to me", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(AssignTaskRequest{Assignee: "me"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/assign", dbTask.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *dao.Task) error {
+					Expect(task.AssigneeID).To(Equal(1))
+					return nil
+				})
+			})
+
+			It("should get the assigned task", func() {
+				var assignRsp AssignTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &assignRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(assignRsp.Result.AssigneeID).To(Equal(1))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("assign task to user", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(AssignTaskRequest{Assignee: "bob"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/assign", dbTask.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				userDAO.EXPECT().GetByUsername("bob").Return(dao.User{ID: 2, Username: "bob"}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *dao.Task) error {
+					Expect(task.AssigneeID).To(Equal(2))
+					return nil
+				})
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("assign task to user not exist", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(AssignTaskRequest{Assignee: "nobody"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/assign", dbTask.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				userDAO.EXPECT().GetByUsername("nobody").Return(dao.User{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("user not found"))
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("assign task to user of other tenant", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(AssignTaskRequest{Assignee: "bob"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/assign", dbTask.ID), bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				userDAO.EXPECT().GetByUsername("bob").Return(dao.User{ID: 2, Username: "bob", Tenant: "acme"}, nil)
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("assign task without assignee", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/assign", dbTask.ID), strings.NewReader("{}"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("unassign task", func() {
+			BeforeEach(func() {
+				dbTask.AssigneeID = 1
+
+				var err error
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/unassign", dbTask.ID), nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *dao.Task) error {
+					Expect(task.AssigneeID).To(BeZero())
+					return nil
+				})
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("watch task", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/watch", dbTask.ID), nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 1, "alice", dao.RoleAdmin)
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			})
+
+			It("should get the watched task", func() {
+				var watchRsp WatchTaskResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &watchRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(watchRsp.Result.WatcherIDs).To(Equal([]int{2, 1}))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("watch task watched already", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/watch", dbTask.ID), nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "bob", dao.RoleAdmin)
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("unwatch task", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%v/unwatch", dbTask.ID), nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "bob", dao.RoleAdmin)
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), dbTask.ID).Return(dbTask, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *dao.Task) error {
+					Expect(task.WatcherIDs).To(BeEmpty())
+					return nil
+				})
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("list tasks assigned to me", func() {
+			var tasks []dao.Task
+
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks?assignee=me&watcher=bob", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				userDAO.EXPECT().GetByUsername("bob").Return(dao.User{ID: 2, Username: "bob"}, nil)
+				tasks = []dao.Task{
+					{ID: 1, AssigneeID: 1, WatcherIDs: []int{2}},
+					{ID: 2, AssigneeID: 1},
+					{ID: 3, AssigneeID: 2, WatcherIDs: []int{2}},
+				}
+				taskDAO.EXPECT().List(gomock.Any()).Return(tasks, nil)
+			})
+
+			It("should get the matched tasks", func() {
+				var listRsp ListTasksResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(Equal(toModelTasks(tasks[:1])))
+			})
+		})
+	})
+	Describe("AuthHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		var (
+			dbUser dao.User
+		)
+
+		BeforeEach(func() {
+			dbUser = dao.User{
+				ID:       rand.Int(),
+				Username: "alice",
+				Role:     dao.RoleEditor,
+			}
+			Expect(dbUser.SetPassword("secret")).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			rawHandler.ServeHTTP(rsp, req)
+		})
+
+		Context("request without token", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 401", func() {
+				Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("request with forged token", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := signToken([]byte("other-secret"), tokenClaims{
+					Subject:   "1",
+					Username:  "alice",
+					ExpiresAt: time.Now().Add(time.Hour).Unix(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Authorization", "Bearer "+token)
+			})
+
+			It("should get status code 401", func() {
+				Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("request with expired token", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				token, err := signToken(testJWTSecret, tokenClaims{
+					Subject:   "1",
+					Username:  "alice",
+					ExpiresAt: time.Now().Add(-time.Second).Unix(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Authorization", "Bearer "+token)
+			})
+
+			It("should get status code 401", func() {
+				Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("request with api key", func() {
+			BeforeEach(func() {
+				secret, hash, err := dao.NewAPIKey()
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks/1/watch", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Authorization", "Bearer "+secret)
+
+				apiKeyDAO.EXPECT().GetByHash(hash).Return(dao.APIKey{ID: 1, UserID: dbUser.ID, KeyHash: hash}, nil)
+				userDAO.EXPECT().GetByID(dbUser.ID).Return(dbUser, nil)
+				// by the policy and then the handler
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil).Times(2)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *dao.Task) error {
+					Expect(task.WatcherIDs).To(Equal([]int{dbUser.ID}))
+					return nil
+				})
+			})
+
+			It("should act as the owner of the key", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("request with revoked api key", func() {
+			BeforeEach(func() {
+				secret, hash, err := dao.NewAPIKey()
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("Authorization", "Bearer "+secret)
+
+				revokedAt := time.Now()
+				apiKeyDAO.EXPECT().GetByHash(hash).Return(dao.APIKey{ID: 1, UserID: dbUser.ID, RevokedAt: &revokedAt}, nil)
+			})
+
+			It("should get status code 401", func() {
+				Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("login", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(LoginRequest{Username: dbUser.Username, Password: "secret"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				userDAO.EXPECT().GetByUsername(dbUser.Username).Return(dbUser, nil)
+			})
+
+			It("should get a token of the user", func() {
+				var loginRsp LoginResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &loginRsp)
+				Expect(err).NotTo(HaveOccurred())
+
+				claims, err := parseToken(testJWTSecret, loginRsp.Result.Token, time.Now())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(claims.Subject).To(Equal(strconv.Itoa(dbUser.ID)))
+				Expect(claims.Username).To(Equal(dbUser.Username))
+				Expect(claims.Role).To(Equal(string(dao.RoleEditor)))
+				Expect(loginRsp.Result.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("login with wrong password", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(LoginRequest{Username: dbUser.Username, Password: "wrong"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				userDAO.EXPECT().GetByUsername(dbUser.Username).Return(dbUser, nil)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("invalid username or password"))
+			})
+
+			It("should get status code 401", func() {
+				Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("create the first user", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateUserRequest{Username: dbUser.Username, Password: "secret"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				userDAO.EXPECT().List().Return(nil, nil)
+				userDAO.EXPECT().Create(gomock.Any()).DoAndReturn(func(user dao.User) (dao.User, error) {
+					Expect(user.Username).To(Equal(dbUser.Username))
+					Expect(user.CheckPassword("secret")).To(BeTrue())
+					Expect(user.Role).To(Equal(dao.RoleAdmin))
+					return dbUser, nil
+				})
+			})
+
+			It("should get the created user", func() {
+				var createRsp CreateUserResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Result).To(Equal(toModelUser(dbUser)))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("create user without token", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateUserRequest{Username: "bob", Password: "secret"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				userDAO.EXPECT().List().Return([]dao.User{dbUser}, nil)
+			})
+
+			It("should get status code 401", func() {
+				Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("create api key", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateAPIKeyRequest{Name: "ci"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/api-keys", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				apiKeyDAO.EXPECT().Create(gomock.Any()).DoAndReturn(func(key dao.APIKey) (dao.APIKey, error) {
+					Expect(key.UserID).To(Equal(1))
+					Expect(key.Name).To(Equal("ci"))
+					key.ID = 1
+					return key, nil
+				})
+			})
+
+			It("should get the key only once", func() {
+				var createRsp CreateAPIKeyResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &createRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createRsp.Key).To(HavePrefix(dao.APIKeyPrefix))
+				Expect(createRsp.Result.ID).To(Equal(1))
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("revoke api key of another user", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodDelete, "/api/api-keys/2", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorize(req, "alice")
+
+				apiKeyDAO.EXPECT().GetByID(2).Return(dao.APIKey{ID: 2, UserID: 2}, nil)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+	Describe("PolicyHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("viewer lists tasks", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "victor", dao.RoleViewer)
+
+				taskDAO.EXPECT().List(gomock.Any()).Return(nil, nil)
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("viewer updates task", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateTaskRequest{Name: "renamed"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "victor", dao.RoleViewer)
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+			})
+
+			It("should get the problem", func() {
+				Expect(rsp.Header().Get("Content-Type")).To(Equal("application/problem+json"))
+
+				var problem ProblemResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &problem)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(problem.RequestID).To(Equal(rsp.Header().Get("X-Request-ID")))
+				problem.RequestID = ""
+				Expect(problem).To(Equal(ProblemResponse{
+					Type:   "about:blank",
+					Title:  "Forbidden",
+					Status: http.StatusForbidden,
+					Detail: "the editor role is required",
+				}))
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("viewer edits task of project as editor member", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks/1/watch", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "victor", dao.RoleViewer)
+
+				task := dao.Task{ID: 1, ProjectID: 3}
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(task, nil).Times(2)
+				projectMemberDAO.EXPECT().Get(3, 2).Return(dao.ProjectMember{ProjectID: 3, UserID: 2, Role: dao.RoleEditor}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("editor creates task in project as viewer member", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateTaskRequest{Name: "task", ProjectID: 3})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+
+				projectMemberDAO.EXPECT().Get(3, 2).Return(dao.ProjectMember{ProjectID: 3, UserID: 2, Role: dao.RoleViewer}, nil)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor member moves task into project as viewer member", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(MoveTaskRequest{ProjectID: 4})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1/project", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "victor", dao.RoleViewer)
+
+				task := dao.Task{ID: 1, ProjectID: 3}
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(task, nil).Times(2)
+				projectMemberDAO.EXPECT().Get(3, 2).Return(dao.ProjectMember{ProjectID: 3, UserID: 2, Role: dao.RoleEditor}, nil)
+				projectDAO.EXPECT().GetByID(4).Return(dao.Project{ID: 4}, nil)
+				projectMemberDAO.EXPECT().Get(4, 2).Return(dao.ProjectMember{ProjectID: 4, UserID: 2, Role: dao.RoleViewer}, nil)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor member reverts task into project as viewer member", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks/1/revert?rev=1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "victor", dao.RoleViewer)
+
+				task := dao.Task{ID: 1, ProjectID: 3}
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(task, nil).Times(2)
+				projectMemberDAO.EXPECT().Get(3, 2).Return(dao.ProjectMember{ProjectID: 3, UserID: 2, Role: dao.RoleEditor}, nil)
+				revision := dao.Revision{TaskID: 1, Number: 1, Action: dao.RevisionActionCreate, After: &dao.Task{ID: 1, ProjectID: 4}}
+				revisionDAO.EXPECT().GetByTaskIDAndNumber(1, 1).Return(revision, nil)
+				projectMemberDAO.EXPECT().Get(4, 2).Return(dao.ProjectMember{ProjectID: 4, UserID: 2, Role: dao.RoleViewer}, nil)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor undoes move out of project as viewer member", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodPost, "/api/undo", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+
+				var log dao.UndoLog
+				log.Push(dao.Operation{TaskID: 1, Before: dao.Task{ID: 1, ProjectID: 4}, After: dao.Task{ID: 1, ProjectID: 3}})
+				Expect(undoLogDAO.Save("eve", log)).To(Succeed())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, ProjectID: 3}, nil)
+				projectMemberDAO.EXPECT().Get(4, 2).Return(dao.ProjectMember{ProjectID: 4, UserID: 2, Role: dao.RoleViewer}, nil)
+			})
+
+			It("should drop the operation", func() {
+				log, err := undoLogDAO.Get("eve")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(log.Undo).To(BeEmpty())
+				Expect(log.Redo).To(BeEmpty())
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor moves card on board of project as viewer member", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(MoveCardRequest{Column: "done"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/boards/5/cards/1/move", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+
+				boardDAO.EXPECT().GetByID(5).Return(dao.Board{ID: 5, ProjectID: 3}, nil)
+				projectMemberDAO.EXPECT().Get(3, 2).Return(dao.ProjectMember{ProjectID: 3, UserID: 2, Role: dao.RoleViewer}, nil)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor purges task", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodDelete, "/api/trash/1", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor creates user", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateUserRequest{Username: "bob", Password: "secret"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("admin creates viewer", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateUserRequest{Username: "bob", Password: "secret", Role: "viewer"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				userDAO.EXPECT().Create(gomock.Any()).DoAndReturn(func(user dao.User) (dao.User, error) {
+					Expect(user.Role).To(Equal(dao.RoleViewer))
+					user.ID = 2
+					return user, nil
+				})
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("admin updates role of user", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateUserRoleRequest{Role: "viewer"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/users/2/role", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				userDAO.EXPECT().GetByID(2).Return(dao.User{ID: 2, Username: "bob", Role: dao.RoleEditor}, nil)
+				userDAO.EXPECT().Update(&dao.User{ID: 2, Username: "bob", Role: dao.RoleViewer}).Return(nil)
+			})
+
+			It("should get the user with the role", func() {
+				var updateRsp UpdateUserRoleResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &updateRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updateRsp.Result.Role).To(Equal("viewer"))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("admin updates user with unknown role", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateUserRoleRequest{Role: "owner"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/users/2/role", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("admin adds member", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(SetProjectMemberRequest{Role: "editor"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/projects/3/members/2", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				projectDAO.EXPECT().GetByID(3).Return(dao.Project{ID: 3}, nil)
+				userDAO.EXPECT().GetByID(2).Return(dao.User{ID: 2}, nil)
+				projectMemberDAO.EXPECT().Set(&dao.ProjectMember{ProjectID: 3, UserID: 2, Role: dao.RoleEditor}).Return(nil)
+			})
+
+			It("should get the member", func() {
+				var setRsp SetProjectMemberResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &setRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(setRsp.Result).To(Equal(ProjectMember{ProjectID: 3, UserID: 2, Role: "editor"}))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("editor member adds member", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(SetProjectMemberRequest{Role: "editor"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/projects/3/members/4", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+
+				projectMemberDAO.EXPECT().Get(3, 2).Return(dao.ProjectMember{}, dao.ErrResourceNotFound)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+	Describe("TenantHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("admin acts on tenant", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("X-Tenant-ID", "acme")
+
+				taskDAO.EXPECT().List(gomock.Any()).Return(nil, nil)
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("user of tenant acts on another tenant", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeIn(req, 2, "alice", dao.RoleAdmin, "acme")
+				req.Header.Set("X-Tenant-ID", "globex")
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("editor of default tenant acts on tenant", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+				req.Header.Set("X-Tenant-ID", "acme")
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("admin acts on invalid tenant", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks", nil)
+				Expect(err).NotTo(HaveOccurred())
+				req.Header.Set("X-Tenant-ID", "acme:1")
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("create task over quota", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateTaskRequest{Name: "task"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().Create(gomock.Any(), gomock.Any()).Return(dao.Task{}, dao.ErrTaskQuotaExceeded)
+			})
+
+			It("should get error message", func() {
+				var body ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(body.Message).To(Equal("task quota exceeded"))
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("admin of tenant creates user", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateUserRequest{Username: "bob", Password: "secret"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeIn(req, 2, "alice", dao.RoleAdmin, "acme")
+
+				userDAO.EXPECT().Create(gomock.Any()).DoAndReturn(func(user dao.User) (dao.User, error) {
+					Expect(user.Tenant).To(Equal("acme"))
+					user.ID = 3
+					return user, nil
+				})
+			})
+
+			It("should get status code 201", func() {
+				Expect(rsp.Code).To(Equal(http.StatusCreated))
+			})
+		})
+
+		Context("admin of tenant creates user of another tenant", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(CreateUserRequest{Username: "bob", Password: "secret", Tenant: "globex"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeIn(req, 2, "alice", dao.RoleAdmin, "acme")
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("list users of tenant", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/users", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeIn(req, 2, "alice", dao.RoleViewer, "acme")
+
+				userDAO.EXPECT().List().Return([]dao.User{
+					{ID: 1, Username: testUsername},
+					{ID: 2, Username: "alice", Tenant: "acme"},
+					{ID: 3, Username: "bob", Tenant: "globex"},
+				}, nil)
+			})
+
+			It("should only get the users of the tenant", func() {
+				var listRsp ListUsersResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(HaveLen(1))
+				Expect(listRsp.Result[0].Username).To(Equal("alice"))
+			})
+		})
+	})
+
+	Describe("ShareHandlers", func() {
+		var (
+			req *http.Request
+			rsp *httptest.ResponseRecorder
+		)
+
+		JustBeforeEach(func() {
+			rsp = httptest.NewRecorder()
+			server.Handler.ServeHTTP(rsp, req)
+		})
+
+		Context("owner lists shares", func() {
+			var shares []dao.TaskShare
+
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodGet, "/api/tasks/1/shares", nil)
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+
+				shares = []dao.TaskShare{{TaskID: 1, UserID: 3, Access: dao.ShareAccessRead}}
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, OwnerID: 2}, nil).Times(2)
+				taskShareDAO.EXPECT().ListByTaskID(1).Return(shares, nil)
+			})
+
+			It("should get the shares", func() {
+				var listRsp ListTaskSharesResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &listRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(listRsp.Result).To(Equal(toModelTaskShares(shares)))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("editor shares task of another owner", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(SetTaskShareRequest{Access: "write"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1/shares/2", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				authorizeAs(req, 2, "eve", dao.RoleEditor)
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, OwnerID: 3}, nil).Times(2)
+			})
+
+			It("should get error message", func() {
+				var errRsp ErrorResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &errRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(errRsp.Message).To(Equal("only the owner can share the task"))
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("admin shares task", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(SetTaskShareRequest{Access: "read"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1/shares/3", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, OwnerID: 2}, nil)
+				userDAO.EXPECT().GetByID(3).Return(dao.User{ID: 3}, nil)
+				taskShareDAO.EXPECT().Set(&dao.TaskShare{TaskID: 1, UserID: 3, Access: dao.ShareAccessRead}).Return(nil)
+			})
+
+			It("should get the share", func() {
+				var setRsp SetTaskShareResponse
+				err := json.Unmarshal(rsp.Body.Bytes(), &setRsp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(setRsp.Result.Access).To(Equal("read"))
+			})
+
+			It("should get status code 200", func() {
+				Expect(rsp.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("share task with invalid access", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(SetTaskShareRequest{Access: "owner"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1/shares/3", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should get status code 400", func() {
+				Expect(rsp.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("share task with user of another tenant", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(SetTaskShareRequest{Access: "read"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1/shares/3", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, OwnerID: 1}, nil)
+				userDAO.EXPECT().GetByID(3).Return(dao.User{ID: 3, Tenant: "acme"}, nil)
+			})
+
+			It("should get status code 404", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("delete share", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequest(http.MethodDelete, "/api/tasks/1/shares/3", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, OwnerID: 1}, nil)
+				taskShareDAO.EXPECT().Delete(1, 3).Return(nil)
+			})
+
+			It("should get status code 204", func() {
+				Expect(rsp.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
+		Context("update task shared read-only", func() {
+			BeforeEach(func() {
+				requestByte, err := json.Marshal(UpdateTaskRequest{Name: "renamed"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err = http.NewRequest(http.MethodPut, "/api/tasks/1", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+
+				taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, OwnerID: 2}, nil)
+				taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(dao.ErrPermissionDenied)
+			})
+
+			It("should get status code 403", func() {
+				Expect(rsp.Code).To(Equal(http.StatusForbidden))
+			})
+		})
+	})
+
+})
+
+var _ = Describe("Tenant isolation", func() {
+	var (
+		handler http.Handler
+		users   map[string]dao.User
+		task    Task
+	)
+
+	// serve makes the request on behalf of a user of the tenant.
+	serve := func(method, url string, body interface{}, tenant string) *httptest.ResponseRecorder {
+		var reader io.Reader
+		if body != nil {
+			requestByte, err := json.Marshal(body)
+			Expect(err).NotTo(HaveOccurred())
+			reader = bytes.NewReader(requestByte)
+		}
+		req, err := http.NewRequest(method, url, reader)
+		Expect(err).NotTo(HaveOccurred())
+		user := users[tenant]
+		authorizeIn(req, user.ID, user.Username, user.Role, user.Tenant)
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		cache := dao.NewGoCache()
+		cache.SetDefault("cacheKeyNextTaskID", int64(0))
+		revisionDAO := dao.NewGoCacheRevisionDAO(logger, cache)
+		commentDAO := dao.NewGoCacheCommentDAO(logger, cache)
+		attachmentDAO := dao.NewGoCacheAttachmentDAO(logger, cache)
+		timeEntryDAO := dao.NewGoCacheTimeEntryDAO(logger, cache)
+		taskDAO := dao.NewRevisionTaskDAO(logger, dao.NewCascadeTaskDAO(logger, dao.NewGoCacheTaskDAO(logger, cache),
+			dao.Dependent[dao.RevisionDAO](revisionDAO),
+			dao.Dependent[dao.CommentDAO](commentDAO),
+			dao.Dependent[dao.AttachmentDAO](attachmentDAO),
+			dao.Dependent[dao.TimeEntryDAO](timeEntryDAO),
+		), revisionDAO)
+		config := Config{
+			DescriptionMaxBytes: 16,
+			AttachmentMaxBytes:  16,
+			JWTSecret:           testJWTSecret,
+			JWTTTL:              time.Hour,
+		}
+		userDAO := dao.NewGoCacheUserDAO(logger, cache)
+		handler = NewHttpServer(logger, "", config, Deps{
+			TaskDAO:          taskDAO,
+			RevisionDAO:      revisionDAO,
+			UndoLogDAO:       dao.NewGoCacheUndoLogDAO(logger, cache, 10),
+			CommentDAO:       commentDAO,
+			AttachmentDAO:    attachmentDAO,
+			TimeEntryDAO:     timeEntryDAO,
+			ProjectDAO:       dao.NewGoCacheProjectDAO(logger, cache),
+			BoardDAO:         dao.NewGoCacheBoardDAO(logger, cache),
+			TemplateDAO:      dao.NewGoCacheTemplateDAO(logger, cache),
+			UserDAO:          userDAO,
+			APIKeyDAO:        dao.NewGoCacheAPIKeyDAO(logger, cache),
+			ProjectMemberDAO: dao.NewGoCacheProjectMemberDAO(logger, cache),
+			TaskShareDAO:     dao.NewGoCacheTaskShareDAO(logger, cache),
+		}).Handler
+
+		users = make(map[string]dao.User)
+		for _, tenant := range []string{"acme", "globex"} {
+			user, err := userDAO.Create(dao.User{Username: tenant + "-user", Role: dao.RoleEditor, Tenant: tenant})
+			Expect(err).NotTo(HaveOccurred())
+			users[tenant] = user
+		}
+
+		rsp := serve(http.MethodPost, "/api/tasks", CreateTaskRequest{Name: "acme secret"}, "acme")
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var createRsp CreateTaskResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &createRsp)).To(Succeed())
+		task = createRsp.Result
+
+		rsp = serve(http.MethodPost, fmt.Sprintf("/api/tasks/%d/comments", task.ID), CreateCommentRequest{Body: "acme secret"}, "acme")
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+	})
+
+	It("should give each tenant its own ID sequence", func() {
+		rsp := serve(http.MethodPost, "/api/tasks", CreateTaskRequest{Name: "globex task"}, "globex")
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+
+		var createRsp CreateTaskResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &createRsp)).To(Succeed())
+		Expect(createRsp.Result.ID).To(Equal(task.ID))
+	})
+
+	It("should never list the tasks of another tenant", func() {
+		rsp := serve(http.MethodGet, "/api/tasks", nil, "globex")
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+
+		var listRsp ListTasksResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &listRsp)).To(Succeed())
+		Expect(listRsp.Result).To(BeEmpty())
+	})
+
+	It("should never read or write the task of another tenant", func() {
+		url := fmt.Sprintf("/api/tasks/%d", task.ID)
+		Expect(serve(http.MethodGet, url, nil, "globex").Code).To(Equal(http.StatusNotFound))
+		Expect(serve(http.MethodPut, url, UpdateTaskRequest{Name: "stolen"}, "globex").Code).To(Equal(http.StatusNotFound))
+		// deleting is idempotent, the task of acme stays
+		Expect(serve(http.MethodDelete, url, nil, "globex").Code).To(Equal(http.StatusNoContent))
+
+		rsp := serve(http.MethodGet, url, nil, "acme")
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		var getRsp GetTaskResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &getRsp)).To(Succeed())
+		Expect(getRsp.Result.Name).To(Equal("acme secret"))
+	})
+
+	It("should never read the comments on the task of another tenant with the same ID", func() {
+		rsp := serve(http.MethodPost, "/api/tasks", CreateTaskRequest{Name: "globex task"}, "globex")
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+
+		rsp = serve(http.MethodGet, fmt.Sprintf("/api/tasks/%d/comments", task.ID), nil, "globex")
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		var listRsp ListCommentsResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &listRsp)).To(Succeed())
+		Expect(listRsp.Result).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Task ownership", func() {
+	var (
+		handler           http.Handler
+		boardDAO          dao.BoardDAO
+		userDAO           dao.UserDAO
+		blobDir           string
+		alice, bob, carol dao.User
+		task              Task
+	)
+
+	// serveAs makes the request on behalf of the user.
+	serveAs := func(req *http.Request, user dao.User) *httptest.ResponseRecorder {
+		authorizeAs(req, user.ID, user.Username, user.Role)
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	serve := func(method, url string, body interface{}, user dao.User) *httptest.ResponseRecorder {
+		var reader io.Reader
+		if body != nil {
+			requestByte, err := json.Marshal(body)
+			Expect(err).NotTo(HaveOccurred())
+			reader = bytes.NewReader(requestByte)
+		}
+		req, err := http.NewRequest(method, url, reader)
+		Expect(err).NotTo(HaveOccurred())
+		return serveAs(req, user)
+	}
+
+	upload := func(user dao.User) *httptest.ResponseRecorder {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", "notes.txt")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = part.Write([]byte("notes"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/tasks/%d/attachments", task.ID), body)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		return serveAs(req, user)
+	}
+
+	BeforeEach(func() {
+		var err error
+		blobDir, err = os.MkdirTemp("", "blobs")
+		Expect(err).NotTo(HaveOccurred())
+		logger := zap.NewNop().Sugar()
+		blobStore, err := dao.NewLocalBlobStore(logger, blobDir)
+		Expect(err).NotTo(HaveOccurred())
+		cache := dao.NewGoCache()
+		cache.SetDefault("cacheKeyNextTaskID", int64(0))
+		revisionDAO := dao.NewGoCacheRevisionDAO(logger, cache)
+		taskShareDAO := dao.NewGoCacheTaskShareDAO(logger, cache)
+		userDAO = dao.NewGoCacheUserDAO(logger, cache)
+		boardDAO = dao.NewGoCacheBoardDAO(logger, cache)
+		taskDAO := dao.NewOwnerTaskDAO(logger, dao.NewWIPLimitTaskDAO(logger, dao.NewRevisionTaskDAO(logger, dao.NewGoCacheTaskDAO(logger, cache), revisionDAO), boardDAO), taskShareDAO)
+		config := Config{
+			DescriptionMaxBytes: 16,
+			AttachmentMaxBytes:  16,
+			JWTSecret:           testJWTSecret,
+			JWTTTL:              time.Hour,
+		}
+		attachmentDAO := dao.NewBlobAttachmentDAO(logger, dao.NewGoCacheAttachmentDAO(logger, cache), blobStore)
+		handler = NewHttpServer(logger, "", config, Deps{
+			TaskDAO:          taskDAO,
+			RevisionDAO:      revisionDAO,
+			UndoLogDAO:       dao.NewGoCacheUndoLogDAO(logger, cache, 10),
+			CommentDAO:       dao.NewGoCacheCommentDAO(logger, cache),
+			AttachmentDAO:    attachmentDAO,
+			BlobStore:        blobStore,
+			TimeEntryDAO:     dao.NewGoCacheTimeEntryDAO(logger, cache),
+			ProjectDAO:       dao.NewGoCacheProjectDAO(logger, cache),
+			BoardDAO:         boardDAO,
+			TemplateDAO:      dao.NewGoCacheTemplateDAO(logger, cache),
+			UserDAO:          userDAO,
+			APIKeyDAO:        dao.NewGoCacheAPIKeyDAO(logger, cache),
+			ProjectMemberDAO: dao.NewGoCacheProjectMemberDAO(logger, cache),
+			TaskShareDAO:     taskShareDAO,
+		}).Handler
+
+		alice, err = userDAO.Create(dao.User{Username: "alice", Role: dao.RoleEditor})
+		Expect(err).NotTo(HaveOccurred())
+		bob, err = userDAO.Create(dao.User{Username: "bob", Role: dao.RoleEditor})
+		Expect(err).NotTo(HaveOccurred())
+		carol, err = userDAO.Create(dao.User{Username: "carol", Role: dao.RoleAdmin})
+		Expect(err).NotTo(HaveOccurred())
+
+		rsp := serve(http.MethodPost, "/api/tasks", CreateTaskRequest{Name: "alice's task"}, alice)
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var createRsp CreateTaskResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &createRsp)).To(Succeed())
+		task = createRsp.Result
+		Expect(task.OwnerID).To(Equal(alice.ID))
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(blobDir)).To(Succeed())
+	})
+
+	It("should hide the task from the others", func() {
+		url := fmt.Sprintf("/api/tasks/%d", task.ID)
+		Expect(serve(http.MethodGet, url, nil, bob).Code).To(Equal(http.StatusNotFound))
+		Expect(serve(http.MethodPut, url, UpdateTaskRequest{Name: "stolen"}, bob).Code).To(Equal(http.StatusNotFound))
+
+		rsp := serve(http.MethodGet, "/api/tasks", nil, bob)
+		var listRsp ListTasksResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &listRsp)).To(Succeed())
+		Expect(listRsp.Result).To(BeEmpty())
+	})
+
+	It("should show the task to the admins", func() {
+		url := fmt.Sprintf("/api/tasks/%d", task.ID)
+		Expect(serve(http.MethodGet, url, nil, carol).Code).To(Equal(http.StatusOK))
+	})
+
+	It("should take the admin rights of a demoted admin at once", func() {
+		url := fmt.Sprintf("/api/tasks/%d", task.ID)
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		Expect(err).NotTo(HaveOccurred())
+		authorizeAs(req, carol.ID, carol.Username, carol.Role)
+
+		carol.Role = dao.RoleEditor
+		Expect(userDAO.Update(&carol)).To(Succeed())
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		Expect(rsp.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should let the owner share the task", func() {
+		url := fmt.Sprintf("/api/tasks/%d", task.ID)
+		sharesURL := fmt.Sprintf("/api/tasks/%d/shares/%d", task.ID, bob.ID)
+		Expect(serve(http.MethodPut, sharesURL, SetTaskShareRequest{Access: "read"}, bob).Code).To(Equal(http.StatusNotFound))
+		Expect(serve(http.MethodPut, sharesURL, SetTaskShareRequest{Access: "read"}, alice).Code).To(Equal(http.StatusOK))
+
+		Expect(serve(http.MethodGet, url, nil, bob).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPut, url, UpdateTaskRequest{Name: "renamed"}, bob).Code).To(Equal(http.StatusForbidden))
+
+		Expect(serve(http.MethodPut, sharesURL, SetTaskShareRequest{Access: "write"}, alice).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPut, url, UpdateTaskRequest{Name: "renamed"}, bob).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodDelete, url, nil, bob).Code).To(Equal(http.StatusForbidden))
+
+		Expect(serve(http.MethodDelete, sharesURL, nil, alice).Code).To(Equal(http.StatusNoContent))
+		Expect(serve(http.MethodGet, url, nil, bob).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should keep the comments and the attachments of the task shared read-only", func() {
+		commentsURL := fmt.Sprintf("/api/tasks/%d/comments", task.ID)
+		rsp := serve(http.MethodPost, commentsURL, CreateCommentRequest{Body: "by alice"}, alice)
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var commentRsp CreateCommentResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &commentRsp)).To(Succeed())
+		commentURL := fmt.Sprintf("%s/%d", commentsURL, commentRsp.Result.ID)
+
+		rsp = upload(alice)
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var attachmentRsp CreateAttachmentResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &attachmentRsp)).To(Succeed())
+		attachmentURL := fmt.Sprintf("/api/tasks/%d/attachments/%d", task.ID, attachmentRsp.Result.ID)
+
+		sharesURL := fmt.Sprintf("/api/tasks/%d/shares/%d", task.ID, bob.ID)
+		Expect(serve(http.MethodPut, sharesURL, SetTaskShareRequest{Access: "read"}, alice).Code).To(Equal(http.StatusOK))
+
+		Expect(serve(http.MethodGet, commentsURL, nil, bob).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodPost, commentsURL, CreateCommentRequest{Body: "by bob"}, bob).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodPut, commentURL, UpdateCommentRequest{Body: "edited by bob"}, bob).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodDelete, commentURL, nil, bob).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodGet, attachmentURL, nil, bob).Code).To(Equal(http.StatusOK))
+		Expect(upload(bob).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodDelete, attachmentURL, nil, bob).Code).To(Equal(http.StatusForbidden))
+
+		Expect(serve(http.MethodGet, commentsURL, nil, alice).Body.String()).To(ContainSubstring("by alice"))
+		Expect(serve(http.MethodGet, attachmentURL, nil, alice).Code).To(Equal(http.StatusOK))
+	})
+
+	It("should let only the author and the admins change the comment", func() {
+		commentsURL := fmt.Sprintf("/api/tasks/%d/comments", task.ID)
+		rsp := serve(http.MethodPost, commentsURL, CreateCommentRequest{Body: "by alice"}, alice)
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var commentRsp CreateCommentResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &commentRsp)).To(Succeed())
+		commentURL := fmt.Sprintf("%s/%d", commentsURL, commentRsp.Result.ID)
+
+		sharesURL := fmt.Sprintf("/api/tasks/%d/shares/%d", task.ID, bob.ID)
+		Expect(serve(http.MethodPut, sharesURL, SetTaskShareRequest{Access: "write"}, alice).Code).To(Equal(http.StatusOK))
+
+		Expect(serve(http.MethodPost, commentsURL, CreateCommentRequest{Body: "by bob"}, bob).Code).To(Equal(http.StatusCreated))
+		Expect(serve(http.MethodPut, commentURL, UpdateCommentRequest{Body: "edited by bob"}, bob).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodDelete, commentURL, nil, bob).Code).To(Equal(http.StatusForbidden))
+
+		Expect(serve(http.MethodPut, commentURL, UpdateCommentRequest{Body: "edited by alice"}, alice).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodDelete, commentURL, nil, carol).Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should count the cards of all owners against the WIP limit", func() {
+		_, err := boardDAO.Create(dao.Board{
+			Name:    "Done",
+			GroupBy: dao.BoardGroupByStatus,
+			Columns: []dao.BoardColumn{{Key: "incomplete"}, {Key: "complete", WIPLimit: 1}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		url := fmt.Sprintf("/api/tasks/%d", task.ID)
+		complete := UpdateTaskRequest{Name: task.Name, Status: int(TaskStatusComplete)}
+		Expect(serve(http.MethodPut, url, complete, alice).Code).To(Equal(http.StatusOK))
+
+		rsp := serve(http.MethodPost, "/api/tasks", CreateTaskRequest{Name: "bob's task"}, bob)
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var createRsp CreateTaskResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &createRsp)).To(Succeed())
+
+		url = fmt.Sprintf("/api/tasks/%d", createRsp.Result.ID)
+		rsp = serve(http.MethodPut, url, UpdateTaskRequest{Name: "bob's task", Status: int(TaskStatusComplete)}, bob)
+		Expect(rsp.Code).To(Equal(http.StatusConflict))
+		Expect(rsp.Body.String()).To(ContainSubstring("column complete of board Done is full"))
+	})
+
+	It("should hide the history of the task from the others", func() {
+		historyURL := fmt.Sprintf("/api/tasks/%d/history", task.ID)
+		rsp := serve(http.MethodGet, historyURL, nil, bob)
+		Expect(rsp.Code).To(Equal(http.StatusNotFound))
+		Expect(rsp.Body.String()).NotTo(ContainSubstring("alice's task"))
+
+		revertURL := fmt.Sprintf("/api/tasks/%d/revert?rev=1", task.ID)
+		Expect(serve(http.MethodPost, revertURL, nil, bob).Code).To(Equal(http.StatusNotFound))
+
+		Expect(serve(http.MethodGet, historyURL, nil, alice).Code).To(Equal(http.StatusOK))
+	})
+
+	It("should let only the user and the admins change the time entry", func() {
+		sharesURL := fmt.Sprintf("/api/tasks/%d/shares/%d", task.ID, bob.ID)
+		Expect(serve(http.MethodPut, sharesURL, SetTaskShareRequest{Access: "write"}, alice).Code).To(Equal(http.StatusOK))
+
+		startedAt := time.Date(2022, 10, 1, 8, 0, 0, 0, time.UTC)
+		request := CreateTimeEntryRequest{StartedAt: startedAt, StoppedAt: startedAt.Add(time.Hour)}
+		rsp := serve(http.MethodPost, fmt.Sprintf("/api/tasks/%d/time-entries", task.ID), request, alice)
+		Expect(rsp.Code).To(Equal(http.StatusCreated))
+		var createRsp CreateTimeEntryResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &createRsp)).To(Succeed())
+		entryURL := fmt.Sprintf("/api/tasks/%d/time-entries/%d", task.ID, createRsp.Result.ID)
+
+		longer := UpdateTimeEntryRequest{StartedAt: startedAt, StoppedAt: startedAt.Add(8 * time.Hour)}
+		Expect(serve(http.MethodPut, entryURL, longer, bob).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodDelete, entryURL, nil, bob).Code).To(Equal(http.StatusForbidden))
+
+		Expect(serve(http.MethodPut, entryURL, longer, alice).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodDelete, entryURL, nil, carol).Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should report the time on the tasks visible only", func() {
+		timerURL := fmt.Sprintf("/api/tasks/%d/timer/start", task.ID)
+		Expect(serve(http.MethodPost, timerURL, nil, alice).Code).To(Equal(http.StatusCreated))
+
+		reportURL := "/api/time-report?from=" + time.Now().UTC().Format("2006-01-02") + "&to=" + time.Now().UTC().Format("2006-01-02")
+		var reportRsp TimeReportResponse
+		rsp := serve(http.MethodGet, reportURL, nil, bob)
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &reportRsp)).To(Succeed())
+		Expect(reportRsp.Result.ByTask).To(BeEmpty())
+
+		rsp = serve(http.MethodGet, reportURL, nil, alice)
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &reportRsp)).To(Succeed())
+		Expect(reportRsp.Result.ByTask).To(HaveLen(1))
+		Expect(reportRsp.Result.ByTask[0].TaskID).To(Equal(task.ID))
+	})
+})
+
+var _ = Describe("rateLimiter", func() {
+	var (
+		limiter *rateLimiter
+		now     time.Time
+	)
+
+	BeforeEach(func() {
+		limiter = newRateLimiter(RateLimit{Rate: 2, Burst: 3})
+		now = time.Now()
+	})
+
+	It("should not limit if the rate is 0", func() {
+		Expect(newRateLimiter(RateLimit{Burst: 3})).To(BeNil())
+	})
+
+	It("should allow the burst and then refill by the rate", func() {
+		for i := 2; i >= 0; i-- {
+			result := limiter.take("alice", now)
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.Remaining).To(Equal(i))
+		}
+
+		result := limiter.take("alice", now)
+		Expect(result.Allowed).To(BeFalse())
+		Expect(result.RetryAfter).To(Equal(500 * time.Millisecond))
+		Expect(result.Reset).To(Equal(1500 * time.Millisecond))
+
+		Expect(limiter.take("alice", now.Add(500*time.Millisecond)).Allowed).To(BeTrue())
+	})
+
+	It("should keep a bucket for each client", func() {
+		for i := 0; i < 3; i++ {
+			limiter.take("alice", now)
+		}
+		Expect(limiter.take("alice", now).Allowed).To(BeFalse())
+		Expect(limiter.take("bob", now).Allowed).To(BeTrue())
+	})
+
+	It("should drop the buckets refilled", func() {
+		limiter.take("alice", now)
+		limiter.take("bob", now.Add(time.Minute))
+		Expect(limiter.buckets).To(HaveLen(1))
+		Expect(limiter.buckets).To(HaveKey("bob"))
+	})
+})
+
+var _ = Describe("RateLimitMiddleware", func() {
+	var (
+		handler http.Handler
+	)
+
+	serve := func(method, authorization string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, "/api/tasks", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.RemoteAddr = "192.0.2.1:1234"
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		apiKeyDAO := daomock.NewMockAPIKeyDAO(ctrl)
+		allowScoping(apiKeyDAO, apiKeyDAO.EXPECT())
+		for _, name := range []string{"alice", "bob"} {
+			apiKeyDAO.EXPECT().GetByHash(dao.HashAPIKey(dao.APIKeyPrefix+name)).Return(dao.APIKey{Name: name}, nil).AnyTimes()
+		}
+		apiKeyDAO.EXPECT().GetByHash(gomock.Any()).Return(dao.APIKey{}, dao.ErrResourceNotFound).AnyTimes()
+		// the users of the keys are gone, so the keys fail the auth
+		userDAO := daomock.NewMockUserDAO(ctrl)
+		allowScoping(userDAO, userDAO.EXPECT())
+		userDAO.EXPECT().GetByID(gomock.Any()).Return(dao.User{}, dao.ErrResourceNotFound).AnyTimes()
+		config := Config{
+			JWTSecret:      testJWTSecret,
+			ReadRateLimit:  RateLimit{Rate: 0.001, Burst: 2},
+			WriteRateLimit: RateLimit{Rate: 0.001, Burst: 1},
+		}
+		// the requests are rejected before reaching the other DAOs
+		handler = NewHttpServer(zap.NewNop().Sugar(), "", config, Deps{
+			UserDAO:   userDAO,
+			APIKeyDAO: apiKeyDAO,
+		}).Handler
+	})
+
+	It("should limit the writes apart from the reads", func() {
+		rsp := serve(http.MethodPost, "")
+		Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rsp.Header().Get("RateLimit-Limit")).To(Equal("1"))
+		Expect(rsp.Header().Get("RateLimit-Remaining")).To(Equal("0"))
+
+		rsp = serve(http.MethodPost, "")
+		Expect(rsp.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rsp.Header().Get("Retry-After")).To(Equal("1000"))
+		var errRsp ErrorResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &errRsp)).To(Succeed())
+		Expect(errRsp.Message).To(Equal("too many requests"))
+
+		rsp = serve(http.MethodGet, "")
+		Expect(rsp.Code).To(Equal(http.StatusUnauthorized))
+		Expect(rsp.Header().Get("RateLimit-Limit")).To(Equal("2"))
+		Expect(rsp.Header().Get("RateLimit-Remaining")).To(Equal("1"))
+	})
+
+	It("should count the API keys apart from the IP", func() {
+		Expect(serve(http.MethodPost, "").Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(http.MethodPost, "Bearer "+dao.APIKeyPrefix+"alice").Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(http.MethodPost, "Bearer "+dao.APIKeyPrefix+"alice").Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(http.MethodPost, "Bearer "+dao.APIKeyPrefix+"bob").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should count the unknown API keys by the IP", func() {
+		Expect(serve(http.MethodPost, "Bearer "+dao.APIKeyPrefix+gofakeit.UUID()).Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(http.MethodPost, "Bearer "+dao.APIKeyPrefix+gofakeit.UUID()).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(http.MethodPost, "").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("should not trust the X-Forwarded-For of the clients", func() {
+		Expect(serve(http.MethodPost, "").Code).To(Equal(http.StatusUnauthorized))
+
+		req, err := http.NewRequest(http.MethodPost, "/api/tasks", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Forwarded-For", "198.51.100.7")
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		Expect(rsp.Code).To(Equal(http.StatusTooManyRequests))
+	})
+})
+
+var _ = Describe("MetricsMiddleware", func() {
+	var (
+		ctrl    *gomock.Controller
+		taskDAO *daomock.MockTaskDAO
+		handler http.Handler
+	)
+
+	serve := func(method, url string, authorize func(req *http.Request)) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, nil)
+		Expect(err).NotTo(HaveOccurred())
+		if authorize != nil {
+			authorize(req)
+		}
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	asOperator := func(req *http.Request) {
+		authorizeAs(req, 1, "alice", dao.RoleAdmin)
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		taskDAO = daomock.NewMockTaskDAO(ctrl)
+		allowScoping(taskDAO, taskDAO.EXPECT())
+		config := Config{
+			JWTSecret:    testJWTSecret,
+			Registry:     prometheus.NewRegistry(),
+			ServeMetrics: true,
+		}
+		handler = NewHttpServer(zap.NewNop().Sugar(), "", config, Deps{
+			TaskDAO: taskDAO,
+			UserDAO: signedInUserDAO{},
+		}).Handler
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should count the requests by route and status", func() {
+		Expect(serve(http.MethodGet, "/api/tasks/1", nil).Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(http.MethodGet, "/api/tasks/2", nil).Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(http.MethodGet, "/unknown", nil).Code).To(Equal(http.StatusNotFound))
+
+		rsp := serve(http.MethodGet, "/metrics", asOperator)
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		Expect(rsp.Body.String()).To(ContainSubstring(`gogo_http_requests_total{method="GET",route="/api/tasks/:id",status="401"} 2`))
+		Expect(rsp.Body.String()).To(ContainSubstring(`gogo_http_requests_total{method="GET",route="unmatched",status="404"} 1`))
+		Expect(rsp.Body.String()).To(ContainSubstring(`gogo_http_request_duration_seconds_count{method="GET",route="/api/tasks/:id",status="401"} 2`))
+	})
+
+	It("should count the panics as 500", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).DoAndReturn(func(_ context.Context, id int) (dao.Task, error) {
+			panic("boom")
+		})
+		Expect(serve(http.MethodGet, "/api/tasks/1", asOperator).Code).To(Equal(http.StatusInternalServerError))
+
+		rsp := serve(http.MethodGet, "/metrics", asOperator)
+		Expect(rsp.Body.String()).To(ContainSubstring(`gogo_http_requests_total{method="GET",route="/api/tasks/:id",status="500"} 1`))
+	})
+
+	It("should serve the metrics to the admins of the default tenant only", func() {
+		Expect(serve(http.MethodGet, "/metrics", nil).Code).To(Equal(http.StatusUnauthorized))
+		Expect(serve(http.MethodGet, "/metrics", func(req *http.Request) {
+			authorizeAs(req, 2, "bob", dao.RoleEditor)
+		}).Code).To(Equal(http.StatusForbidden))
+		Expect(serve(http.MethodGet, "/metrics", func(req *http.Request) {
+			authorizeIn(req, 3, "carol", dao.RoleAdmin, "acme")
+		}).Code).To(Equal(http.StatusForbidden))
+	})
+})
+
+var _ = Describe("Health", func() {
+	var (
+		health  *Health
+		handler http.Handler
+	)
+
+	serve := func(url string) (*httptest.ResponseRecorder, HealthResponse) {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		var healthRsp HealthResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &healthRsp)).To(Succeed())
+		return rsp, healthRsp
+	}
+
+	BeforeEach(func() {
+		logger := zap.NewNop().Sugar()
+		health = NewHealth(map[string]dao.Pinger{
+			"tasks": dao.NewGoCacheTaskDAO(logger, dao.NewGoCache()),
+		})
+		config := Config{
+			JWTSecret: testJWTSecret,
+			Health:    health,
+		}
+		handler = NewHttpServer(logger, "", config, Deps{}).Handler
+	})
+
+	It("should be alive", func() {
+		rsp, healthRsp := serve("/healthz")
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		Expect(healthRsp.Status).To(Equal("ok"))
+	})
+
+	It("should not be ready until set ready", func() {
+		health.SetReady(true)
+		rsp, healthRsp := serve("/readyz")
+		Expect(rsp.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(healthRsp).To(Equal(HealthResponse{
+			Status: "not ready",
+			Checks: map[string]string{"tasks": dao.ErrNotLoaded.Error()},
+		}))
+	})
+
+	It("should be ready once set ready and the checks pass", func() {
+		logger := zap.NewNop().Sugar()
+		taskDAO := dao.NewGoCacheTaskDAO(logger, dao.NewGoCache())
+		Expect(taskDAO.Load(filepath.Join(os.TempDir(), "not-exist.gocache"))).To(Succeed())
+		health.checks["tasks"] = taskDAO
+
+		rsp, _ := serve("/readyz")
+		Expect(rsp.Code).To(Equal(http.StatusServiceUnavailable))
+
+		health.SetReady(true)
+		rsp, healthRsp := serve("/readyz")
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		Expect(healthRsp).To(Equal(HealthResponse{
+			Status: "ready",
+			Checks: map[string]string{"tasks": "ok"},
+		}))
+
+		health.SetReady(false)
+		rsp, _ = serve("/readyz")
+		Expect(rsp.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+})
+
+var _ = Describe("AccessLogMiddleware", func() {
+	var (
+		ctrl    *gomock.Controller
+		taskDAO *daomock.MockTaskDAO
+		logs    *observer.ObservedLogs
+		handler http.Handler
+	)
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Request-ID", "req-1")
+		authorize(req, testUsername)
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		taskDAO = daomock.NewMockTaskDAO(ctrl)
+		allowScoping(taskDAO, taskDAO.EXPECT())
+		var core zapcore.Core
+		core, logs = observer.New(zap.InfoLevel)
+		config := Config{
+			JWTSecret: testJWTSecret,
+		}
+		handler = NewHttpServer(zap.New(core).Sugar(), "", config, Deps{
+			TaskDAO: taskDAO,
+			UserDAO: signedInUserDAO{},
+		}).Handler
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should log the request", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{}, dao.ErrResourceNotFound)
+		rsp := serve(http.MethodGet, "/api/tasks/1")
+		Expect(rsp.Code).To(Equal(http.StatusNotFound))
+
+		entries := logs.FilterMessage("request served").All()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Level).To(Equal(zap.InfoLevel))
+		fields := entries[0].ContextMap()
+		Expect(fields["request_id"]).To(Equal("req-1"))
+		Expect(fields["method"]).To(Equal(http.MethodGet))
+		Expect(fields["route"]).To(Equal("/api/tasks/:id"))
+		Expect(fields["status"]).To(BeEquivalentTo(http.StatusNotFound))
+		Expect(fields["bytes"]).To(BeEquivalentTo(rsp.Body.Len()))
+		Expect(fields["user"]).To(Equal(testUsername))
+		Expect(fields).To(HaveKey("latency"))
+	})
+
+	It("should recover from the panic with 500", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).DoAndReturn(func(_ context.Context, id int) (dao.Task, error) {
+			panic("boom")
+		})
+		rsp := serve(http.MethodGet, "/api/tasks/1")
+		Expect(rsp.Code).To(Equal(http.StatusInternalServerError))
+
+		var errRsp ErrorResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &errRsp)).To(Succeed())
+		Expect(errRsp.Message).To(Equal("something went wrong"))
+
+		Expect(logs.FilterMessage("panic recovered").All()).To(HaveLen(1))
+		entries := logs.FilterMessage("request served").All()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Level).To(Equal(zap.ErrorLevel))
+	})
+})
+
+var _ = Describe("RequestIDMiddleware", func() {
+	var (
+		ctrl    *gomock.Controller
+		taskDAO *daomock.MockTaskDAO
+		logger  *zap.SugaredLogger
+		logs    *observer.ObservedLogs
+		handler http.Handler
+	)
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		authorize(req, testUsername)
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		taskDAO = daomock.NewMockTaskDAO(ctrl)
+		allowScoping(taskDAO, taskDAO.EXPECT())
+		var core zapcore.Core
+		core, logs = observer.New(zap.InfoLevel)
+		logger = zap.New(core).Sugar()
+		config := Config{
+			JWTSecret: testJWTSecret,
+		}
+		handler = NewHttpServer(logger, "", config, Deps{
+			TaskDAO: taskDAO,
+			UserDAO: signedInUserDAO{},
+		}).Handler
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should echo the request ID", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/tasks/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Request-ID", "req-1")
+
+		rsp := serve(req)
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		Expect(rsp.Header().Get("X-Request-ID")).To(Equal("req-1"))
+	})
+
+	It("should generate the request ID if it is missing", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/tasks/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		rsp := serve(req)
+		Expect(rsp.Header().Get("X-Request-ID")).To(MatchRegexp("^[0-9a-f]{32}$"))
+	})
+
+	It("should replace the invalid request ID", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+		req, err := http.NewRequest(http.MethodGet, "/api/tasks/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Request-ID", "forged request_id=1")
+
+		rsp := serve(req)
+		Expect(rsp.Header().Get("X-Request-ID")).To(MatchRegexp("^[0-9a-f]{32}$"))
+	})
+
+	It("should include the request ID in the error", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{}, dao.ErrResourceNotFound)
+		req, err := http.NewRequest(http.MethodGet, "/api/tasks/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("X-Request-ID", "req-1")
+
+		rsp := serve(req)
+		Expect(rsp.Code).To(Equal(http.StatusNotFound))
+		var errRsp ErrorResponse
+		Expect(json.Unmarshal(rsp.Body.Bytes(), &errRsp)).To(Succeed())
+		Expect(errRsp.RequestID).To(Equal("req-1"))
+	})
+
+	It("should log the request ID in the DAO", func() {
+		cache := dao.NewGoCache()
+		// the ID sequence is corrupted, so creating a task fails in the DAO
+		cache.Set("cacheKeyNextTaskID", "corrupted", 0)
+		handler = NewHttpServer(logger, "", Config{JWTSecret: testJWTSecret}, Deps{
+			TaskDAO: dao.NewGoCacheTaskDAO(logger, cache),
+			UserDAO: signedInUserDAO{},
+		}).Handler
+
+		requestByte, err := json.Marshal(CreateTaskRequest{Name: "task"})
+		Expect(err).NotTo(HaveOccurred())
+		req, err := http.NewRequest(http.MethodPost, "/api/tasks", bytes.NewReader(requestByte))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "req-1")
+
+		rsp := serve(req)
+		Expect(rsp.Code).To(Equal(http.StatusInternalServerError))
+		entries := logs.FilterMessageSnippet("gocache.IncrementInt64 failed").All()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ContextMap()["request_id"]).To(Equal("req-1"))
+	})
+})
+
+var _ = Describe("TracingMiddleware", func() {
+	const (
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID    = "00f067aa0ba902b7"
+		traceparent = "00-" + traceID + "-" + parentID + "-01"
+	)
+
+	var (
+		ctrl     *gomock.Controller
+		taskDAO  *daomock.MockTaskDAO
+		recorder *tracetest.SpanRecorder
+		logs     *observer.ObservedLogs
+		handler  http.Handler
+	)
+
+	serve := func(method, url string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(method, url, nil)
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set("traceparent", traceparent)
+		authorize(req, testUsername)
+
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		taskDAO = daomock.NewMockTaskDAO(ctrl)
+		allowScoping(taskDAO, taskDAO.EXPECT())
+		recorder = tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		var core zapcore.Core
+		core, logs = observer.New(zap.InfoLevel)
+		config := Config{
+			JWTSecret:      testJWTSecret,
+			TracerProvider: provider,
+		}
+		handler = NewHttpServer(zap.New(core).Sugar(), "", config, Deps{
+			TaskDAO: dao.NewTracingTaskDAO(taskDAO, provider),
+			UserDAO: signedInUserDAO{},
+		}).Handler
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should continue the trace of the traceparent", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+		rsp := serve(http.MethodGet, "/api/tasks/1")
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		daoSpan, requestSpan := spans[0], spans[1]
+		Expect(requestSpan.Name()).To(Equal("GET /api/tasks/:id"))
+		Expect(requestSpan.SpanContext().TraceID().String()).To(Equal(traceID))
+		Expect(requestSpan.Parent().SpanID().String()).To(Equal(parentID))
+		Expect(requestSpan.Attributes()).To(ContainElement(attribute.Int("http.status_code", http.StatusOK)))
+		Expect(daoSpan.Name()).To(Equal("TaskDAO.GetByID"))
+		Expect(daoSpan.Parent().SpanID()).To(Equal(requestSpan.SpanContext().SpanID()))
+	})
+
+	It("should log the trace ID", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+		serve(http.MethodGet, "/api/tasks/1")
+
+		entries := logs.FilterMessage("request served").All()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].ContextMap()["trace_id"]).To(Equal(traceID))
+	})
+
+	It("should mark the span of the server error", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{}, errors.New("boom"))
+		rsp := serve(http.MethodGet, "/api/tasks/1")
+		Expect(rsp.Code).To(Equal(http.StatusInternalServerError))
+
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		Expect(spans[0].Status().Code).To(Equal(codes.Error))
+		Expect(spans[1].Status().Code).To(Equal(codes.Error))
+	})
+})
+
+var _ = Describe("SignUp", func() {
+	It("should create a single first user from the concurrent sign-ups", func() {
+		userDAO := dao.NewGoCacheUserDAO(zap.NewNop().Sugar(), dao.NewGoCache())
+		config := Config{JWTSecret: testJWTSecret}
+		handler := NewHttpServer(zap.NewNop().Sugar(), "", config, Deps{
+			UserDAO: userDAO,
+		}).Handler
+
+		const signUps = 5
+		codes := make(chan int, signUps)
+		for i := 0; i < signUps; i++ {
+			go func(i int) {
+				defer GinkgoRecover()
+				requestByte, err := json.Marshal(CreateUserRequest{Username: fmt.Sprintf("user%d", i), Password: "secret"})
+				Expect(err).NotTo(HaveOccurred())
+				req, err := http.NewRequest(http.MethodPost, "/api/users", bytes.NewReader(requestByte))
+				Expect(err).NotTo(HaveOccurred())
+				rsp := httptest.NewRecorder()
+				handler.ServeHTTP(rsp, req)
+				codes <- rsp.Code
+			}(i)
+		}
+
+		created := 0
+		for i := 0; i < signUps; i++ {
+			var code int
+			Eventually(codes, 10*time.Second).Should(Receive(&code))
+			if code == http.StatusCreated {
+				created++
+			} else {
+				Expect(code).To(Equal(http.StatusUnauthorized))
+			}
+		}
+		Expect(created).To(Equal(1))
+
+		users, err := userDAO.List()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(users).To(HaveLen(1))
+		Expect(users[0].Role).To(Equal(dao.RoleAdmin))
+	})
+})
+
+var _ = Describe("Webhooks", func() {
+	type delivery struct {
+		header http.Header
+		event  WebhookEvent
+	}
+
+	var (
+		ctrl       *gomock.Controller
+		taskDAO    *daomock.MockTaskDAO
+		receiver   *httptest.Server
+		deliveries chan delivery
+		handler    http.Handler
+	)
+
+	serve := func(method, path string, body interface{}) *httptest.ResponseRecorder {
+		requestByte, err := json.Marshal(body)
+		Expect(err).NotTo(HaveOccurred())
+		req, err := http.NewRequest(method, path, bytes.NewReader(requestByte))
+		Expect(err).NotTo(HaveOccurred())
+		authorizeAs(req, 3, "carol", dao.RoleAdmin)
+		rsp := httptest.NewRecorder()
+		handler.ServeHTTP(rsp, req)
+		return rsp
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		taskDAO = daomock.NewMockTaskDAO(ctrl)
+		allowScoping(taskDAO, taskDAO.EXPECT())
+		deliveries = make(chan delivery, 10)
+		receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// a malformed body leaves the event empty, which fails the checks
+			var event WebhookEvent
+			_ = json.NewDecoder(r.Body).Decode(&event)
+			deliveries <- delivery{header: r.Header, event: event}
+		}))
+		config := Config{
+			JWTSecret:      testJWTSecret,
+			Webhooks:       []string{receiver.URL},
+			WebhookTimeout: time.Second,
+		}
+		handler = NewHttpServer(zap.NewNop().Sugar(), "", config, Deps{
+			TaskDAO:    taskDAO,
+			UndoLogDAO: dao.NewGoCacheUndoLogDAO(zap.NewNop().Sugar(), dao.NewGoCache(), 10),
+			UserDAO:    signedInUserDAO{},
+		}).Handler
+	})
+
+	AfterEach(func() {
+		receiver.Close()
+		ctrl.Finish()
+	})
+
+	It("should post the task assigned with its assignee", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, WatcherIDs: []int{2}}, nil)
+		taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rsp := serve(http.MethodPost, "/api/tasks/1/assign", AssignTaskRequest{Assignee: "me"})
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+
+		var d delivery
+		Eventually(deliveries).Should(Receive(&d))
+		Expect(d.header.Get("X-Gogo-Event")).To(Equal("task.assigned"))
+		Expect(d.event.Type).To(Equal("task.assigned"))
+		Expect(d.event.Actor).To(Equal("carol"))
+		Expect(d.event.Task.AssigneeID).To(Equal(3))
+		Expect(d.event.Task.WatcherIDs).To(Equal([]int{2}))
+	})
+
+	It("should carry the request ID", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1}, nil)
+		taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rsp := serve(http.MethodPost, "/api/tasks/1/assign", AssignTaskRequest{Assignee: "me"})
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+
+		var d delivery
+		Eventually(deliveries).Should(Receive(&d))
+		Expect(d.header.Get("X-Request-ID")).To(Equal(rsp.Header().Get("X-Request-ID")))
+		Expect(d.event.RequestID).To(Equal(rsp.Header().Get("X-Request-ID")))
+	})
+
+	It("should post the task completed", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, AssigneeID: 2}, nil)
+		taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rsp := serve(http.MethodPut, "/api/tasks/1", UpdateTaskRequest{Name: "done", Status: int(TaskStatusComplete)})
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+
+		var d delivery
+		Eventually(deliveries).Should(Receive(&d))
+		Expect(d.event.Type).To(Equal("task.completed"))
+		Expect(d.event.Task.AssigneeID).To(Equal(2))
+	})
+
+	It("should not post the other changes", func() {
+		taskDAO.EXPECT().GetByID(gomock.Any(), 1).Return(dao.Task{ID: 1, AssigneeID: 2}, nil)
+		taskDAO.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+		rsp := serve(http.MethodPut, "/api/tasks/1", UpdateTaskRequest{Name: "renamed"})
+		Expect(rsp.Code).To(Equal(http.StatusOK))
+		Consistently(deliveries, 200*time.Millisecond).ShouldNot(Receive())
+	})
+})
+
+var _ = DescribeTable("requiredRole",
+	func(method, path string, role dao.Role) {
+		Expect(requiredRole(method, path)).To(Equal(role))
+	},
+	Entry("list tasks", http.MethodGet, "/api/tasks", dao.RoleViewer),
+	Entry("get task", http.MethodGet, "/api/tasks/:id", dao.RoleViewer),
+	Entry("create task", http.MethodPost, "/api/tasks", dao.RoleEditor),
+	Entry("update task", http.MethodPut, "/api/tasks/:id", dao.RoleEditor),
+	Entry("delete task", http.MethodDelete, "/api/tasks/:id", dao.RoleEditor),
+	Entry("restore task", http.MethodPost, "/api/tasks/:id/restore", dao.RoleEditor),
+	Entry("purge task", http.MethodDelete, "/api/trash/:id", dao.RoleAdmin),
+	Entry("delete project", http.MethodDelete, "/api/projects/:id", dao.RoleAdmin),
+	Entry("list members", http.MethodGet, "/api/projects/:id/members", dao.RoleViewer),
+	Entry("set member", http.MethodPut, "/api/projects/:id/members/:userID", dao.RoleAdmin),
+	Entry("delete member", http.MethodDelete, "/api/projects/:id/members/:userID", dao.RoleAdmin),
+	Entry("update user role", http.MethodPut, "/api/users/:id/role", dao.RoleAdmin),
+	Entry("list shares", http.MethodGet, "/api/tasks/:id/shares", dao.RoleViewer),
+	Entry("set share", http.MethodPut, "/api/tasks/:id/shares/:userID", dao.RoleEditor),
+	Entry("create api key", http.MethodPost, "/api/api-keys", dao.RoleViewer),
+	Entry("revoke api key", http.MethodDelete, "/api/api-keys/:id", dao.RoleViewer),
+)
+
+var _ = Describe("toModelTask", func() {
+	It("should fill checklist progress", func() {
+		task := dao.Task{ID: rand.Int()}
+		task.AddChecklistItem("first")
+		task.AddChecklistItem("second")
+		task.Checklist[0].Done = true
+
+		Expect(toModelTask(task).ChecklistProgress).To(Equal("1/2"))
+	})
+
+	It("should leave checklist progress empty without checklist", func() {
+		Expect(toModelTask(dao.Task{}).ChecklistProgress).To(BeEmpty())
+	})
 })
 
 func TestServer(t *testing.T) {
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLogMiddleware logs every request once it is served, the server errors
+// at the error level and the others at the info level.
+func (s *httpServerImpl) AccessLogMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = routeUnmatched
+		}
+		fields := []interface{}{
+			"method", c.Request.Method,
+			"route", route,
+			"status", c.Writer.Status(),
+			"latency", time.Since(start),
+			"bytes", c.Writer.Size(),
+			"user", actorOf(c),
+		}
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			s.loggerOf(c).Errorw("request served", fields...)
+			return
+		}
+		s.loggerOf(c).Infow("request served", fields...)
+	}
+}
+
+// RecoveryMiddleware turns a panic in the handlers into 500, the panic is
+// logged along with the stack.
+func (s *httpServerImpl) RecoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			// the client is gone, there is no one to respond to
+			if err, ok := r.(error); ok && isBrokenConnection(err) {
+				s.loggerOf(c).Warnw("connection broken", "err", err, "route", c.FullPath())
+				c.Abort()
+				return
+			}
+
+			s.loggerOf(c).Errorw("panic recovered", "err", r, "route", c.FullPath(), "stack", string(debug.Stack()))
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			c.Abort()
+		}()
+
+		c.Next()
+	}
+}
+
+func isBrokenConnection(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
+	}
+	message := strings.ToLower(syscallErr.Error())
+	return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+}
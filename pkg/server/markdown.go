@@ -0,0 +1,49 @@
+package server
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+const (
+	renderHTML = "html"
+)
+
+var (
+	markdown   = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	htmlPolicy = bluemonday.UGCPolicy()
+)
+
+// renderMarkdown converts the Markdown text into HTML, which is sanitized
+// since the text is written by users.
+func renderMarkdown(text string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(text), &buf); err != nil {
+		return "", err
+	}
+
+	return htmlPolicy.Sanitize(buf.String()), nil
+}
+
+// renderTask fills the rendered description of the task.
+func renderTask(task *Task) error {
+	html, err := renderMarkdown(task.Description)
+	if err != nil {
+		return err
+	}
+
+	task.DescriptionHTML = html
+	return nil
+}
+
+func renderTasks(tasks []Task) error {
+	for i := range tasks {
+		if err := renderTask(&tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
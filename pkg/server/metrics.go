@@ -0,0 +1,74 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// routeUnmatched labels the requests matching no route, so the metrics
+	// are not labelled by the arbitrary paths.
+	routeUnmatched = "unmatched"
+)
+
+type httpMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+func newHTTPMetrics(registerer prometheus.Registerer) *httpMetrics {
+	labels := []string{"method", "route", "status"}
+	metrics := &httpMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gogo_http_requests_total",
+			Help: "Number of the HTTP requests served.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gogo_http_request_duration_seconds",
+			Help:    "Latency of the HTTP requests.",
+			Buckets: prometheus.DefBuckets,
+		}, labels),
+	}
+	registerer.MustRegister(metrics.requests, metrics.duration)
+	return metrics
+}
+
+// MetricsMiddleware counts the requests and their latency by the route
+// pattern, such as "/api/tasks/:id", and the status code.
+func (s *httpServerImpl) MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = routeUnmatched
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		s.metrics.requests.WithLabelValues(c.Request.Method, route, status).Inc()
+		s.metrics.duration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// NewMetricsHandler serves the metrics of the registry in the Prometheus text
+// format.
+func NewMetricsHandler(registry *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// NewAdminServer serves the metrics apart from the API, so they can be kept
+// off the public address.
+func NewAdminServer(addr string, registry *prometheus.Registry) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", NewMetricsHandler(registry))
+
+	return &http.Server{
+		Handler: mux,
+		Addr:    addr,
+	}
+}
@@ -1,14 +1,56 @@
 package server
 
+import (
+	"time"
+)
+
 type ListTasksRequest struct {
+	// Render is "html" to render the descriptions.
+	Render string `form:"render"`
+	// Sort is one of id, created_at, updated_at, completed_at, manual and
+	// field.<key> of a custom field, the order is descending if it is prefixed
+	// with "-".
+	Sort string `form:"sort"`
+	// ProjectID keeps the tasks of the project, 0 keeps the tasks without a
+	// project.
+	ProjectID *int `form:"project_id"`
+	// Assignee and Watcher keep the tasks assigned to or watched by the user
+	// of the username, "me" is the requester.
+	Assignee string `form:"assignee"`
+	Watcher  string `form:"watcher"`
+	// Fields keeps the tasks whose custom fields equal to the given values,
+	// each is in the form of "key:value".
+	Fields          []string   `form:"field"`
+	CreatedAfter    *time.Time `form:"created_after"`
+	CreatedBefore   *time.Time `form:"created_before"`
+	UpdatedAfter    *time.Time `form:"updated_after"`
+	UpdatedBefore   *time.Time `form:"updated_before"`
+	CompletedAfter  *time.Time `form:"completed_after"`
+	CompletedBefore *time.Time `form:"completed_before"`
 }
 
 type ListTasksResponse struct {
 	Result []Task `json:"result"`
 }
 
+type GetTaskRequest struct {
+	// Render is "html" to render the description.
+	Render string `form:"render"`
+}
+
+type GetTaskResponse struct {
+	Result Task `json:"result"`
+}
+
 type CreateTaskRequest struct {
-	Name string `json:"name"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	ProjectID   int                    `json:"project_id"`
+	Tags        []string               `json:"tags"`
+	Fields      map[string]interface{} `json:"fields"`
+	// Assignee is the username of the user to assign the task to, "me" is
+	// the requester.
+	Assignee string `json:"assignee"`
 }
 
 type CreateTaskResponse struct {
@@ -18,6 +60,13 @@ type CreateTaskResponse struct {
 type UpdateTaskRequest struct {
 	Name   string `json:"name"`
 	Status int    `json:"status"`
+	// Description is kept if it is omitted.
+	Description *string `json:"description,omitempty"`
+	// Tags are kept if it is omitted.
+	Tags []string `json:"tags,omitempty"`
+	// Fields replaces the custom field values, they are kept if it is
+	// omitted.
+	Fields map[string]interface{} `json:"fields,omitempty"`
 }
 
 type UpdateTaskResponse struct {
@@ -31,19 +80,602 @@ type DeleteTaskRequest struct {
 type DeleteTaskResponse struct {
 }
 
+type RestoreTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type ListTrashResponse struct {
+	Result []Task `json:"result"`
+}
+
+type ListTaskHistoryResponse struct {
+	Result []Revision `json:"result"`
+}
+
+type RevertTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type UndoResponse struct {
+	Result Task `json:"result"`
+}
+
+type RedoResponse struct {
+	Result Task `json:"result"`
+}
+
+type ListChecklistResponse struct {
+	Result []ChecklistItem `json:"result"`
+}
+
+type CreateChecklistItemRequest struct {
+	Text string `json:"text"`
+}
+
+type CreateChecklistItemResponse struct {
+	Result ChecklistItem `json:"result"`
+}
+
+type UpdateChecklistItemRequest struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+type UpdateChecklistItemResponse struct {
+	Result ChecklistItem `json:"result"`
+}
+
+type ReorderChecklistRequest struct {
+	ItemIDs []int `json:"item_ids"`
+}
+
+type ReorderChecklistResponse struct {
+	Result []ChecklistItem `json:"result"`
+}
+
+type ListCommentsResponse struct {
+	Result []Comment `json:"result"`
+}
+
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+type CreateCommentResponse struct {
+	Result Comment `json:"result"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body"`
+}
+
+type UpdateCommentResponse struct {
+	Result Comment `json:"result"`
+}
+
+type ListAttachmentsResponse struct {
+	Result []Attachment `json:"result"`
+}
+
+type CreateAttachmentResponse struct {
+	Result Attachment `json:"result"`
+}
+
+type StartTimerRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type StartTimerResponse struct {
+	Result TimeEntry `json:"result"`
+	// Stopped is the previous timer of the user stopped by this one.
+	Stopped *TimeEntry `json:"stopped,omitempty"`
+}
+
+type StopTimerResponse struct {
+	Result TimeEntry `json:"result"`
+}
+
+type ListTimeEntriesResponse struct {
+	Result []TimeEntry `json:"result"`
+}
+
+type CreateTimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at"`
+	StoppedAt time.Time `json:"stopped_at"`
+	Tags      []string  `json:"tags"`
+}
+
+type CreateTimeEntryResponse struct {
+	Result TimeEntry `json:"result"`
+}
+
+type UpdateTimeEntryRequest struct {
+	StartedAt time.Time `json:"started_at"`
+	StoppedAt time.Time `json:"stopped_at"`
+	Tags      []string  `json:"tags"`
+}
+
+type UpdateTimeEntryResponse struct {
+	Result TimeEntry `json:"result"`
+}
+
+type TimeReportRequest struct {
+	// From and To are the first and the last day of the report in UTC.
+	From time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To   time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	// User limits the report to the entries of the user if it is given.
+	User string `form:"user"`
+}
+
+type TimeReportResponse struct {
+	Result TimeReport `json:"result"`
+}
+
+type ListProjectsRequest struct {
+	// Archived includes the archived projects if it is true.
+	Archived bool `form:"archived"`
+}
+
+type ListProjectsResponse struct {
+	Result []Project `json:"result"`
+}
+
+type GetProjectResponse struct {
+	Result Project `json:"result"`
+}
+
+type CreateProjectRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateProjectResponse struct {
+	Result Project `json:"result"`
+}
+
+type UpdateProjectRequest struct {
+	Name     string `json:"name"`
+	Archived bool   `json:"archived"`
+}
+
+type UpdateProjectResponse struct {
+	Result Project `json:"result"`
+}
+
+type DeleteProjectRequest struct {
+	// Mode is "archive" to keep the tasks or "cascade" to move them to the
+	// trash, it is "archive" by default.
+	Mode string `form:"mode"`
+}
+
+type ListCustomFieldsResponse struct {
+	Result []CustomField `json:"result"`
+}
+
+type UpdateCustomFieldsRequest struct {
+	Fields []CustomField `json:"fields"`
+}
+
+type UpdateCustomFieldsResponse struct {
+	Result []CustomField `json:"result"`
+}
+
+type ListTemplatesResponse struct {
+	Result []Template `json:"result"`
+}
+
+type GetTemplateResponse struct {
+	Result Template `json:"result"`
+}
+
+type CreateTemplateRequest struct {
+	TemplateTask
+}
+
+type CreateTemplateResponse struct {
+	Result Template `json:"result"`
+}
+
+type UpdateTemplateRequest struct {
+	TemplateTask
+}
+
+type UpdateTemplateResponse struct {
+	Result Template `json:"result"`
+}
+
+type InstantiateTemplateRequest struct {
+	ProjectID int `json:"project_id"`
+	// Variables fill the placeholders such as {{assignee}}, {{date}} is the
+	// current date unless it is given.
+	Variables map[string]string `json:"variables"`
+}
+
+type InstantiateTemplateResponse struct {
+	// Result are the created tasks, the root task first.
+	Result []Task `json:"result"`
+}
+
+type AssignTaskRequest struct {
+	// Assignee is the username of the user to assign the task to, "me" is
+	// the requester.
+	Assignee string `json:"assignee"`
+}
+
+type AssignTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type UnassignTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type WatchTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type UnwatchTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Result Token `json:"result"`
+}
+
+type CreateUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Role is editor if it is omitted, the first user is always an admin.
+	Role string `json:"role"`
+	// Tenant is the tenant of the creator if it is omitted, only the admins
+	// of the default tenant can create users of other tenants.
+	Tenant string `json:"tenant"`
+}
+
+type CreateUserResponse struct {
+	Result User `json:"result"`
+}
+
+type ListUsersResponse struct {
+	Result []User `json:"result"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type UpdateUserRoleResponse struct {
+	Result User `json:"result"`
+}
+
+type ListProjectMembersResponse struct {
+	Result []ProjectMember `json:"result"`
+}
+
+type SetProjectMemberRequest struct {
+	Role string `json:"role"`
+}
+
+type SetProjectMemberResponse struct {
+	Result ProjectMember `json:"result"`
+}
+
+type ListTaskSharesResponse struct {
+	Result []TaskShare `json:"result"`
+}
+
+type SetTaskShareRequest struct {
+	// Access is "read" or "write".
+	Access string `json:"access"`
+}
+
+type SetTaskShareResponse struct {
+	Result TaskShare `json:"result"`
+}
+
+type GetMeResponse struct {
+	Result User `json:"result"`
+}
+
+type ListAPIKeysResponse struct {
+	Result []APIKey `json:"result"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateAPIKeyResponse struct {
+	Result APIKey `json:"result"`
+	// Key is the API key itself, it can not be retrieved again.
+	Key string `json:"key"`
+}
+
+type MoveTaskRequest struct {
+	// ProjectID is the destination project, 0 takes the task out of its
+	// project.
+	ProjectID int `json:"project_id"`
+}
+
+type MoveTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type ReorderTaskRequest struct {
+	// Before and After are the IDs of the tasks which the task is placed
+	// right before and right after in the manual order, at least one is
+	// required.
+	Before *int `json:"before"`
+	After  *int `json:"after"`
+}
+
+type ReorderTaskResponse struct {
+	Result Task `json:"result"`
+}
+
+type ListBoardsResponse struct {
+	Result []Board `json:"result"`
+}
+
+type GetBoardResponse struct {
+	Result Board `json:"result"`
+}
+
+type CreateBoardRequest struct {
+	Name      string `json:"name"`
+	ProjectID int    `json:"project_id"`
+	// GroupBy is "status" or "column".
+	GroupBy string        `json:"group_by"`
+	Columns []BoardColumn `json:"columns"`
+}
+
+type CreateBoardResponse struct {
+	Result Board `json:"result"`
+}
+
+type UpdateBoardRequest struct {
+	Name      string        `json:"name"`
+	ProjectID int           `json:"project_id"`
+	GroupBy   string        `json:"group_by"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+type UpdateBoardResponse struct {
+	Result Board `json:"result"`
+}
+
+type MoveCardRequest struct {
+	// Column is the key of the destination column.
+	Column string `json:"column"`
+	// Before and After optionally place the card in the manual order, the
+	// same as reordering tasks.
+	Before *int `json:"before"`
+	After  *int `json:"after"`
+}
+
+type MoveCardResponse struct {
+	Result Task `json:"result"`
+}
+
 type Task struct {
-	ID     int        `json:"id"`
-	Name   string     `json:"name"`
-	Status TaskStatus `json:"status"`
+	ID          int        `json:"id"`
+	Name        string     `json:"name"`
+	Status      TaskStatus `json:"status"`
+	Description string     `json:"description"`
+	ProjectID   int        `json:"project_id,omitempty"`
+	ParentID    int        `json:"parent_id,omitempty"`
+	OwnerID     int        `json:"owner_id,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	AssigneeID  int        `json:"assignee_id,omitempty"`
+	WatcherIDs  []int      `json:"watcher_ids,omitempty"`
+	Rank        string     `json:"rank"`
+	Column      string     `json:"column,omitempty"`
+	// Fields are the custom field values, the dates are in the form of
+	// "2006-01-02".
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// DescriptionHTML is the sanitized HTML rendered from the description,
+	// it is filled on request.
+	DescriptionHTML string          `json:"description_html,omitempty"`
+	Checklist       []ChecklistItem `json:"checklist"`
+	// ChecklistProgress is the number of done items and all items, such as
+	// "3/5", it is empty if the task has no checklist.
+	ChecklistProgress string     `json:"checklist_progress,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
 }
 
 type TaskStatus int
 
+type ChecklistItem struct {
+	ID   int    `json:"id"`
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
 const (
 	TaskStatusIncomplete TaskStatus = iota
 	TaskStatusComplete
 )
 
+type Revision struct {
+	Number    int           `json:"number"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	CreatedAt time.Time     `json:"created_at"`
+	Changes   []FieldChange `json:"changes"`
+}
+
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type Comment struct {
+	ID        int        `json:"id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+type Attachment struct {
+	ID          int       `json:"id"`
+	Filename    string    `json:"filename"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	SHA256      string    `json:"sha256"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type TimeEntry struct {
+	ID              int        `json:"id"`
+	TaskID          int        `json:"task_id"`
+	User            string     `json:"user"`
+	Tags            []string   `json:"tags"`
+	StartedAt       time.Time  `json:"started_at"`
+	StoppedAt       *time.Time `json:"stopped_at,omitempty"`
+	DurationSeconds int64      `json:"duration_seconds"`
+}
+
+type TimeReport struct {
+	From         string     `json:"from"`
+	To           string     `json:"to"`
+	TotalSeconds int64      `json:"total_seconds"`
+	ByTask       []TaskTime `json:"by_task"`
+	ByTag        []TagTime  `json:"by_tag"`
+	ByDay        []DayTime  `json:"by_day"`
+}
+
+type TaskTime struct {
+	TaskID  int   `json:"task_id"`
+	Seconds int64 `json:"seconds"`
+}
+
+type TagTime struct {
+	Tag     string `json:"tag"`
+	Seconds int64  `json:"seconds"`
+}
+
+type DayTime struct {
+	Date    string `json:"date"`
+	Seconds int64  `json:"seconds"`
+}
+
+type Project struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+}
+
+type Board struct {
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+	ProjectID int           `json:"project_id,omitempty"`
+	GroupBy   string        `json:"group_by"`
+	Columns   []BoardColumn `json:"columns"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type BoardColumn struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	WIPLimit int    `json:"wip_limit,omitempty"`
+	// Cards are the tasks in the column in the manual order, they are only
+	// filled when getting a board.
+	Cards []Task `json:"cards,omitempty"`
+}
+
+type Template struct {
+	ID int `json:"id"`
+	TemplateTask
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TemplateTask struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Checklist   []string       `json:"checklist,omitempty"`
+	Subtasks    []TemplateTask `json:"subtasks,omitempty"`
+}
+
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	// Role is one of viewer, editor and admin.
+	Role      string    `json:"role"`
+	Tenant    string    `json:"tenant,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ProjectMember struct {
+	ProjectID int       `json:"project_id"`
+	UserID    int       `json:"user_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TaskShare struct {
+	TaskID    int       `json:"task_id"`
+	UserID    int       `json:"user_id"`
+	Access    string    `json:"access"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Token struct {
+	// Token is the JWT to send as the bearer token.
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type APIKey struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CustomField struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// Type is one of string, number, enum, date and bool.
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+	// Checks are the results of the readiness checks by name, "ok" or the
+	// error.
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// ProblemResponse is the problem details of RFC 7807.
+type ProblemResponse struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// RequestID is the X-Request-ID of the request, so the problem can be
+	// found in the logs.
+	RequestID string `json:"request_id,omitempty"`
+}
+
 type ErrorResponse struct {
-	Message string `json:"message"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
 }
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// routePolicy is the lowest role allowed to call the route, the path is the
+// route pattern such as "/api/tasks/:id".
+type routePolicy struct {
+	Method string
+	Path   string
+	Role   dao.Role
+}
+
+// routePolicies are the routes whose roles differ from the default ones, see
+// requiredRole.
+var routePolicies = []routePolicy{
+	{http.MethodDelete, "/api/trash/:id", dao.RoleAdmin},
+	{http.MethodDelete, "/api/projects/:id", dao.RoleAdmin},
+	{http.MethodPut, "/api/projects/:id/members/:userID", dao.RoleAdmin},
+	{http.MethodDelete, "/api/projects/:id/members/:userID", dao.RoleAdmin},
+	{http.MethodPut, "/api/users/:id/role", dao.RoleAdmin},
+	// everyone manages their own API keys
+	{http.MethodPost, "/api/api-keys", dao.RoleViewer},
+	{http.MethodDelete, "/api/api-keys/:id", dao.RoleViewer},
+}
+
+// requiredRole returns the lowest role allowed to call the route, the viewers
+// can read and the editors can also write unless the route is listed in
+// routePolicies.
+func requiredRole(method, path string) dao.Role {
+	for _, policy := range routePolicies {
+		if policy.Method == method && policy.Path == path {
+			return policy.Role
+		}
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		return dao.RoleViewer
+	}
+	return dao.RoleEditor
+}
+
+// PolicyMiddleware rejects the requests whose requester does not have the
+// role required by the route, it runs after the AuthMiddleware.
+func (s *httpServerImpl) PolicyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		required := requiredRole(c.Request.Method, c.FullPath())
+		role, err := s.roleOf(c)
+		if err != nil {
+			s.loggerOf(c).Errorf("roleOf failed, err=%v, path=%v", err, c.FullPath())
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			c.Abort()
+			return
+		}
+
+		if !role.Includes(required) {
+			writeProblem(c, http.StatusForbidden, fmt.Sprintf("the %v role is required", required))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// roleOf returns the role of the requester for the request, which is the role
+// of the membership if the request is about a project the requester is a
+// member of. The admins are admins everywhere.
+func (s *httpServerImpl) roleOf(c *gin.Context) (dao.Role, error) {
+	id, _ := identityOf(c)
+	if id.Role == dao.RoleAdmin {
+		return id.Role, nil
+	}
+
+	projectID, err := s.projectOfRequest(c)
+	if err != nil {
+		return "", err
+	}
+
+	return s.roleInProject(c, projectID)
+}
+
+// roleInProject returns the role of the requester in the project, 0 means no
+// project and gives the role of the user.
+func (s *httpServerImpl) roleInProject(c *gin.Context, projectID int) (dao.Role, error) {
+	id, _ := identityOf(c)
+	if id.Role == dao.RoleAdmin || projectID == 0 {
+		return id.Role, nil
+	}
+
+	member, err := s.members(c).Get(projectID, id.UserID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		return id.Role, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return member.Role, nil
+}
+
+// projectOfRequest returns the project the request is about, 0 means none. The
+// invalid inputs are left to the handlers.
+func (s *httpServerImpl) projectOfRequest(c *gin.Context) (int, error) {
+	path := c.FullPath()
+	switch {
+	case strings.HasPrefix(path, "/api/projects/:id"):
+		projectID, _ := strconv.Atoi(c.Param("id"))
+		return projectID, nil
+	case strings.HasPrefix(path, "/api/tasks/:id"):
+		taskID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return 0, nil
+		}
+		task, err := s.tasks(c).GetByID(c.Request.Context(), taskID)
+		if errors.Is(err, dao.ErrResourceNotFound) {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+		return task.ProjectID, nil
+	case path == "/api/tasks" && c.Request.Method == http.MethodPost:
+		var req CreateTaskRequest
+		_ = c.ShouldBindBodyWith(&req, binding.JSON)
+		return req.ProjectID, nil
+	case strings.HasPrefix(path, "/api/boards/:id"):
+		boardID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return 0, nil
+		}
+		board, err := s.boards(c).GetByID(boardID)
+		if errors.Is(err, dao.ErrResourceNotFound) {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+		return board.ProjectID, nil
+	case path == "/api/boards" && c.Request.Method == http.MethodPost:
+		var req CreateBoardRequest
+		_ = c.ShouldBindBodyWith(&req, binding.JSON)
+		return req.ProjectID, nil
+	default:
+		return 0, nil
+	}
+}
+
+// writeProblem writes the problem details of RFC 7807.
+func writeProblem(c *gin.Context, status int, detail string) {
+	c.Header("Content-Type", "application/problem+json")
+	c.JSON(status, ProblemResponse{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		RequestID: requestIDOf(c),
+	})
+}
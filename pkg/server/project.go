@@ -0,0 +1,347 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	deleteProjectModeArchive = "archive"
+	deleteProjectModeCascade = "cascade"
+)
+
+func (s *httpServerImpl) ListProjectsHandler(c *gin.Context) {
+	var req ListProjectsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	projects, err := s.projects(c).List()
+	if err != nil {
+		s.loggerOf(c).Errorf("projectDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if !req.Archived {
+		activeProjects := make([]dao.Project, 0, len(projects))
+		for i := range projects {
+			if projects[i].ArchivedAt == nil {
+				activeProjects = append(activeProjects, projects[i])
+			}
+		}
+		projects = activeProjects
+	}
+
+	rsp := ListProjectsResponse{
+		Result: toModelProjects(projects),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) GetProjectHandler(c *gin.Context) {
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	rsp := GetProjectResponse{
+		Result: toModelProject(project),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) CreateProjectHandler(c *gin.Context) {
+	var req CreateProjectRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Name == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	project, err := s.projects(c).Create(dao.Project{
+		Name: req.Name,
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("projectDAO.Create failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateProjectResponse{
+		Result: toModelProject(project),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) UpdateProjectHandler(c *gin.Context) {
+	var req UpdateProjectRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || req.Name == "" {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	project.Name = req.Name
+	if !req.Archived {
+		project.ArchivedAt = nil
+	} else if project.ArchivedAt == nil {
+		archivedAt := time.Now().UTC()
+		project.ArchivedAt = &archivedAt
+	}
+
+	if !s.updateProjectOrAbort(c, &project) {
+		return
+	}
+
+	rsp := UpdateProjectResponse{
+		Result: toModelProject(project),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DeleteProjectHandler archives the project by default. In the cascade mode
+// the project is deleted and its tasks are taken out of it and moved to the
+// trash, so they can still be restored.
+func (s *httpServerImpl) DeleteProjectHandler(c *gin.Context) {
+	var req DeleteProjectRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	if req.Mode == "" {
+		req.Mode = deleteProjectModeArchive
+	}
+	if req.Mode != deleteProjectModeArchive && req.Mode != deleteProjectModeCascade {
+		writeResponseError(c, http.StatusBadRequest, "unsupported delete mode")
+		return
+	}
+
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if req.Mode == deleteProjectModeArchive {
+		if project.ArchivedAt == nil {
+			archivedAt := time.Now().UTC()
+			project.ArchivedAt = &archivedAt
+			if !s.updateProjectOrAbort(c, &project) {
+				return
+			}
+		}
+
+		c.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	tasks, err := s.tasks(c).List(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	for i := range tasks {
+		if tasks[i].ProjectID != project.ID {
+			continue
+		}
+
+		task := tasks[i].Clone()
+		task.ProjectID = 0
+		if err := s.tasks(c).Update(c.Request.Context(), &task); err != nil {
+			s.loggerOf(c).Errorf("taskDAO.Update failed, err=%v, taskID=%v", err, task.ID)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			return
+		}
+		if err := s.tasks(c).Delete(c.Request.Context(), task.ID); err != nil {
+			s.loggerOf(c).Errorf("taskDAO.Delete failed, err=%v, taskID=%v", err, task.ID)
+			writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+			return
+		}
+	}
+
+	if err := s.projects(c).Delete(project.ID); err != nil {
+		s.loggerOf(c).Errorf("projectDAO.Delete failed, err=%v, projectID=%v", err, project.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if err := s.members(c).DeleteByProjectID(project.ID); err != nil {
+		s.loggerOf(c).Errorf("projectMemberDAO.DeleteByProjectID failed, err=%v, projectID=%v", err, project.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (s *httpServerImpl) ListProjectTasksHandler(c *gin.Context) {
+	var req ListTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	req.ProjectID = &project.ID
+	s.listTasks(c, req)
+}
+
+func (s *httpServerImpl) MoveTaskHandler(c *gin.Context) {
+	var req MoveTaskRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	project, ok := s.getTaskProjectOrAbort(c, req.ProjectID)
+	if !ok {
+		return
+	}
+
+	if !s.checkTargetProjectOrAbort(c, req.ProjectID) {
+		return
+	}
+
+	if !checkFieldsOrAbort(c, project, current.Fields) {
+		return
+	}
+
+	task := current.Clone()
+	task.ProjectID = req.ProjectID
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := MoveTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// errTargetProjectRole is returned when the requester is not an editor of the
+// project a task is moved into.
+var errTargetProjectRole = fmt.Errorf("the %v role is required in the target project", dao.RoleEditor)
+
+// checkTargetProject fails with errTargetProjectRole unless the requester is
+// an editor of the project a task is moved into, the PolicyMiddleware only
+// checked the role in the project of the task.
+func (s *httpServerImpl) checkTargetProject(c *gin.Context, projectID int) error {
+	role, err := s.roleInProject(c, projectID)
+	if err != nil {
+		return err
+	}
+
+	if !role.Includes(dao.RoleEditor) {
+		return errTargetProjectRole
+	}
+
+	return nil
+}
+
+// checkTargetProjectOrAbort is checkTargetProject writing the error response
+// if it fails.
+func (s *httpServerImpl) checkTargetProjectOrAbort(c *gin.Context, projectID int) bool {
+	err := s.checkTargetProject(c, projectID)
+	if errors.Is(err, errTargetProjectRole) {
+		writeProblem(c, http.StatusForbidden, err.Error())
+		return false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("roleInProject failed, err=%v, projectID=%v", err, projectID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return false
+	}
+
+	return true
+}
+
+// getProjectOrAbort returns the project given by the path parameter, the
+// error response is written if it fails.
+func (s *httpServerImpl) getProjectOrAbort(c *gin.Context) (dao.Project, bool) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.Project{}, false
+	}
+
+	project, err := s.projects(c).GetByID(projectID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "project not found")
+		return dao.Project{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("projectDAO.GetByID failed, err=%v, projectID=%v", err, projectID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Project{}, false
+	}
+
+	return project, true
+}
+
+func (s *httpServerImpl) updateProjectOrAbort(c *gin.Context, project *dao.Project) bool {
+	err := s.projects(c).Update(project)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "project not found")
+		return false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("projectDAO.Update failed, err=%v, projectID=%v", err, project.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return false
+	}
+
+	return true
+}
+
+// checkProjectOrAbort checks the project given in the request can take tasks,
+// 0 means no project. The error response is written if it can not.
+func (s *httpServerImpl) checkProjectOrAbort(c *gin.Context, projectID int) bool {
+	_, ok := s.getTaskProjectOrAbort(c, projectID)
+	return ok
+}
+
+// getTaskProjectOrAbort returns the project given in the request if it can
+// take tasks, the zero project is returned for 0. The error response is
+// written if it can not.
+func (s *httpServerImpl) getTaskProjectOrAbort(c *gin.Context, projectID int) (dao.Project, bool) {
+	if projectID == 0 {
+		return dao.Project{}, true
+	}
+
+	project, err := s.projects(c).GetByID(projectID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusBadRequest, "project not found")
+		return dao.Project{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("projectDAO.GetByID failed, err=%v, projectID=%v", err, projectID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Project{}, false
+	}
+
+	if project.ArchivedAt != nil {
+		writeResponseError(c, http.StatusBadRequest, "project is archived")
+		return dao.Project{}, false
+	}
+
+	return project, true
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func (s *httpServerImpl) ListProjectMembersHandler(c *gin.Context) {
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	members, err := s.members(c).ListByProjectID(project.ID)
+	if err != nil {
+		s.loggerOf(c).Errorf("projectMemberDAO.ListByProjectID failed, err=%v, projectID=%v", err, project.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListProjectMembersResponse{
+		Result: toModelProjectMembers(members),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// SetProjectMemberHandler adds a user to the project or changes the role of
+// the member.
+func (s *httpServerImpl) SetProjectMemberHandler(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	var req SetProjectMemberRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+	if role := dao.Role(req.Role); !role.Valid() {
+		writeResponseError(c, http.StatusBadRequest, "invalid role")
+		return
+	}
+
+	project, ok := s.getProjectOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if user, err := s.users(c).GetByID(userID); errors.Is(err, dao.ErrResourceNotFound) || (err == nil && user.Tenant != tenantOf(c)) {
+		writeResponseError(c, http.StatusNotFound, "user not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("userDAO.GetByID failed, err=%v, userID=%v", err, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	member := dao.ProjectMember{
+		ProjectID: project.ID,
+		UserID:    userID,
+		Role:      dao.Role(req.Role),
+	}
+	if err := s.members(c).Set(&member); err != nil {
+		s.loggerOf(c).Errorf("projectMemberDAO.Set failed, err=%v, projectID=%v, userID=%v", err, project.ID, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := SetProjectMemberResponse{
+		Result: toModelProjectMember(member),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DeleteProjectMemberHandler removes the member from the project, the user
+// gets back the global role for the tasks of the project.
+func (s *httpServerImpl) DeleteProjectMemberHandler(c *gin.Context) {
+	projectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	if err := s.members(c).Delete(projectID, userID); err != nil {
+		s.loggerOf(c).Errorf("projectMemberDAO.Delete failed, err=%v, projectID=%v, userID=%v", err, projectID, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+
+	// rateLimitSweepInterval is how often the buckets of the clients gone
+	// quiet are dropped, so the buckets do not pile up.
+	rateLimitSweepInterval = time.Minute
+)
+
+// RateLimit is the token bucket given to each client, the bucket holds up to
+// Burst tokens and is refilled by Rate tokens per second. A Rate of 0 means
+// unlimited.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// rateLimiter keeps a token bucket for each client.
+type rateLimiter struct {
+	limit   RateLimit
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+}
+
+// rateLimitResult is the state of the bucket after taking a token.
+type rateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the wait until the next token, it is 0 if a token is
+	// left.
+	RetryAfter time.Duration
+	// Reset is the wait until the bucket is full again.
+	Reset time.Duration
+}
+
+// newRateLimiter returns nil if the limit is unlimited.
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &rateLimiter{
+		limit:   limit,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// take takes a token from the bucket of the client, the bucket of a new client
+// starts full.
+func (l *rateLimiter) take(client string, now time.Time) rateLimitResult {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	burst := float64(l.limit.Burst)
+	bucket, ok := l.buckets[client]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, updatedAt: now}
+		l.buckets[client] = bucket
+	}
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.limit.Rate)
+	bucket.updatedAt = now
+
+	result := rateLimitResult{}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.wait(1 - bucket.tokens)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.Reset = l.wait(burst - bucket.tokens)
+
+	return result
+}
+
+// sweep drops the buckets which are full by now, they are the same as the new
+// ones.
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < rateLimitSweepInterval {
+		return
+	}
+	l.sweptAt = now
+
+	burst := float64(l.limit.Burst)
+	for client, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.limit.Rate >= burst {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// wait returns the time to refill the tokens.
+func (l *rateLimiter) wait(tokens float64) time.Duration {
+	return time.Duration(tokens / l.limit.Rate * float64(time.Second))
+}
+
+// RateLimitMiddleware limits the requests of each client, the reads and the
+// writes by separate budgets. The clients are told apart by the valid API
+// keys, or the IPs otherwise, so it can run before the AuthMiddleware and
+// protect the login as well.
+func (s *httpServerImpl) RateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter := s.writeLimiter
+		if isReadMethod(c.Request.Method) {
+			limiter = s.readLimiter
+		}
+		if limiter == nil {
+			c.Next()
+			return
+		}
+
+		result := limiter.take(s.rateLimitClientOf(c), time.Now())
+		c.Header(headerRateLimitLimit, strconv.Itoa(limiter.limit.Burst))
+		c.Header(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+		c.Header(headerRateLimitReset, formatSeconds(result.Reset))
+		if !result.Allowed {
+			c.Header(headerRetryAfter, formatSeconds(result.RetryAfter))
+			writeResponseError(c, http.StatusTooManyRequests, "too many requests")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitClientOf returns the client the request is counted for. Only the
+// API keys which exist and are not revoked get their own buckets, otherwise
+// made-up keys would bypass the limit of the IP. The API keys are hashed so
+// they are not kept in the memory.
+func (s *httpServerImpl) rateLimitClientOf(c *gin.Context) string {
+	token := strings.TrimPrefix(c.GetHeader(headerAuthorization), bearerPrefix)
+	if strings.HasPrefix(token, dao.APIKeyPrefix) {
+		hash := dao.HashAPIKey(token)
+		if key, err := s.apiKeys(c).GetByHash(hash); err == nil && key.RevokedAt == nil {
+			return "key:" + hash
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// formatSeconds rounds the duration up to whole seconds, as the headers take.
+func formatSeconds(d time.Duration) string {
+	return fmt.Sprint(int64(math.Ceil(d.Seconds())))
+}
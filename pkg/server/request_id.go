@@ -0,0 +1,64 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	headerRequestID = "X-Request-ID"
+
+	// contextKeyRequestID keeps the ID of the request in the gin context, see
+	// requestIDOf.
+	contextKeyRequestID = "request_id"
+	// contextKeyLogger keeps the logger of the request in the gin context, see
+	// loggerOf.
+	contextKeyLogger = "logger"
+)
+
+// validRequestID is the request ID accepted from the client, the others are
+// replaced so the logs cannot be forged through it.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware accepts the X-Request-ID of the request or generates
+// one, and echoes it in the response. The logger of the request carries the
+// ID, so the logs of the handlers and the DAOs can be told apart by request.
+func (s *httpServerImpl) RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(headerRequestID)
+		if !validRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+
+		c.Set(contextKeyRequestID, id)
+		c.Set(contextKeyLogger, s.logger.With("request_id", id))
+		c.Header(headerRequestID, id)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// requestIDOf returns the ID placed by the RequestIDMiddleware.
+func requestIDOf(c *gin.Context) string {
+	return c.GetString(contextKeyRequestID)
+}
+
+// loggerOf returns the logger of the request, or the logger of the server if
+// the RequestIDMiddleware has not run.
+func (s *httpServerImpl) loggerOf(c *gin.Context) *zap.SugaredLogger {
+	if logger, ok := c.Value(contextKeyLogger).(*zap.SugaredLogger); ok {
+		return logger
+	}
+	return s.logger
+}
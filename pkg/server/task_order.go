@@ -0,0 +1,107 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var errAnchorNotFound = errors.New("anchor task not found")
+
+// ReorderTaskHandler moves the task in the manual order by giving it a rank
+// between its new neighbours, the other tasks are not renumbered.
+func (s *httpServerImpl) ReorderTaskHandler(c *gin.Context) {
+	var req ReorderTaskRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || (req.Before == nil && req.After == nil) {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := s.tasks(c).List(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rank, err := rankBetweenAnchors(tasks, current.ID, req.Before, req.After)
+	if errors.Is(err, errAnchorNotFound) {
+		writeResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	} else if errors.Is(err, dao.ErrInvalidRank) {
+		writeResponseError(c, http.StatusConflict, "the anchor tasks are not in order")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("rankBetweenAnchors failed, err=%v, taskID=%v", err, current.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	task := current.Clone()
+	task.Rank = rank
+	if !s.updateTaskOrAbort(c, current, &task) {
+		return
+	}
+
+	rsp := ReorderTaskResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// rankBetweenAnchors returns the rank placing the task right before the before
+// task and right after the after task. If only one anchor is given, the other
+// neighbour is the adjacent task in the manual order.
+func rankBetweenAnchors(tasks []dao.Task, taskID int, before, after *int) (string, error) {
+	ordered := make([]dao.Task, 0, len(tasks))
+	for i := range tasks {
+		if tasks[i].ID != taskID {
+			ordered = append(ordered, tasks[i])
+		}
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return manualTaskLess(ordered[i], ordered[j])
+	})
+
+	indexOf := func(id int) int {
+		for i := range ordered {
+			if ordered[i].ID == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var lower, upper string
+	if after != nil {
+		i := indexOf(*after)
+		if i < 0 {
+			return "", errAnchorNotFound
+		}
+		lower = ordered[i].Rank
+		if before == nil && i+1 < len(ordered) {
+			upper = ordered[i+1].Rank
+		}
+	}
+	if before != nil {
+		i := indexOf(*before)
+		if i < 0 {
+			return "", errAnchorNotFound
+		}
+		upper = ordered[i].Rank
+		if after == nil && i > 0 {
+			lower = ordered[i-1].Rank
+		}
+	}
+
+	return dao.RankBetween(lower, upper)
+}
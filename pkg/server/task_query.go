@@ -0,0 +1,164 @@
+package server
+
+import (
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"sort"
+	"strings"
+	"time"
+)
+
+// taskLessFuncs are the sortable fields of the task list, the incomplete tasks
+// are taken as completed later than the others.
+var taskLessFuncs = map[string]func(a, b dao.Task) bool{
+	"id": func(a, b dao.Task) bool {
+		return a.ID < b.ID
+	},
+	"created_at": func(a, b dao.Task) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	},
+	"updated_at": func(a, b dao.Task) bool {
+		return a.UpdatedAt.Before(b.UpdatedAt)
+	},
+	"completed_at": func(a, b dao.Task) bool {
+		return timeBefore(a.CompletedAt, b.CompletedAt)
+	},
+	"manual": manualTaskLess,
+}
+
+// manualTaskLess orders the tasks by rank, the ID breaks the tie in case the
+// ranks are equal.
+func manualTaskLess(a, b dao.Task) bool {
+	if a.Rank != b.Rank {
+		return a.Rank < b.Rank
+	}
+	return a.ID < b.ID
+}
+
+// taskQuery filters and sorts the task list.
+type taskQuery struct {
+	filters []func(task dao.Task) bool
+	less    func(a, b dao.Task) bool
+}
+
+// newTaskQuery returns the query of the request, the users of req.Assignee and
+// req.Watcher are given by their IDs resolved, 0 means no filter.
+func newTaskQuery(req ListTasksRequest, assigneeID, watcherID int) (taskQuery, error) {
+	var query taskQuery
+
+	if req.Sort != "" {
+		field := strings.TrimPrefix(req.Sort, "-")
+		desc := strings.HasPrefix(req.Sort, "-")
+		if key := strings.TrimPrefix(field, customFieldSortPrefix); key != field {
+			query.less = customFieldLess(key, desc)
+		} else {
+			less, ok := taskLessFuncs[field]
+			if !ok {
+				return taskQuery{}, fmt.Errorf("unsupported sort field: %v", field)
+			}
+
+			query.less = less
+			if desc {
+				query.less = func(a, b dao.Task) bool {
+					return less(b, a)
+				}
+			}
+		}
+	}
+
+	if req.ProjectID != nil {
+		projectID := *req.ProjectID
+		query.filters = append(query.filters, func(task dao.Task) bool {
+			return task.ProjectID == projectID
+		})
+	}
+
+	if assigneeID != 0 {
+		query.filters = append(query.filters, func(task dao.Task) bool {
+			return task.AssigneeID == assigneeID
+		})
+	}
+
+	if watcherID != 0 {
+		query.filters = append(query.filters, func(task dao.Task) bool {
+			return task.IsWatchedBy(watcherID)
+		})
+	}
+
+	for _, f := range req.Fields {
+		key, value, ok := strings.Cut(f, ":")
+		if !ok || key == "" {
+			return taskQuery{}, fmt.Errorf("invalid field filter: %v", f)
+		}
+		query.filters = append(query.filters, func(task dao.Task) bool {
+			return customFieldEqual(task.Fields[key], value)
+		})
+	}
+
+	query.addTimeRange(req.CreatedAfter, req.CreatedBefore, func(task dao.Task) *time.Time {
+		return &task.CreatedAt
+	})
+	query.addTimeRange(req.UpdatedAfter, req.UpdatedBefore, func(task dao.Task) *time.Time {
+		return &task.UpdatedAt
+	})
+	query.addTimeRange(req.CompletedAfter, req.CompletedBefore, func(task dao.Task) *time.Time {
+		return task.CompletedAt
+	})
+
+	return query, nil
+}
+
+// addTimeRange keeps the tasks whose field is within the given range, the
+// tasks without the field are dropped once any bound is given.
+func (q *taskQuery) addTimeRange(after, before *time.Time, field func(task dao.Task) *time.Time) {
+	if after == nil && before == nil {
+		return
+	}
+
+	q.filters = append(q.filters, func(task dao.Task) bool {
+		t := field(task)
+		if t == nil {
+			return false
+		}
+		if after != nil && t.Before(*after) {
+			return false
+		}
+		if before != nil && t.After(*before) {
+			return false
+		}
+		return true
+	})
+}
+
+func (q taskQuery) apply(tasks []dao.Task) []dao.Task {
+	retTasks := make([]dao.Task, 0, len(tasks))
+	for i := range tasks {
+		if q.match(tasks[i]) {
+			retTasks = append(retTasks, tasks[i])
+		}
+	}
+
+	if q.less != nil {
+		sort.SliceStable(retTasks, func(i, j int) bool {
+			return q.less(retTasks[i], retTasks[j])
+		})
+	}
+
+	return retTasks
+}
+
+func (q taskQuery) match(task dao.Task) bool {
+	for _, filter := range q.filters {
+		if !filter(task) {
+			return false
+		}
+	}
+	return true
+}
+
+func timeBefore(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	return a.Before(*b)
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func (s *httpServerImpl) ListTaskSharesHandler(c *gin.Context) {
+	task, ok := s.getOwnedTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	shares, err := s.shares(c).ListByTaskID(task.ID)
+	if err != nil {
+		s.loggerOf(c).Errorf("taskShareDAO.ListByTaskID failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListTaskSharesResponse{
+		Result: toModelTaskShares(shares),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// SetTaskShareHandler shares the task with a user or changes the access of
+// the user.
+func (s *httpServerImpl) SetTaskShareHandler(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	var req SetTaskShareRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+	if access := dao.ShareAccess(req.Access); !access.Valid() {
+		writeResponseError(c, http.StatusBadRequest, "invalid access")
+		return
+	}
+
+	task, ok := s.getOwnedTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if user, err := s.users(c).GetByID(userID); errors.Is(err, dao.ErrResourceNotFound) || (err == nil && user.Tenant != tenantOf(c)) {
+		writeResponseError(c, http.StatusNotFound, "user not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("userDAO.GetByID failed, err=%v, userID=%v", err, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	share := dao.TaskShare{
+		TaskID: task.ID,
+		UserID: userID,
+		Access: dao.ShareAccess(req.Access),
+	}
+	if err := s.shares(c).Set(&share); err != nil {
+		s.loggerOf(c).Errorf("taskShareDAO.Set failed, err=%v, taskID=%v, userID=%v", err, task.ID, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := SetTaskShareResponse{
+		Result: toModelTaskShare(share),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// DeleteTaskShareHandler stops sharing the task with the user.
+func (s *httpServerImpl) DeleteTaskShareHandler(c *gin.Context) {
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	task, ok := s.getOwnedTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if err := s.shares(c).Delete(task.ID, userID); err != nil {
+		s.loggerOf(c).Errorf("taskShareDAO.Delete failed, err=%v, taskID=%v, userID=%v", err, task.ID, userID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// getOwnedTaskOrAbort returns the task given by the id path parameter if the
+// requester owns it or is an admin, the error response is written if it fails.
+func (s *httpServerImpl) getOwnedTaskOrAbort(c *gin.Context) (dao.Task, bool) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return dao.Task{}, false
+	}
+
+	if id, ok := identityOf(c); ok && id.Role != dao.RoleAdmin && task.OwnerID != id.UserID {
+		writeResponseError(c, http.StatusForbidden, "only the owner can share the task")
+		return dao.Task{}, false
+	}
+
+	return task, true
+}
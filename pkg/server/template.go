@@ -0,0 +1,283 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// templatePlaceholder matches the placeholders such as {{date}}, the spaces
+// inside the braces are allowed.
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// templateDateLayout is the layout of the {{date}} placeholder.
+const templateDateLayout = "2006-01-02"
+
+func (s *httpServerImpl) ListTemplatesHandler(c *gin.Context) {
+	templates, err := s.templates(c).List()
+	if err != nil {
+		s.loggerOf(c).Errorf("templateDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListTemplatesResponse{
+		Result: toModelTemplates(templates),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) GetTemplateHandler(c *gin.Context) {
+	template, ok := s.getTemplateOrAbort(c)
+	if !ok {
+		return
+	}
+
+	rsp := GetTemplateResponse{
+		Result: toModelTemplate(template),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) CreateTemplateHandler(c *gin.Context) {
+	var req CreateTemplateRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || !validTemplateTask(req.TemplateTask) {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	template, err := s.templates(c).Create(dao.Template{
+		TemplateTask: toDAOTemplateTask(req.TemplateTask),
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("templateDAO.Create failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateTemplateResponse{
+		Result: toModelTemplate(template),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) UpdateTemplateHandler(c *gin.Context) {
+	var req UpdateTemplateRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || !validTemplateTask(req.TemplateTask) {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	current, ok := s.getTemplateOrAbort(c)
+	if !ok {
+		return
+	}
+
+	template := dao.Template{
+		ID:           current.ID,
+		TemplateTask: toDAOTemplateTask(req.TemplateTask),
+	}
+	err := s.templates(c).Update(&template)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "template not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("templateDAO.Update failed, err=%v, templateID=%v", err, template.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := UpdateTemplateResponse{
+		Result: toModelTemplate(template),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) DeleteTemplateHandler(c *gin.Context) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	if err := s.templates(c).Delete(templateID); err != nil {
+		s.loggerOf(c).Errorf("templateDAO.Delete failed, err=%v, templateID=%v", err, templateID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// InstantiateTemplateHandler creates the task tree of the template with the
+// placeholders filled, the tasks are created in one batch.
+func (s *httpServerImpl) InstantiateTemplateHandler(c *gin.Context) {
+	var req InstantiateTemplateRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	template, ok := s.getTemplateOrAbort(c)
+	if !ok {
+		return
+	}
+
+	project, ok := s.getTaskProjectOrAbort(c, req.ProjectID)
+	if !ok {
+		return
+	}
+
+	// the tasks are created without custom fields
+	if !checkFieldsOrAbort(c, project, nil) {
+		return
+	}
+
+	variables := map[string]string{
+		"date": time.Now().Format(templateDateLayout),
+	}
+	for name, value := range req.Variables {
+		variables[name] = value
+	}
+
+	tree, err := instantiateTemplateTask(template.TemplateTask, req.ProjectID, ownerOf(c), variables)
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tasks, _ := tree.Flatten()
+	for i := range tasks {
+		if !s.validDescription(tasks[i].Description) {
+			writeResponseError(c, http.StatusBadRequest, "description is too long")
+			return
+		}
+	}
+
+	tasks, err = s.tasks(c).CreateTree(c.Request.Context(), tree)
+	if errors.Is(err, dao.ErrTaskQuotaExceeded) {
+		writeResponseError(c, http.StatusForbidden, err.Error())
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.CreateTree failed, err=%v, templateID=%v", err, template.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	for i := range tasks {
+		s.recordOperation(c, trashedTask(tasks[i]), tasks[i])
+	}
+
+	rsp := InstantiateTemplateResponse{
+		Result: toModelTasks(tasks),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+// getTemplateOrAbort returns the template given by the path parameter, the
+// error response is written if it fails.
+func (s *httpServerImpl) getTemplateOrAbort(c *gin.Context) (dao.Template, bool) {
+	templateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.Template{}, false
+	}
+
+	template, err := s.templates(c).GetByID(templateID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "template not found")
+		return dao.Template{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("templateDAO.GetByID failed, err=%v, templateID=%v", err, templateID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.Template{}, false
+	}
+
+	return template, true
+}
+
+// validTemplateTask reports whether the task and all its subtasks have names.
+func validTemplateTask(task TemplateTask) bool {
+	if task.Name == "" {
+		return false
+	}
+	for _, subtask := range task.Subtasks {
+		if !validTemplateTask(subtask) {
+			return false
+		}
+	}
+	return true
+}
+
+// instantiateTemplateTask builds the task tree of the template task with the
+// placeholders filled by the variables, the tasks are owned by ownerID.
+func instantiateTemplateTask(templateTask dao.TemplateTask, projectID, ownerID int, variables map[string]string) (dao.TaskTree, error) {
+	fill := func(text string) (string, error) {
+		return fillPlaceholders(text, variables)
+	}
+
+	task := dao.Task{
+		ProjectID: projectID,
+		OwnerID:   ownerID,
+	}
+	var err error
+	if task.Name, err = fill(templateTask.Name); err != nil {
+		return dao.TaskTree{}, err
+	}
+	if task.Description, err = fill(templateTask.Description); err != nil {
+		return dao.TaskTree{}, err
+	}
+	for _, tag := range templateTask.Tags {
+		if tag, err = fill(tag); err != nil {
+			return dao.TaskTree{}, err
+		}
+		task.Tags = append(task.Tags, tag)
+	}
+	for _, text := range templateTask.Checklist {
+		if text, err = fill(text); err != nil {
+			return dao.TaskTree{}, err
+		}
+		task.AddChecklistItem(text)
+	}
+
+	tree := dao.TaskTree{
+		Task: task,
+	}
+	for _, subtask := range templateTask.Subtasks {
+		subtree, err := instantiateTemplateTask(subtask, projectID, ownerID, variables)
+		if err != nil {
+			return dao.TaskTree{}, err
+		}
+		tree.Subtasks = append(tree.Subtasks, subtree)
+	}
+
+	return tree, nil
+}
+
+// fillPlaceholders replaces the placeholders in the text with the variables,
+// it fails if any of them is not given.
+func fillPlaceholders(text string, variables map[string]string) (string, error) {
+	var missing string
+	filled := templatePlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, ok := variables[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+
+	if missing != "" {
+		return "", fmt.Errorf("variable %v is not given", missing)
+	}
+	return filled, nil
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"gogo-exercise/pkg/dao"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	headerTenantID = "X-Tenant-ID"
+	// contextKeyTenant keeps the tenant of the request in the gin context,
+	// see tenantOf.
+	contextKeyTenant = "tenant"
+)
+
+// TenantMiddleware resolves the tenant of the request, which is the tenant of
+// the requester. The admins of the default tenant operate the deployment, so
+// they can act on any tenant given by the X-Tenant-ID header. It runs after
+// the AuthMiddleware.
+func (s *httpServerImpl) TenantMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, _ := identityOf(c)
+		tenant := id.Tenant
+		if header := c.GetHeader(headerTenantID); header != "" && header != tenant {
+			if id.Tenant != dao.DefaultTenant || id.Role != dao.RoleAdmin {
+				writeProblem(c, http.StatusForbidden, "the tenant is not accessible")
+				c.Abort()
+				return
+			}
+			tenant = header
+		}
+
+		if !dao.ValidTenant(tenant) {
+			writeResponseError(c, http.StatusBadRequest, "invalid tenant")
+			c.Abort()
+			return
+		}
+
+		c.Set(contextKeyTenant, tenant)
+		c.Next()
+	}
+}
+
+// tenantOf returns the tenant placed by the TenantMiddleware.
+func tenantOf(c *gin.Context) string {
+	return c.GetString(contextKeyTenant)
+}
@@ -0,0 +1,244 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func (s *httpServerImpl) StartTimerHandler(c *gin.Context) {
+	var req StartTimerRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+			writeResponseError(c, http.StatusBadRequest, "parse input failed")
+			return
+		}
+	}
+
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	started, stopped, err := s.timeEntries(c).StartTimer(task.ID, actorOf(c), req.Tags)
+	if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.StartTimer failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	now := time.Now()
+	rsp := StartTimerResponse{
+		Result: toModelTimeEntry(started, now),
+	}
+	if stopped != nil {
+		entry := toModelTimeEntry(*stopped, now)
+		rsp.Stopped = &entry
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) StopTimerHandler(c *gin.Context) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	entry, err := s.timeEntries(c).StopTimer(task.ID, actorOf(c))
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusConflict, "no running timer")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.StopTimer failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := StopTimerResponse{
+		Result: toModelTimeEntry(entry, time.Now()),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) ListTimeEntriesHandler(c *gin.Context) {
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	entries, err := s.timeEntries(c).ListByTaskID(task.ID)
+	if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.ListByTaskID failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := ListTimeEntriesResponse{
+		Result: toModelTimeEntries(entries, time.Now()),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) CreateTimeEntryHandler(c *gin.Context) {
+	var req CreateTimeEntryRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || !validTimePeriod(req.StartedAt, req.StoppedAt) {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return
+	}
+
+	stoppedAt := req.StoppedAt.UTC()
+	entry, err := s.timeEntries(c).Create(dao.TimeEntry{
+		TaskID:    task.ID,
+		User:      actorOf(c),
+		Tags:      req.Tags,
+		StartedAt: req.StartedAt.UTC(),
+		StoppedAt: &stoppedAt,
+	})
+	if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.Create failed, err=%v, taskID=%v", err, task.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := CreateTimeEntryResponse{
+		Result: toModelTimeEntry(entry, time.Now()),
+	}
+	c.JSON(http.StatusCreated, rsp)
+}
+
+func (s *httpServerImpl) UpdateTimeEntryHandler(c *gin.Context) {
+	var req UpdateTimeEntryRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil || !validTimePeriod(req.StartedAt, req.StoppedAt) {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	entry, ok := s.getTimeEntryOrAbort(c)
+	if !ok {
+		return
+	}
+
+	stoppedAt := req.StoppedAt.UTC()
+	entry.StartedAt = req.StartedAt.UTC()
+	entry.StoppedAt = &stoppedAt
+	entry.Tags = req.Tags
+	err := s.timeEntries(c).Update(&entry)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		writeResponseError(c, http.StatusNotFound, "time entry not found")
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.Update failed, err=%v, entryID=%v", err, entry.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	rsp := UpdateTimeEntryResponse{
+		Result: toModelTimeEntry(entry, time.Now()),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) DeleteTimeEntryHandler(c *gin.Context) {
+	entry, ok := s.getTimeEntryOrAbort(c)
+	if !ok {
+		return
+	}
+
+	if err := s.timeEntries(c).Delete(entry.ID); err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.Delete failed, err=%v, entryID=%v", err, entry.ID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (s *httpServerImpl) TimeReportHandler(c *gin.Context) {
+	var req TimeReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil || req.From.IsZero() || req.To.Before(req.From) {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return
+	}
+
+	// the last day is included
+	from, to := req.From, req.To.Add(24*time.Hour)
+	entries, err := s.timeEntries(c).List(from, to)
+	if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.List failed, err=%v, from=%v, to=%v", err, from, to)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	// the time is only reported on the tasks visible to the requester, as
+	// the tasks are listed
+	tasks, err := s.tasks(c).List(c.Request.Context())
+	if err != nil {
+		s.loggerOf(c).Errorf("taskDAO.List failed, err=%v", err)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+	taskTags := make(map[int][]string, len(tasks))
+	for i := range tasks {
+		taskTags[tasks[i].ID] = tasks[i].Tags
+	}
+
+	reportEntries := make([]dao.TimeEntry, 0, len(entries))
+	for i := range entries {
+		if _, visible := taskTags[entries[i].TaskID]; visible && (req.User == "" || entries[i].User == req.User) {
+			reportEntries = append(reportEntries, entries[i])
+		}
+	}
+	entries = reportEntries
+
+	rsp := TimeReportResponse{
+		Result: newTimeReport(entries, taskTags, from, to, time.Now()),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// getTimeEntryOrAbort returns the time entry given by the path parameters to
+// change, which only its user and the admins can do since the entries are
+// billed. The error response is written if it fails.
+func (s *httpServerImpl) getTimeEntryOrAbort(c *gin.Context) (dao.TimeEntry, bool) {
+	entryID, err := strconv.Atoi(c.Param("entryID"))
+	if err != nil {
+		writeResponseError(c, http.StatusBadRequest, "parse input failed")
+		return dao.TimeEntry{}, false
+	}
+
+	task, ok := s.getTaskOrAbort(c)
+	if !ok {
+		return dao.TimeEntry{}, false
+	}
+
+	entry, err := s.timeEntries(c).GetByID(entryID)
+	if errors.Is(err, dao.ErrResourceNotFound) || (err == nil && entry.TaskID != task.ID) {
+		writeResponseError(c, http.StatusNotFound, "time entry not found")
+		return dao.TimeEntry{}, false
+	} else if err != nil {
+		s.loggerOf(c).Errorf("timeEntryDAO.GetByID failed, err=%v, entryID=%v", err, entryID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return dao.TimeEntry{}, false
+	}
+
+	if id, _ := identityOf(c); entry.User != actorOf(c) && id.Role != dao.RoleAdmin {
+		writeResponseError(c, http.StatusForbidden, "only the user of the time entry can change it")
+		return dao.TimeEntry{}, false
+	}
+
+	return entry, true
+}
+
+func validTimePeriod(startedAt, stoppedAt time.Time) bool {
+	return !startedAt.IsZero() && stoppedAt.After(startedAt)
+}
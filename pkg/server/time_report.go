@@ -0,0 +1,94 @@
+package server
+
+import (
+	"gogo-exercise/pkg/dao"
+	"sort"
+	"time"
+)
+
+const reportDateLayout = "2006-01-02"
+
+// newTimeReport sums the time of the entries within [from, to). The entries are
+// clipped to the range and split by the UTC days, a running entry is counted
+// until now. An entry counts for each tag of its task, given by taskTags, and
+// of its own, once for a tag on both.
+func newTimeReport(entries []dao.TimeEntry, taskTags map[int][]string, from, to, now time.Time) TimeReport {
+	var total time.Duration
+	byTask := make(map[int]time.Duration)
+	byTag := make(map[string]time.Duration)
+	byDay := make(map[string]time.Duration)
+
+	for _, entry := range entries {
+		start, stop := entry.StartedAt, now
+		if entry.StoppedAt != nil {
+			stop = *entry.StoppedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if stop.After(to) {
+			stop = to
+		}
+		if !start.Before(stop) {
+			continue
+		}
+
+		duration := stop.Sub(start)
+		total += duration
+		byTask[entry.TaskID] += duration
+		counted := make(map[string]bool)
+		for _, tags := range [][]string{taskTags[entry.TaskID], entry.Tags} {
+			for _, tag := range tags {
+				if !counted[tag] {
+					counted[tag] = true
+					byTag[tag] += duration
+				}
+			}
+		}
+
+		for day := start.UTC().Truncate(24 * time.Hour); day.Before(stop); day = day.Add(24 * time.Hour) {
+			dayStart, dayStop := day, day.Add(24*time.Hour)
+			if dayStart.Before(start) {
+				dayStart = start
+			}
+			if dayStop.After(stop) {
+				dayStop = stop
+			}
+			byDay[day.Format(reportDateLayout)] += dayStop.Sub(dayStart)
+		}
+	}
+
+	report := TimeReport{
+		From:         from.Format(reportDateLayout),
+		To:           to.Add(-24 * time.Hour).Format(reportDateLayout),
+		TotalSeconds: seconds(total),
+		ByTask:       make([]TaskTime, 0, len(byTask)),
+		ByTag:        make([]TagTime, 0, len(byTag)),
+		ByDay:        make([]DayTime, 0, len(byDay)),
+	}
+	for taskID, duration := range byTask {
+		report.ByTask = append(report.ByTask, TaskTime{TaskID: taskID, Seconds: seconds(duration)})
+	}
+	for tag, duration := range byTag {
+		report.ByTag = append(report.ByTag, TagTime{Tag: tag, Seconds: seconds(duration)})
+	}
+	for date, duration := range byDay {
+		report.ByDay = append(report.ByDay, DayTime{Date: date, Seconds: seconds(duration)})
+	}
+
+	sort.Slice(report.ByTask, func(i, j int) bool {
+		return report.ByTask[i].TaskID < report.ByTask[j].TaskID
+	})
+	sort.Slice(report.ByTag, func(i, j int) bool {
+		return report.ByTag[i].Tag < report.ByTag[j].Tag
+	})
+	sort.Slice(report.ByDay, func(i, j int) bool {
+		return report.ByDay[i].Date < report.ByDay[j].Date
+	})
+
+	return report
+}
+
+func seconds(duration time.Duration) int64 {
+	return int64(duration / time.Second)
+}
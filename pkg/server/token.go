@@ -0,0 +1,83 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var errInvalidToken = errors.New("invalid token")
+
+// tokenHeader is the only JWT header issued and accepted, the tokens signed by
+// other algorithms are rejected.
+const tokenHeader = `{"alg":"HS256","typ":"JWT"}`
+
+// tokenClaims are the JWT claims identifying a user. The role and the tenant
+// are only informative for the clients, the AuthMiddleware loads them.
+type tokenClaims struct {
+	// Subject is the user ID.
+	Subject   string `json:"sub"`
+	Username  string `json:"name"`
+	Role      string `json:"role"`
+	Tenant    string `json:"tenant,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// signToken returns the HS256 JWT of the claims.
+func signToken(secret []byte, claims tokenClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString([]byte(tokenHeader)) + "." + encoding.EncodeToString(payload)
+	return signingInput + "." + encoding.EncodeToString(tokenSignature(secret, signingInput)), nil
+}
+
+// parseToken verifies the JWT and returns its claims, it fails with
+// errInvalidToken if the token is malformed, forged or expired.
+func parseToken(secret []byte, token string, now time.Time) (tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return tokenClaims{}, errInvalidToken
+	}
+
+	encoding := base64.RawURLEncoding
+	header, err := encoding.DecodeString(parts[0])
+	if err != nil || string(header) != tokenHeader {
+		return tokenClaims{}, errInvalidToken
+	}
+
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, tokenSignature(secret, parts[0]+"."+parts[1])) {
+		return tokenClaims{}, errInvalidToken
+	}
+
+	payload, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return tokenClaims{}, errInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return tokenClaims{}, errInvalidToken
+	}
+
+	if now.Unix() >= claims.ExpiresAt {
+		return tokenClaims{}, errInvalidToken
+	}
+
+	return claims, nil
+}
+
+func tokenSignature(secret []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,245 @@
+package server
+
+import (
+	"errors"
+	"gogo-exercise/pkg/dao"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errTaskChanged = errors.New("task has changed since the operation")
+
+func (s *httpServerImpl) UndoHandler(c *gin.Context) {
+	s.undoMu.Lock()
+	defer s.undoMu.Unlock()
+
+	clientID := undoClientID(c)
+	log, err := s.undoLogs(c).Get(clientID)
+	if err != nil {
+		s.loggerOf(c).Errorf("undoLogDAO.Get failed, err=%v, clientID=%v", err, clientID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if len(log.Undo) == 0 {
+		writeResponseError(c, http.StatusConflict, "nothing to undo")
+		return
+	}
+
+	op := log.Undo[len(log.Undo)-1]
+	log.Undo = log.Undo[:len(log.Undo)-1]
+
+	task, err := s.applyOperation(c, op.TaskID, op.After, op.Before)
+	if errors.Is(err, errTaskChanged) {
+		// the operation can not be undone anymore, drop it
+		s.saveUndoLog(c, clientID, log)
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if errors.Is(err, errTargetProjectRole) {
+		// the requester can not undo the operation, drop it
+		s.saveUndoLog(c, clientID, log)
+		writeProblem(c, http.StatusForbidden, err.Error())
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		// the operation is kept, it can be undone once the column has room
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("applyOperation failed, err=%v, taskID=%v", err, op.TaskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	log.Redo = append(log.Redo, dao.Operation{TaskID: op.TaskID, Before: task, After: op.After})
+	s.saveUndoLog(c, clientID, log)
+
+	rsp := UndoResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+func (s *httpServerImpl) RedoHandler(c *gin.Context) {
+	s.undoMu.Lock()
+	defer s.undoMu.Unlock()
+
+	clientID := undoClientID(c)
+	log, err := s.undoLogs(c).Get(clientID)
+	if err != nil {
+		s.loggerOf(c).Errorf("undoLogDAO.Get failed, err=%v, clientID=%v", err, clientID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	if len(log.Redo) == 0 {
+		writeResponseError(c, http.StatusConflict, "nothing to redo")
+		return
+	}
+
+	op := log.Redo[len(log.Redo)-1]
+	log.Redo = log.Redo[:len(log.Redo)-1]
+
+	task, err := s.applyOperation(c, op.TaskID, op.Before, op.After)
+	if errors.Is(err, errTaskChanged) {
+		// the operation can not be redone anymore, drop it
+		s.saveUndoLog(c, clientID, log)
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if errors.Is(err, errTargetProjectRole) {
+		// the requester can not redo the operation, drop it
+		s.saveUndoLog(c, clientID, log)
+		writeProblem(c, http.StatusForbidden, err.Error())
+		return
+	} else if errors.Is(err, dao.ErrWIPLimitReached) {
+		// the operation is kept, it can be redone once the column has room
+		writeResponseError(c, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		s.loggerOf(c).Errorf("applyOperation failed, err=%v, taskID=%v", err, op.TaskID)
+		writeResponseError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	log.Undo = append(log.Undo, dao.Operation{TaskID: op.TaskID, Before: op.Before, After: task})
+	s.saveUndoLog(c, clientID, log)
+
+	rsp := RedoResponse{
+		Result: toModelTask(task),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// recordOperation pushes the mutation of a task into the undo log of the
+// requester. The mutation has been applied, so a failure is only logged.
+func (s *httpServerImpl) recordOperation(c *gin.Context, before, after dao.Task) {
+	s.undoMu.Lock()
+	defer s.undoMu.Unlock()
+
+	clientID := undoClientID(c)
+	log, err := s.undoLogs(c).Get(clientID)
+	if err != nil {
+		s.loggerOf(c).Errorf("undoLogDAO.Get failed, err=%v, clientID=%v", err, clientID)
+		return
+	}
+
+	log.Push(dao.Operation{TaskID: after.ID, Before: before, After: after})
+	s.saveUndoLog(c, clientID, log)
+}
+
+// undoClientID returns the client of the undo log of the requester, which is
+// kept per tenant as the tasks are.
+func undoClientID(c *gin.Context) string {
+	if tenant := tenantOf(c); tenant != dao.DefaultTenant {
+		return tenant + "/" + actorOf(c)
+	}
+	return actorOf(c)
+}
+
+func (s *httpServerImpl) saveUndoLog(c *gin.Context, clientID string, log dao.UndoLog) {
+	if err := s.undoLogs(c).Save(clientID, log); err != nil {
+		s.loggerOf(c).Errorf("undoLogDAO.Save failed, err=%v, clientID=%v", err, clientID)
+	}
+}
+
+// applyOperation moves the task from the from state to the to state, it fails
+// with errTaskChanged if the task is not in the from state anymore.
+func (s *httpServerImpl) applyOperation(c *gin.Context, taskID int, from, to dao.Task) (dao.Task, error) {
+	current, err := s.taskState(c, taskID)
+	if errors.Is(err, dao.ErrResourceNotFound) {
+		return dao.Task{}, errTaskChanged
+	} else if err != nil {
+		return dao.Task{}, err
+	}
+
+	if !sameTaskState(current, from) {
+		return dao.Task{}, errTaskChanged
+	}
+
+	var task dao.Task
+	switch {
+	case from.DeletedAt == nil && to.DeletedAt == nil:
+		if to.ProjectID != current.ProjectID {
+			if err := s.checkTargetProject(c, to.ProjectID); err != nil {
+				return dao.Task{}, err
+			}
+		}
+		task = to
+		err = s.tasks(c).Update(c.Request.Context(), &task)
+	case from.DeletedAt == nil:
+		task = trashedTask(current)
+		err = s.tasks(c).Delete(c.Request.Context(), taskID)
+	case to.DeletedAt == nil:
+		task, err = s.tasks(c).Restore(c.Request.Context(), taskID)
+	default:
+		task = current
+	}
+
+	// the task may not be shared with the requester anymore
+	if errors.Is(err, dao.ErrResourceNotFound) || errors.Is(err, dao.ErrPermissionDenied) {
+		return dao.Task{}, errTaskChanged
+	} else if err != nil {
+		return dao.Task{}, err
+	}
+
+	return task, nil
+}
+
+// taskState returns the task whether it is in the trash or not.
+func (s *httpServerImpl) taskState(c *gin.Context, taskID int) (dao.Task, error) {
+	task, err := s.tasks(c).GetByID(c.Request.Context(), taskID)
+	if !errors.Is(err, dao.ErrResourceNotFound) {
+		return task, err
+	}
+
+	tasks, err := s.tasks(c).ListTrashed(c.Request.Context())
+	if err != nil {
+		return dao.Task{}, err
+	}
+
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			return tasks[i], nil
+		}
+	}
+
+	return dao.Task{}, dao.ErrResourceNotFound
+}
+
+// sameTaskState compares two task states, the time a task is trashed at does
+// not matter, nor does an empty slice or map differ from a nil one, as the
+// TaskDAO may store either.
+func sameTaskState(a, b dao.Task) bool {
+	if (a.DeletedAt == nil) != (b.DeletedAt == nil) {
+		return false
+	}
+
+	return reflect.DeepEqual(normalizedTaskState(a), normalizedTaskState(b))
+}
+
+func normalizedTaskState(task dao.Task) dao.Task {
+	task.DeletedAt = nil
+	if len(task.Checklist) == 0 {
+		task.Checklist = nil
+	}
+	if len(task.Tags) == 0 {
+		task.Tags = nil
+	}
+	if len(task.WatcherIDs) == 0 {
+		task.WatcherIDs = nil
+	}
+	if len(task.Fields) == 0 {
+		task.Fields = nil
+	}
+	return task
+}
+
+func trashedTask(task dao.Task) dao.Task {
+	if task.DeletedAt == nil {
+		now := time.Now()
+		task.DeletedAt = &now
+	}
+	return task
+}
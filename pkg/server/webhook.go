@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"gogo-exercise/pkg/dao"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	webhookEventTaskAssigned  = "task.assigned"
+	webhookEventTaskCompleted = "task.completed"
+
+	headerWebhookEvent = "X-Gogo-Event"
+)
+
+// WebhookEvent is posted to the webhooks as JSON, the receivers notify the
+// assignee and the watchers of the task. RequestID is the request causing the
+// event, which is sent as X-Request-ID too.
+type WebhookEvent struct {
+	Type       string    `json:"type"`
+	Tenant     string    `json:"tenant,omitempty"`
+	Actor      string    `json:"actor"`
+	Task       Task      `json:"task"`
+	RequestID  string    `json:"request_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// webhookNotifier posts the events to the webhooks in the background. Each
+// event is delivered once, a failed delivery is only logged.
+type webhookNotifier struct {
+	urls   []string
+	client *http.Client
+}
+
+// newWebhookNotifier returns nil if there are no webhooks.
+func newWebhookNotifier(urls []string, timeout time.Duration) *webhookNotifier {
+	if len(urls) == 0 {
+		return nil
+	}
+	return &webhookNotifier{
+		urls:   urls,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// notifyTaskChanges posts the events of the task changed from current, which
+// is the zero Task if the task is created.
+func (s *httpServerImpl) notifyTaskChanges(c *gin.Context, current, task dao.Task) {
+	if s.webhooks == nil {
+		return
+	}
+
+	var types []string
+	if task.AssigneeID != 0 && task.AssigneeID != current.AssigneeID {
+		types = append(types, webhookEventTaskAssigned)
+	}
+	if task.Status == dao.TaskStatusComplete && current.Status != dao.TaskStatusComplete {
+		types = append(types, webhookEventTaskCompleted)
+	}
+
+	for _, eventType := range types {
+		s.webhooks.post(s.loggerOf(c), WebhookEvent{
+			Type:       eventType,
+			Tenant:     tenantOf(c),
+			Actor:      actorOf(c),
+			Task:       toModelTask(task),
+			RequestID:  requestIDOf(c),
+			OccurredAt: time.Now().UTC(),
+		})
+	}
+}
+
+func (n *webhookNotifier) post(logger *zap.SugaredLogger, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorf("json.Marshal failed, err=%v, type=%v", err, event.Type)
+		return
+	}
+
+	for _, url := range n.urls {
+		go n.deliver(logger, url, event, body)
+	}
+}
+
+func (n *webhookNotifier) deliver(logger *zap.SugaredLogger, url string, event WebhookEvent, body []byte) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		logger.Errorf("http.NewRequest failed, err=%v, url=%v", err, url)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(headerWebhookEvent, event.Type)
+	if event.RequestID != "" {
+		req.Header.Set(headerRequestID, event.RequestID)
+	}
+
+	rsp, err := n.client.Do(req)
+	if err != nil {
+		logger.Errorf("webhook delivery failed, err=%v, url=%v, type=%v", err, url, event.Type)
+		return
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode >= http.StatusMultipleChoices {
+		logger.Errorf("webhook delivery failed, status=%v, url=%v, type=%v", rsp.StatusCode, url, event.Type)
+	}
+}